@@ -23,9 +23,30 @@ type Config struct {
 	RefreshTokenDuration  time.Duration
 	RedisURL              string
 	UploadFolder          string
+	StorageDriver         string
+	S3Endpoint            string
+	S3Region              string
+	S3Bucket              string
+	S3AccessKey           string
+	S3SecretKey           string
+	S3UseSSL              bool
+	S3PresignExpiry       time.Duration
+	MediaMaxUploadSize    int64
+	MediaPDFRenderer      string
+	MediaHEICConverter    string
 	SqlFolder             string
+	MigrationsFolder      string
+	MigrateOnStart        bool
 	MediaCleanerThreshold time.Duration
 	MediaCleanerInterval  string
+	BudgetAlertInterval   string
+	RecurringInterval     string
+	TransferInterval      string
+	ExportInterval        string
+	NetWorthInterval      string
+	IntegrityInterval     string
+	ExportSyncLimit       int
+	InvitationDuration    time.Duration
 }
 
 var AppConfig *Config
@@ -48,9 +69,26 @@ func Load() {
 	viper.SetDefault("AccessTokenRefresh", 15*time.Minute)
 	viper.SetDefault("RefreshTokenDuration", 168*time.Hour)
 	viper.SetDefault("UploadFolder", "./upload")
+	viper.SetDefault("storage.driver", "local")
+	viper.SetDefault("storage.s3.bucket", "shirinec")
+	viper.SetDefault("storage.s3.use_ssl", true)
+	viper.SetDefault("storage.s3.presign_expiry", 15*time.Minute)
+	viper.SetDefault("services.media.max_upload_size", 10<<20)
+	viper.SetDefault("services.media.pdf_renderer", "pdftoppm")
+	viper.SetDefault("services.media.heic_converter", "heif-convert")
 	viper.SetDefault("SqlFolder", "./internal/db/sql")
+	viper.SetDefault("server.migrations_folder", "./migrations")
+	viper.SetDefault("database.migrate_on_start", false)
 	viper.SetDefault("MediaCleanerThreshold", 60*time.Minute)
 	viper.SetDefault("MediaCleanerInterval", "60m")
+	viper.SetDefault("worker.budget_alert_interval", "15m")
+	viper.SetDefault("worker.recurring_transaction_interval", "10m")
+	viper.SetDefault("worker.scheduled_transfer_interval", "10m")
+	viper.SetDefault("worker.export_interval", "1m")
+	viper.SetDefault("worker.networth_snapshot_interval", "1h")
+	viper.SetDefault("worker.ledger_integrity_interval", "6h")
+	viper.SetDefault("services.export.sync_limit", 5000)
+	viper.SetDefault("services.financial_group.invitation_duration", 168*time.Hour)
 
 	viper.AutomaticEnv()
 
@@ -71,9 +109,30 @@ func Load() {
 		RefreshTokenDuration:  viper.GetDuration("services.auth.refresh_token_duration"),
 		RedisURL:              getEnvOrDefault("REDIS_URL", ""),
 		UploadFolder:          viper.GetString("server.upload_folder"),
+		StorageDriver:         viper.GetString("storage.driver"),
+		S3Endpoint:            viper.GetString("storage.s3.endpoint"),
+		S3Region:              viper.GetString("storage.s3.region"),
+		S3Bucket:              viper.GetString("storage.s3.bucket"),
+		S3AccessKey:           getEnvOrDefault("S3_ACCESS_KEY", ""),
+		S3SecretKey:           getEnvOrDefault("S3_SECRET_KEY", ""),
+		S3UseSSL:              viper.GetBool("storage.s3.use_ssl"),
+		S3PresignExpiry:       viper.GetDuration("storage.s3.presign_expiry"),
+		MediaMaxUploadSize:    viper.GetInt64("services.media.max_upload_size"),
+		MediaPDFRenderer:      viper.GetString("services.media.pdf_renderer"),
+		MediaHEICConverter:    viper.GetString("services.media.heic_converter"),
 		SqlFolder:             viper.GetString("server.sql_folder"),
+		MigrationsFolder:      viper.GetString("server.migrations_folder"),
+		MigrateOnStart:        viper.GetBool("database.migrate_on_start"),
 		MediaCleanerThreshold: viper.GetDuration("worker.media_cleaner_threshold"),
 		MediaCleanerInterval:  viper.GetString("worker.media_cleaner_interval"),
+		BudgetAlertInterval:   viper.GetString("worker.budget_alert_interval"),
+		RecurringInterval:     viper.GetString("worker.recurring_transaction_interval"),
+		TransferInterval:      viper.GetString("worker.scheduled_transfer_interval"),
+		ExportInterval:        viper.GetString("worker.export_interval"),
+		NetWorthInterval:      viper.GetString("worker.networth_snapshot_interval"),
+		IntegrityInterval:     viper.GetString("worker.ledger_integrity_interval"),
+		ExportSyncLimit:       viper.GetInt("services.export.sync_limit"),
+		InvitationDuration:    viper.GetDuration("services.financial_group.invitation_duration"),
 	}
 	println(viper.GetInt("database.pool_size"))
 
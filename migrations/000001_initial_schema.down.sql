@@ -0,0 +1,30 @@
+DROP TABLE IF EXISTS account_access;
+DROP TABLE IF EXISTS user_financial_groups;
+DROP TABLE IF EXISTS media_transaction;
+DROP TABLE IF EXISTS purchase_list_items;
+DROP TABLE IF EXISTS items CASCADE;
+DROP TABLE IF EXISTS transactions CASCADE;
+DROP TABLE IF EXISTS accounts CASCADE;
+DROP TABLE IF EXISTS categories CASCADE;
+DROP TABLE IF EXISTS financial_groups CASCADE;
+DROP TABLE IF EXISTS media CASCADE;
+DROP TABLE IF EXISTS profiles CASCADE;
+DROP TABLE IF EXISTS users CASCADE;
+
+DROP FUNCTION IF EXISTS add_financial_group_insert_owner();
+DROP FUNCTION IF EXISTS add_user_to_financial_group_check();
+DROP FUNCTION IF EXISTS check_account_category();
+DROP FUNCTION IF EXISTS check_item_category();
+DROP FUNCTION IF EXISTS update_category_icon_check();
+DROP FUNCTION IF EXISTS update_date_on_change();
+DROP FUNCTION IF EXISTS update_item_image_check();
+DROP FUNCTION IF EXISTS update_profile_picture_check();
+
+DROP TYPE IF EXISTS UserStatus;
+DROP TYPE IF EXISTS UserRole;
+DROP TYPE IF EXISTS TransactionType;
+DROP TYPE IF EXISTS CategoryEntityType;
+DROP TYPE IF EXISTS AccessLevel;
+DROP TYPE IF EXISTS MediaStatus;
+DROP TYPE IF EXISTS MediaAccess;
+DROP TYPE IF EXISTS AccountType;
@@ -1,25 +1,3 @@
-DROP TABLE IF EXISTS users CASCADE;
-DROP TABLE IF EXISTS accounts CASCADE;
-DROP TABLE IF EXISTS categories CASCADE;
-DROP TABLE IF EXISTS transactions CASCADE;
-DROP TABLE IF EXISTS profiles CASCADE;
-DROP TABLE IF EXISTS media CASCADE;
-DROP TABLE IF EXISTS media_transaction;
-DROP TABLE IF EXISTS items CASCADE;
-DROP TABLE IF EXISTS purchase_list_items;
-DROP TABLE IF EXISTS account_access;
-DROP TABLE IF EXISTS financial_groups CASCADE;
-DROP TABLE IF EXISTS user_financial_groups;
-
-DROP TYPE IF EXISTS UserStatus;
-DROP TYPE IF EXISTS UserRole;
-DROP TYPE IF EXISTS TransactionType;
-DROP TYPE IF EXISTS CategoryEntityType;
-DROP TYPE IF EXISTS AccessLevel CASCADE;
-DROP TYPE IF EXISTS MediaStatus CASCADE;
-DROP TYPE IF EXISTS MediaAccess;
-DROP TYPE IF EXISTS AccountType;
-
 CREATE TYPE UserStatus AS ENUM ('banned', 'verified', 'disabled', 'locked', 'pending');
 
 CREATE TYPE UserRole AS ENUM ('admin', 'user');
@@ -161,12 +139,6 @@ CREATE TABLE account_access (
     update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-ALTER TABLE media DROP CONSTRAINT IF EXISTS fk_user_id;
-ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_profile_id;
-ALTER TABLE profiles DROP CONSTRAINT IF EXISTS fk_picture_id;
-ALTER TABLE media DROP CONSTRAINT IF EXISTS fk_financial_group_id;
-ALTER TABLE financial_groups DROP CONSTRAINT IF EXISTS fk_fg_image_id;
-
 ALTER TABLE media ADD CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users(id);
 ALTER TABLE users ADD CONSTRAINT fk_profile_id FOREIGN KEY (profile_id) REFERENCES profiles(id);
 ALTER TABLE profiles ADD CONSTRAINT fk_picture_id FOREIGN KEY (picture_id) REFERENCES media(id);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS validate_transaction_category ON transactions;
+DROP FUNCTION IF EXISTS check_transaction_category();
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS transaction_date;
+
+-- Only transfers are stored without a category, both legs go together
+DELETE FROM transactions WHERE category_id IS NULL;
+ALTER TABLE transactions ALTER COLUMN category_id SET NOT NULL;
+
+ALTER TABLE transactions RENAME COLUMN amount TO amout;
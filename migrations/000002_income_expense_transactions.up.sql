@@ -0,0 +1,40 @@
+-- The column was created with a typo, every query uses "amount"
+ALTER TABLE transactions RENAME COLUMN amout TO amount;
+
+-- Transfers move money between accounts and have no category
+ALTER TABLE transactions ALTER COLUMN category_id DROP NOT NULL;
+
+-- Existing rows only know when they were recorded, that date is the closest
+-- to when the money moved. The backfill is not a user change, update_date is
+-- kept as it is.
+ALTER TABLE transactions ADD COLUMN transaction_date TIMESTAMP;
+ALTER TABLE transactions DISABLE TRIGGER update_date_trigger;
+UPDATE transactions SET transaction_date = creation_date;
+ALTER TABLE transactions ENABLE TRIGGER update_date_trigger;
+ALTER TABLE transactions ALTER COLUMN transaction_date SET DEFAULT CURRENT_TIMESTAMP;
+
+CREATE OR REPLACE FUNCTION check_transaction_category()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.transaction_type = 'transfer' THEN
+        RETURN NEW;
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1
+        FROM categories
+        WHERE id = NEW.category_id
+            AND user_id = NEW.user_id
+            AND entity_type::TEXT = NEW.transaction_type::TEXT
+    ) THEN
+        RAISE EXCEPTION 'Invalid category_id: must reference a category with entity_type %', NEW.transaction_type
+            USING ERRCODE = 'S0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER validate_transaction_category
+BEFORE INSERT OR UPDATE ON transactions
+FOR EACH ROW
+EXECUTE FUNCTION check_transaction_category();
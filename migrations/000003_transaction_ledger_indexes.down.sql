@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS transactions_account_idx;
+DROP INDEX IF EXISTS transactions_user_amount_idx;
+DROP INDEX IF EXISTS transactions_user_date_idx;
@@ -0,0 +1,5 @@
+-- The ledger pages through a user's transactions ordered by date or amount,
+-- the id breaks ties so cursors stay stable.
+CREATE INDEX transactions_user_date_idx ON transactions (user_id, transaction_date, id);
+CREATE INDEX transactions_user_amount_idx ON transactions (user_id, amount, id);
+CREATE INDEX transactions_account_idx ON transactions (account_id);
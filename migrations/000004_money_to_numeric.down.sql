@@ -0,0 +1,11 @@
+ALTER TABLE purchase_list_items
+    ALTER COLUMN unit_price TYPE REAL USING unit_price::REAL;
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE REAL USING amount::REAL;
+
+ALTER TABLE accounts
+    ALTER COLUMN balance DROP NOT NULL,
+    ALTER COLUMN balance DROP DEFAULT,
+    ALTER COLUMN balance TYPE REAL USING balance::REAL,
+    ALTER COLUMN balance SET DEFAULT 0.0;
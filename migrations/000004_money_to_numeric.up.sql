@@ -0,0 +1,14 @@
+-- Money was stored as REAL. The values are rounded to cents, the closest
+-- representation of what users originally entered. The "amout" typo is
+-- already renamed by 000002.
+ALTER TABLE accounts
+    ALTER COLUMN balance DROP DEFAULT,
+    ALTER COLUMN balance TYPE NUMERIC(20, 2) USING ROUND(COALESCE(balance, 0)::NUMERIC, 2),
+    ALTER COLUMN balance SET DEFAULT 0,
+    ALTER COLUMN balance SET NOT NULL;
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE NUMERIC(20, 2) USING ROUND(amount::NUMERIC, 2);
+
+ALTER TABLE purchase_list_items
+    ALTER COLUMN unit_price TYPE NUMERIC(20, 2) USING ROUND(unit_price::NUMERIC, 2);
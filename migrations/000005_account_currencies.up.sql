@@ -0,0 +1,25 @@
+-- Accounts created before currencies existed are all kept in the same one,
+-- the default fills them in and is dropped again since new accounts always
+-- name their currency. Deployments using another base currency update these
+-- accounts before adding exchange rates.
+ALTER TABLE accounts ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$');
+ALTER TABLE accounts ALTER COLUMN currency DROP DEFAULT;
+
+-- Rate applied to the amount of a transfer between accounts in different
+-- currencies, NULL when both use the same one
+ALTER TABLE transactions ADD COLUMN exchange_rate NUMERIC(20, 10) CHECK (exchange_rate > 0);
+
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    base_currency VARCHAR(3) NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency VARCHAR(3) NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, base_currency, quote_currency),
+    CHECK (base_currency <> quote_currency)
+);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON exchange_rates
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
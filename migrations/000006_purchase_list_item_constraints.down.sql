@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS purchase_list_items_transaction_idx;
+
+ALTER TABLE purchase_list_items
+    DROP CONSTRAINT purchase_list_items_unit_price_check,
+    DROP CONSTRAINT purchase_list_items_count_check,
+    DROP CONSTRAINT purchase_list_items_transaction_id_fkey,
+    ADD CONSTRAINT purchase_list_items_transaction_id_fkey
+        FOREIGN KEY (transaction_id) REFERENCES transactions(id);
@@ -0,0 +1,8 @@
+ALTER TABLE purchase_list_items
+    DROP CONSTRAINT purchase_list_items_transaction_id_fkey,
+    ADD CONSTRAINT purchase_list_items_transaction_id_fkey
+        FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    ADD CONSTRAINT purchase_list_items_count_check CHECK (count > 0),
+    ADD CONSTRAINT purchase_list_items_unit_price_check CHECK (unit_price >= 0);
+
+CREATE INDEX purchase_list_items_transaction_idx ON purchase_list_items (transaction_id);
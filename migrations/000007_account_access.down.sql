@@ -0,0 +1,14 @@
+DROP FUNCTION IF EXISTS has_account_access(INT, UUID, AccessLevel);
+DROP FUNCTION IF EXISTS account_access_level(INT, UUID);
+
+DROP INDEX IF EXISTS transactions_account_date_idx;
+DROP INDEX IF EXISTS account_access_user_idx;
+
+ALTER TABLE account_access
+    DROP CONSTRAINT account_access_account_user_key,
+    DROP CONSTRAINT account_access_user_id_fkey,
+    DROP CONSTRAINT account_access_account_id_fkey,
+    ADD CONSTRAINT account_access_account_id_fkey
+        FOREIGN KEY (account_id) REFERENCES accounts(id),
+    ADD CONSTRAINT account_access_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,34 @@
+ALTER TABLE account_access
+    DROP CONSTRAINT account_access_account_id_fkey,
+    DROP CONSTRAINT account_access_user_id_fkey,
+    ADD CONSTRAINT account_access_account_id_fkey
+        FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    ADD CONSTRAINT account_access_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    ADD CONSTRAINT account_access_account_user_key UNIQUE (account_id, user_id);
+
+CREATE INDEX account_access_user_idx ON account_access (user_id);
+CREATE INDEX transactions_account_date_idx ON transactions (account_id, transaction_date, id);
+
+-- The owner of an account always has 'all', other users get what was granted
+-- to them in account_access. NULL means no access at all.
+CREATE OR REPLACE FUNCTION account_access_level(p_account_id INT, p_user_id UUID)
+RETURNS AccessLevel AS $$
+    SELECT CASE
+        WHEN a.user_id = p_user_id THEN 'all'::AccessLevel
+        ELSE (
+            SELECT aa.access
+            FROM account_access aa
+            WHERE aa.account_id = a.id AND aa.user_id = p_user_id
+        )
+    END
+    FROM accounts a
+    WHERE a.id = p_account_id
+$$ LANGUAGE sql STABLE;
+
+-- AccessLevel values are declared in increasing order (view, edit, all) so
+-- they can be compared directly.
+CREATE OR REPLACE FUNCTION has_account_access(p_account_id INT, p_user_id UUID, p_required AccessLevel)
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE(account_access_level(p_account_id, p_user_id) >= p_required, FALSE)
+$$ LANGUAGE sql STABLE;
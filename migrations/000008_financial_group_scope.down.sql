@@ -0,0 +1,45 @@
+CREATE OR REPLACE FUNCTION check_transaction_category()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.transaction_type = 'transfer' THEN
+        RETURN NEW;
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1
+        FROM categories
+        WHERE id = NEW.category_id
+            AND user_id = NEW.user_id
+            AND entity_type::TEXT = NEW.transaction_type::TEXT
+    ) THEN
+        RAISE EXCEPTION 'Invalid category_id: must reference a category with entity_type %', NEW.transaction_type
+            USING ERRCODE = 'S0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS can_use_category(INT, UUID);
+
+CREATE OR REPLACE FUNCTION account_access_level(p_account_id INT, p_user_id UUID)
+RETURNS AccessLevel AS $$
+    SELECT CASE
+        WHEN a.user_id = p_user_id THEN 'all'::AccessLevel
+        ELSE (
+            SELECT aa.access
+            FROM account_access aa
+            WHERE aa.account_id = a.id AND aa.user_id = p_user_id
+        )
+    END
+    FROM accounts a
+    WHERE a.id = p_account_id
+$$ LANGUAGE sql STABLE;
+
+DROP INDEX IF EXISTS user_financial_groups_user_idx;
+DROP INDEX IF EXISTS items_financial_group_idx;
+DROP INDEX IF EXISTS categories_financial_group_idx;
+DROP INDEX IF EXISTS accounts_financial_group_idx;
+
+ALTER TABLE items DROP COLUMN financial_group_id;
+ALTER TABLE categories DROP COLUMN financial_group_id;
+ALTER TABLE accounts DROP COLUMN financial_group_id;
@@ -0,0 +1,76 @@
+ALTER TABLE accounts
+    ADD COLUMN financial_group_id INT REFERENCES financial_groups(id) ON DELETE CASCADE;
+ALTER TABLE categories
+    ADD COLUMN financial_group_id INT REFERENCES financial_groups(id) ON DELETE CASCADE;
+ALTER TABLE items
+    ADD COLUMN financial_group_id INT REFERENCES financial_groups(id) ON DELETE CASCADE;
+
+CREATE INDEX accounts_financial_group_idx ON accounts (financial_group_id);
+CREATE INDEX categories_financial_group_idx ON categories (financial_group_id);
+CREATE INDEX items_financial_group_idx ON items (financial_group_id);
+CREATE INDEX user_financial_groups_user_idx ON user_financial_groups (user_id);
+
+-- Group accounts belong to the group and not to the member who created them:
+-- the group owner has 'all' and other members 'edit'. Explicit grants in
+-- account_access still apply on top of that.
+CREATE OR REPLACE FUNCTION account_access_level(p_account_id INT, p_user_id UUID)
+RETURNS AccessLevel AS $$
+    SELECT GREATEST(
+        CASE
+            WHEN a.financial_group_id IS NULL AND a.user_id = p_user_id THEN 'all'::AccessLevel
+            WHEN fg.user_id = p_user_id THEN 'all'::AccessLevel
+            WHEN EXISTS (
+                SELECT 1
+                FROM user_financial_groups ufg
+                WHERE ufg.financial_group_id = a.financial_group_id AND ufg.user_id = p_user_id
+            ) THEN 'edit'::AccessLevel
+        END,
+        (
+            SELECT aa.access
+            FROM account_access aa
+            WHERE aa.account_id = a.id AND aa.user_id = p_user_id
+        )
+    )
+    FROM accounts a
+    LEFT JOIN financial_groups fg
+        ON fg.id = a.financial_group_id
+    WHERE a.id = p_account_id
+$$ LANGUAGE sql STABLE;
+
+-- A category can be used by its owner, or by any member when it belongs to a
+-- financial group.
+CREATE OR REPLACE FUNCTION can_use_category(p_category_id INT, p_user_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1
+        FROM categories c
+        WHERE c.id = p_category_id
+            AND (
+                (c.financial_group_id IS NULL AND c.user_id = p_user_id)
+                OR
+                c.financial_group_id IN (
+                    SELECT financial_group_id FROM user_financial_groups WHERE user_id = p_user_id
+                )
+            )
+    )
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION check_transaction_category()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.transaction_type = 'transfer' THEN
+        RETURN NEW;
+    END IF;
+
+    IF NOT can_use_category(NEW.category_id, NEW.user_id) OR NOT EXISTS (
+        SELECT 1
+        FROM categories
+        WHERE id = NEW.category_id
+            AND entity_type::TEXT = NEW.transaction_type::TEXT
+    ) THEN
+        RAISE EXCEPTION 'Invalid category_id: must reference a category with entity_type %', NEW.transaction_type
+            USING ERRCODE = 'S0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
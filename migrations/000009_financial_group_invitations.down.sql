@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS financial_group_invitations;
+DROP TYPE IF EXISTS InvitationStatus;
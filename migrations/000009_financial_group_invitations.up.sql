@@ -0,0 +1,25 @@
+CREATE TYPE InvitationStatus AS ENUM ('pending', 'accepted', 'declined', 'revoked', 'expired');
+
+CREATE TABLE financial_group_invitations (
+    id SERIAL PRIMARY KEY,
+    financial_group_id INT NOT NULL REFERENCES financial_groups(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    status InvitationStatus NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A group can have a single pending invitation per email
+CREATE UNIQUE INDEX financial_group_invitations_pending_key
+    ON financial_group_invitations (financial_group_id, LOWER(email))
+    WHERE status = 'pending';
+
+CREATE INDEX financial_group_invitations_email_idx
+    ON financial_group_invitations (LOWER(email))
+    WHERE status = 'pending';
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON financial_group_invitations
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
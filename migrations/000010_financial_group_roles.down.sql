@@ -0,0 +1,42 @@
+DROP TRIGGER update_financial_group_image_on_change ON financial_groups;
+DROP FUNCTION IF EXISTS update_financial_group_image_check();
+CREATE TRIGGER update_item_image_on_change BEFORE UPDATE ON financial_groups
+    FOR EACH ROW EXECUTE PROCEDURE update_item_image_check();
+
+ALTER TABLE financial_group_invitations DROP COLUMN role;
+
+CREATE OR REPLACE FUNCTION account_access_level(p_account_id INT, p_user_id UUID)
+RETURNS AccessLevel AS $$
+    SELECT GREATEST(
+        CASE
+            WHEN a.financial_group_id IS NULL AND a.user_id = p_user_id THEN 'all'::AccessLevel
+            WHEN fg.user_id = p_user_id THEN 'all'::AccessLevel
+            WHEN EXISTS (
+                SELECT 1
+                FROM user_financial_groups ufg
+                WHERE ufg.financial_group_id = a.financial_group_id AND ufg.user_id = p_user_id
+            ) THEN 'edit'::AccessLevel
+        END,
+        (
+            SELECT aa.access
+            FROM account_access aa
+            WHERE aa.account_id = a.id AND aa.user_id = p_user_id
+        )
+    )
+    FROM accounts a
+    LEFT JOIN financial_groups fg
+        ON fg.id = a.financial_group_id
+    WHERE a.id = p_account_id
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION add_financial_group_insert_owner()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO user_financial_groups (financial_group_id, user_id)
+    VALUES (NEW.id, NEW.user_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE user_financial_groups DROP COLUMN role;
+DROP TYPE IF EXISTS FinancialGroupMemberRole;
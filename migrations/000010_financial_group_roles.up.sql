@@ -0,0 +1,90 @@
+-- Values are ordered from the weakest to the strongest role so they can be
+-- compared with >=. The owner is kept in financial_groups.user_id and always
+-- holds the admin role on its membership.
+CREATE TYPE FinancialGroupMemberRole AS ENUM ('viewer', 'contributor', 'admin');
+
+-- Existing members could already edit the group data, contributor keeps that.
+ALTER TABLE user_financial_groups
+    ADD COLUMN role FinancialGroupMemberRole NOT NULL DEFAULT 'contributor';
+
+UPDATE user_financial_groups ufg
+SET role = 'admin'
+FROM financial_groups fg
+WHERE fg.id = ufg.financial_group_id AND fg.user_id = ufg.user_id;
+
+CREATE OR REPLACE FUNCTION add_financial_group_insert_owner()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO user_financial_groups (financial_group_id, user_id, role)
+    VALUES (NEW.id, NEW.user_id, 'admin');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Group accounts: admins have 'all', contributors 'edit' and viewers 'view'.
+-- Explicit grants in account_access still apply on top of that.
+CREATE OR REPLACE FUNCTION account_access_level(p_account_id INT, p_user_id UUID)
+RETURNS AccessLevel AS $$
+    SELECT GREATEST(
+        CASE
+            WHEN a.financial_group_id IS NULL AND a.user_id = p_user_id THEN 'all'::AccessLevel
+            WHEN fg.user_id = p_user_id THEN 'all'::AccessLevel
+            ELSE (
+                SELECT CASE ufg.role
+                    WHEN 'admin' THEN 'all'::AccessLevel
+                    WHEN 'contributor' THEN 'edit'::AccessLevel
+                    ELSE 'view'::AccessLevel
+                END
+                FROM user_financial_groups ufg
+                WHERE ufg.financial_group_id = a.financial_group_id AND ufg.user_id = p_user_id
+            )
+        END,
+        (
+            SELECT aa.access
+            FROM account_access aa
+            WHERE aa.account_id = a.id AND aa.user_id = p_user_id
+        )
+    )
+    FROM accounts a
+    LEFT JOIN financial_groups fg
+        ON fg.id = a.financial_group_id
+    WHERE a.id = p_account_id
+$$ LANGUAGE sql STABLE;
+
+-- Invitations carry the role given to the invitee once accepted
+ALTER TABLE financial_group_invitations
+    ADD COLUMN role FinancialGroupMemberRole NOT NULL DEFAULT 'contributor';
+
+-- Groups used the items image trigger, which validates the image on every
+-- update and so rejected ownership transfers. Only check a changed image.
+CREATE OR REPLACE FUNCTION update_financial_group_image_check()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.image_id IS NOT DISTINCT FROM OLD.image_id THEN
+        RETURN NEW;
+    END IF;
+
+    IF NEW.image_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    IF EXISTS(
+        SELECT 1
+        FROM media
+        WHERE id = NEW.image_id
+        AND user_id = NEW.user_id
+    ) THEN
+        UPDATE media
+        SET status = 'attached'
+        WHERE id = NEW.image_id;
+    ELSE
+        RAISE EXCEPTION 'Foreign key violation: % does not exists in media', NEW.image_id
+            USING ERRCODE = 'S0002';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER update_item_image_on_change ON financial_groups;
+CREATE TRIGGER update_financial_group_image_on_change BEFORE UPDATE ON financial_groups
+    FOR EACH ROW EXECUTE PROCEDURE update_financial_group_image_check();
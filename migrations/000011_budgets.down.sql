@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS budget_alerts;
+DROP TABLE IF EXISTS budgets;
+DROP INDEX IF EXISTS transactions_category_date_idx;
+DROP TYPE IF EXISTS BudgetPeriod;
@@ -0,0 +1,41 @@
+CREATE TYPE BudgetPeriod AS ENUM ('monthly', 'weekly', 'custom');
+
+-- Monthly and weekly budgets repeat every calendar month or week (weeks start
+-- on Monday) from the one containing start_date, custom budgets cover the
+-- single start_date to end_date range.
+CREATE TABLE budgets (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    amount NUMERIC(20, 2) NOT NULL CHECK (amount > 0),
+    period BudgetPeriod NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    alert_threshold SMALLINT NOT NULL DEFAULT 80 CHECK (alert_threshold BETWEEN 1 AND 100),
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT budgets_custom_range_check CHECK (
+        (period = 'custom' AND end_date IS NOT NULL AND end_date >= start_date)
+        OR
+        (period <> 'custom' AND end_date IS NULL)
+    )
+);
+
+CREATE INDEX budgets_user_idx ON budgets (user_id);
+CREATE INDEX transactions_category_date_idx ON transactions (category_id, transaction_date);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON budgets
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
+
+-- Raised by the budget worker once per budget, period and threshold
+CREATE TABLE budget_alerts (
+    id SERIAL PRIMARY KEY,
+    budget_id INT NOT NULL REFERENCES budgets(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    threshold SMALLINT NOT NULL,
+    spent NUMERIC(20, 2) NOT NULL,
+    budget_limit NUMERIC(20, 2) NOT NULL,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT budget_alerts_period_threshold_key UNIQUE (budget_id, period_start, threshold)
+);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS transactions_recurring_occurrence_key;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS recurring_occurrence_date,
+    DROP COLUMN IF EXISTS recurring_transaction_id;
+
+DROP TABLE IF EXISTS recurring_transaction_overrides;
+DROP TABLE IF EXISTS recurring_transactions;
+DROP TYPE IF EXISTS RecurrenceFrequency;
@@ -0,0 +1,56 @@
+CREATE TYPE RecurrenceFrequency AS ENUM ('daily', 'weekly', 'monthly', 'yearly');
+
+-- A rule repeats every repeat_interval days, weeks, months or years counting
+-- from start_date. Monthly and yearly rules keep the day of start_date and use
+-- the last day of shorter months. occurrence_index is the position of the
+-- first occurrence that was not materialized yet and next_occurrence its date,
+-- it is NULL once the rule is past end_date.
+CREATE TABLE recurring_transactions (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    transaction_type TransactionType NOT NULL CHECK (transaction_type IN ('income', 'expense')),
+    amount NUMERIC(20, 2) NOT NULL CHECK (amount > 0),
+    description TEXT,
+    frequency RecurrenceFrequency NOT NULL,
+    repeat_interval SMALLINT NOT NULL DEFAULT 1 CHECK (repeat_interval >= 1),
+    start_date DATE NOT NULL,
+    end_date DATE CHECK (end_date >= start_date),
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    occurrence_index INT NOT NULL DEFAULT 0,
+    next_occurrence DATE,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX recurring_transactions_user_idx ON recurring_transactions (user_id);
+CREATE INDEX recurring_transactions_due_idx ON recurring_transactions (next_occurrence) WHERE NOT paused;
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON recurring_transactions
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
+
+-- Changes a single upcoming occurrence, it is either skipped or materialized
+-- with the amount and description set here instead of the rule ones.
+CREATE TABLE recurring_transaction_overrides (
+    id SERIAL PRIMARY KEY,
+    recurring_transaction_id INT NOT NULL REFERENCES recurring_transactions(id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    skip BOOLEAN NOT NULL DEFAULT FALSE,
+    amount NUMERIC(20, 2) CHECK (amount > 0),
+    description TEXT,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT recurring_transaction_overrides_occurrence_key UNIQUE (recurring_transaction_id, occurrence_date)
+);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON recurring_transaction_overrides
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
+
+-- Every occurrence is materialized at most once, the worker relies on this
+-- index when several instances or a retry after downtime race on a rule.
+ALTER TABLE transactions
+    ADD COLUMN recurring_transaction_id INT REFERENCES recurring_transactions(id) ON DELETE SET NULL,
+    ADD COLUMN recurring_occurrence_date DATE;
+
+CREATE UNIQUE INDEX transactions_recurring_occurrence_key ON transactions (recurring_transaction_id, recurring_occurrence_date);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS scheduled_transfer_runs;
+DROP TABLE IF EXISTS scheduled_transfers;
+DROP TYPE IF EXISTS ScheduledTransferRunStatus;
+DROP TYPE IF EXISTS ScheduledTransferStatus;
@@ -0,0 +1,45 @@
+CREATE TYPE ScheduledTransferStatus AS ENUM ('active', 'finished', 'cancelled');
+
+CREATE TYPE ScheduledTransferRunStatus AS ENUM ('succeeded', 'failed');
+
+-- A transfer without frequency runs once on start_date, otherwise it repeats
+-- like a recurring transaction. The accounts are set to NULL when they are
+-- deleted so the following runs are recorded as failed instead of the
+-- schedule disappearing.
+CREATE TABLE scheduled_transfers (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_account_id INT REFERENCES accounts(id) ON DELETE SET NULL,
+    dest_account_id INT REFERENCES accounts(id) ON DELETE SET NULL,
+    amount NUMERIC(20, 2) NOT NULL CHECK (amount > 0),
+    exchange_rate NUMERIC(20, 10) CHECK (exchange_rate > 0),
+    frequency RecurrenceFrequency,
+    repeat_interval SMALLINT NOT NULL DEFAULT 1 CHECK (repeat_interval >= 1),
+    start_date DATE NOT NULL,
+    end_date DATE CHECK (end_date >= start_date),
+    status ScheduledTransferStatus NOT NULL DEFAULT 'active',
+    occurrence_index INT NOT NULL DEFAULT 0,
+    next_run DATE,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX scheduled_transfers_user_idx ON scheduled_transfers (user_id);
+CREATE INDEX scheduled_transfers_due_idx ON scheduled_transfers (next_run) WHERE status = 'active';
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON scheduled_transfers
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
+
+-- One row per executed occurrence, written in the same database transaction
+-- as the transfer itself.
+CREATE TABLE scheduled_transfer_runs (
+    id SERIAL PRIMARY KEY,
+    scheduled_transfer_id INT NOT NULL REFERENCES scheduled_transfers(id) ON DELETE CASCADE,
+    run_date DATE NOT NULL,
+    status ScheduledTransferRunStatus NOT NULL,
+    error_message TEXT,
+    from_transaction_id INT REFERENCES transactions(id) ON DELETE SET NULL,
+    dest_transaction_id INT REFERENCES transactions(id) ON DELETE SET NULL,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduled_transfer_runs_date_key UNIQUE (scheduled_transfer_id, run_date)
+);
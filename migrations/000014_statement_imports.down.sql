@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS statement_import_rows;
+DROP TABLE IF EXISTS statement_imports;
+DROP TYPE IF EXISTS StatementImportStatus;
+DROP TYPE IF EXISTS StatementFormat;
@@ -0,0 +1,41 @@
+CREATE TYPE StatementFormat AS ENUM ('csv', 'ofx', 'qif');
+
+CREATE TYPE StatementImportStatus AS ENUM ('pending', 'committed');
+
+-- A parsed bank statement waiting for the user to review it. Nothing touches
+-- the account until the import is committed, discarding a pending import
+-- deletes it.
+CREATE TABLE statement_imports (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    format StatementFormat NOT NULL,
+    file_name TEXT NOT NULL,
+    status StatementImportStatus NOT NULL DEFAULT 'pending',
+    commit_date TIMESTAMP,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX statement_imports_user_idx ON statement_imports (user_id);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON statement_imports
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
+
+-- duplicate_of points at an existing transaction that looks the same, rows
+-- with one are skipped on commit unless the user asks otherwise.
+-- transaction_id is set for the rows that were committed.
+CREATE TABLE statement_import_rows (
+    id SERIAL PRIMARY KEY,
+    import_id INT NOT NULL REFERENCES statement_imports(id) ON DELETE CASCADE,
+    line_number INT NOT NULL,
+    transaction_date DATE NOT NULL,
+    amount NUMERIC(20, 2) NOT NULL,
+    description TEXT,
+    external_id TEXT,
+    duplicate_of INT REFERENCES transactions(id) ON DELETE SET NULL,
+    transaction_id INT REFERENCES transactions(id) ON DELETE SET NULL
+);
+
+CREATE INDEX statement_import_rows_import_idx ON statement_import_rows (import_id, id);
+CREATE INDEX statement_import_rows_transaction_idx ON statement_import_rows (transaction_id) WHERE transaction_id IS NOT NULL;
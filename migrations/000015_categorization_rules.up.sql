@@ -0,0 +1,23 @@
+-- Rules pick the category of income and expense transactions that are
+-- created or imported without one. Every condition that is set must match,
+-- amounts are compared without their sign and the rule with the lowest
+-- priority wins.
+CREATE TABLE categorization_rules (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    transaction_type TransactionType NOT NULL CHECK (transaction_type IN ('income', 'expense')),
+    priority INT NOT NULL,
+    account_id INT REFERENCES accounts(id) ON DELETE CASCADE,
+    description_contains TEXT,
+    min_amount NUMERIC(20, 2) CHECK (min_amount >= 0),
+    max_amount NUMERIC(20, 2) CHECK (max_amount >= min_amount),
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (account_id IS NOT NULL OR description_contains IS NOT NULL OR min_amount IS NOT NULL OR max_amount IS NOT NULL)
+);
+
+CREATE INDEX categorization_rules_user_priority_idx ON categorization_rules (user_id, transaction_type, priority, id);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON categorization_rules
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS export_jobs;
+DROP TYPE IF EXISTS ExportJobStatus;
+DROP TYPE IF EXISTS ExportFormat;
+DELETE FROM media WHERE financial_group_id IS NULL;
+ALTER TABLE media ALTER COLUMN financial_group_id SET NOT NULL;
@@ -0,0 +1,29 @@
+-- Files created for a user alone, like exports, do not belong to a group
+ALTER TABLE media ALTER COLUMN financial_group_id DROP NOT NULL;
+
+CREATE TYPE ExportFormat AS ENUM ('csv', 'json', 'ofx');
+
+CREATE TYPE ExportJobStatus AS ENUM ('pending', 'running', 'completed', 'failed');
+
+-- Exports too large to stream are written by a worker. The file is saved as
+-- temporary media so the media cleaner removes it after its threshold,
+-- media_id becomes NULL at that point.
+CREATE TABLE export_jobs (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    format ExportFormat NOT NULL,
+    from_date DATE,
+    to_date DATE CHECK (to_date >= from_date),
+    status ExportJobStatus NOT NULL DEFAULT 'pending',
+    media_id INT REFERENCES media(id) ON DELETE SET NULL,
+    error_message TEXT,
+    completion_date TIMESTAMP,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX export_jobs_user_idx ON export_jobs (user_id);
+CREATE INDEX export_jobs_queue_idx ON export_jobs (update_date) WHERE status IN ('pending', 'running');
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON export_jobs
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
@@ -0,0 +1,15 @@
+-- Balance of every account at the end of each day. The snapshot worker keeps
+-- the current day up to date, older days are rebuilt from the transactions by
+-- the backfill.
+CREATE TABLE account_balance_snapshots (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL,
+    balance NUMERIC(20, 2) NOT NULL,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT account_balance_snapshots_date_key UNIQUE (account_id, snapshot_date)
+);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON account_balance_snapshots
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
@@ -0,0 +1,26 @@
+-- Opening balances and balance adjustments are recorded as transactions so the
+-- balance of an account always equals the sum of its transactions. The values
+-- can not be used in the transaction adding them, the constraints relying on
+-- them are in the next migration.
+ALTER TYPE TransactionType ADD VALUE IF NOT EXISTS 'opening';
+ALTER TYPE TransactionType ADD VALUE IF NOT EXISTS 'adjustment';
+
+CREATE OR REPLACE FUNCTION check_transaction_category()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.transaction_type::TEXT IN ('transfer', 'opening', 'adjustment') THEN
+        RETURN NEW;
+    END IF;
+
+    IF NOT can_use_category(NEW.category_id, NEW.user_id) OR NOT EXISTS (
+        SELECT 1
+        FROM categories
+        WHERE id = NEW.category_id
+            AND entity_type::TEXT = NEW.transaction_type::TEXT
+    ) THEN
+        RAISE EXCEPTION 'Invalid category_id: must reference a category with entity_type %', NEW.transaction_type
+            USING ERRCODE = 'S0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
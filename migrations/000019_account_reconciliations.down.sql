@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS account_balance_discrepancies;
+DROP TABLE IF EXISTS account_reconciliations;
+DROP INDEX IF EXISTS transactions_opening_key;
@@ -0,0 +1,53 @@
+CREATE UNIQUE INDEX transactions_opening_key ON transactions (account_id) WHERE transaction_type = 'opening';
+
+-- Existing accounts got their starting balance without a transaction, what
+-- their transactions do not explain becomes their opening balance.
+INSERT INTO transactions (user_id, account_id, amount, description, transaction_type, transaction_date, creation_date, update_date)
+SELECT
+    a.user_id,
+    a.id,
+    a.balance - COALESCE(l.total, 0),
+    'Opening balance',
+    'opening',
+    COALESCE(LEAST(a.creation_date, l.first_date), CURRENT_TIMESTAMP),
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+FROM accounts a
+LEFT JOIN (
+    SELECT account_id, SUM(amount) AS total, MIN(transaction_date) AS first_date
+    FROM transactions
+    GROUP BY account_id
+) l
+    ON l.account_id = a.id
+WHERE a.balance <> COALESCE(l.total, 0);
+
+-- Balance of the bank statement compared with the transactions recorded up to
+-- the end of statement_date, difference is statement minus ledger.
+CREATE TABLE account_reconciliations (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    statement_date DATE NOT NULL,
+    statement_balance NUMERIC(20, 2) NOT NULL,
+    ledger_balance NUMERIC(20, 2) NOT NULL,
+    difference NUMERIC(20, 2) NOT NULL,
+    adjustment_transaction_id INT REFERENCES transactions(id) ON DELETE SET NULL,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX account_reconciliations_account_idx ON account_reconciliations (account_id, statement_date);
+
+-- Accounts whose stored balance is not the sum of their transactions, kept
+-- up to date by the ledger integrity worker.
+CREATE TABLE account_balance_discrepancies (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL UNIQUE REFERENCES accounts(id) ON DELETE CASCADE,
+    stored_balance NUMERIC(20, 2) NOT NULL,
+    ledger_balance NUMERIC(20, 2) NOT NULL,
+    detection_date TIMESTAMP NOT NULL,
+    creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    update_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_date_trigger BEFORE UPDATE ON account_balance_discrepancies
+    FOR EACH ROW EXECUTE PROCEDURE update_date_on_change();
@@ -0,0 +1,20 @@
+-- The balances already include the reversals, removing them means putting
+-- their amount back on the accounts.
+UPDATE accounts a
+SET balance = a.balance - r.total
+FROM (
+    SELECT account_id, SUM(amount) AS total
+    FROM transactions
+    WHERE status = 'reversal'
+    GROUP BY account_id
+) r
+WHERE a.id = r.account_id;
+
+UPDATE transactions SET linked_transaction_id = NULL WHERE status = 'reversal';
+DELETE FROM transactions WHERE status = 'reversal';
+
+DROP INDEX IF EXISTS transactions_reversal_of_key;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS reversal_of_id,
+    DROP COLUMN IF EXISTS status;
+DROP TYPE IF EXISTS TransactionStatus;
@@ -0,0 +1,11 @@
+-- Transactions are never removed to undo them, a reversal row with the negated
+-- amount is recorded and the original is marked voided. Reports skip both so
+-- a voided transaction no longer counts while the ledger keeps its history.
+CREATE TYPE TransactionStatus AS ENUM ('posted', 'voided', 'reversal');
+
+ALTER TABLE transactions
+    ADD COLUMN status TransactionStatus NOT NULL DEFAULT 'posted',
+    ADD COLUMN reversal_of_id INT REFERENCES transactions(id);
+
+-- A transaction can only be reversed once
+CREATE UNIQUE INDEX transactions_reversal_of_key ON transactions (reversal_of_id) WHERE reversal_of_id IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS media_transaction_transaction_idx;
+ALTER TABLE media_transaction
+    DROP CONSTRAINT IF EXISTS media_transaction_media_transaction_key,
+    DROP COLUMN IF EXISTS creation_date;
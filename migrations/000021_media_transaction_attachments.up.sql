@@ -0,0 +1,13 @@
+-- Receipts and documents are attached to transactions through media_transaction,
+-- a media can only be attached once to the same transaction.
+DELETE FROM media_transaction mt
+USING media_transaction duplicate
+WHERE duplicate.media_id = mt.media_id
+    AND duplicate.transaction_id = mt.transaction_id
+    AND duplicate.id < mt.id;
+
+ALTER TABLE media_transaction
+    ADD COLUMN creation_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD CONSTRAINT media_transaction_media_transaction_key UNIQUE (media_id, transaction_id);
+
+CREATE INDEX media_transaction_transaction_idx ON media_transaction (transaction_id);
@@ -0,0 +1,20 @@
+-- Uncategorized income and expenses are kept, only new ones are rejected again
+CREATE OR REPLACE FUNCTION check_transaction_category()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.transaction_type::TEXT IN ('transfer', 'opening', 'adjustment') THEN
+        RETURN NEW;
+    END IF;
+
+    IF NOT can_use_category(NEW.category_id, NEW.user_id) OR NOT EXISTS (
+        SELECT 1
+        FROM categories
+        WHERE id = NEW.category_id
+            AND entity_type::TEXT = NEW.transaction_type::TEXT
+    ) THEN
+        RAISE EXCEPTION 'Invalid category_id: must reference a category with entity_type %', NEW.transaction_type
+            USING ERRCODE = 'S0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"strconv"
 
@@ -13,6 +14,7 @@ import (
 	"shirinec.com/src/internal/handlers"
 	"shirinec.com/src/internal/repositories"
 	"shirinec.com/src/internal/routes"
+	"shirinec.com/src/internal/storage"
 	"shirinec.com/src/internal/utils"
 	"shirinec.com/src/internal/validators"
 	"shirinec.com/src/internal/workers"
@@ -27,8 +29,22 @@ func main() {
 	}
 	defer database.Close()
 
+	if config.AppConfig.MigrateOnStart {
+		migrator := db.NewMigrator(database.Pool, config.AppConfig.MigrationsFolder)
+		applied, err := migrator.Up(context.Background(), 0)
+		if err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+		log.Printf("%d migrations applied", applied)
+	}
+
 	db.NewRedis()
 
+	mediaStorage, err := storage.New(context.Background(), config.AppConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up media storage: %v", err)
+	}
+
 	userRepo := repositories.NewUserRepository(database.Pool)
 	categoryRepo := repositories.NewCategoryRepository(database.Pool)
 	itemRepo := repositories.NewItemRepository(database.Pool)
@@ -36,19 +52,48 @@ func main() {
 	mediaRepo := repositories.NewMediaRepository(database.Pool)
 	financialGroupRepo := repositories.NewFinancialGroupRepository(database.Pool)
 	transactionRepo := repositories.NewTransactionRepository(database.Pool)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(database.Pool)
+	purchaseListItemRepo := repositories.NewPurchaseListItemRepository(database.Pool)
+	accountAccessRepo := repositories.NewAccountAccessRepository(database.Pool)
+	financialGroupInvitationRepo := repositories.NewFinancialGroupInvitationRepository(database.Pool)
+	budgetRepo := repositories.NewBudgetRepository(database.Pool)
+	recurringTransactionRepo := repositories.NewRecurringTransactionRepository(database.Pool)
+	scheduledTransferRepo := repositories.NewScheduledTransferRepository(database.Pool)
+	statementImportRepo := repositories.NewStatementImportRepository(database.Pool)
+	categorizationRuleRepo := repositories.NewCategorizationRuleRepository(database.Pool)
+	exportRepo := repositories.NewExportRepository(database.Pool)
+	reportRepo := repositories.NewReportRepository(database.Pool)
+	netWorthRepo := repositories.NewNetWorthRepository(database.Pool)
+	reconciliationRepo := repositories.NewReconciliationRepository(database.Pool)
+	transactionAttachmentRepo := repositories.NewTransactionAttachmentRepository(database.Pool)
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		validators.RegisterValidators(v)
 	}
 
 	deps := handler.Dependencies{
-		UserRepo:           userRepo,
-		CategoryRepo:       categoryRepo,
-		ItemRepo:           itemRepo,
-		AccountRepo:        accountRepo,
-		MediaRepo:          mediaRepo,
-		FinancialGroupRepo: financialGroupRepo,
-		TransactionRepo:    transactionRepo,
+		UserRepo:                     userRepo,
+		CategoryRepo:                 categoryRepo,
+		ItemRepo:                     itemRepo,
+		AccountRepo:                  accountRepo,
+		MediaRepo:                    mediaRepo,
+		FinancialGroupRepo:           financialGroupRepo,
+		TransactionRepo:              transactionRepo,
+		ExchangeRateRepo:             exchangeRateRepo,
+		PurchaseListItemRepo:         purchaseListItemRepo,
+		AccountAccessRepo:            accountAccessRepo,
+		FinancialGroupInvitationRepo: financialGroupInvitationRepo,
+		BudgetRepo:                   budgetRepo,
+		RecurringTransactionRepo:     recurringTransactionRepo,
+		ScheduledTransferRepo:        scheduledTransferRepo,
+		StatementImportRepo:          statementImportRepo,
+		CategorizationRuleRepo:       categorizationRuleRepo,
+		ExportRepo:                   exportRepo,
+		ReportRepo:                   reportRepo,
+		NetWorthRepo:                 netWorthRepo,
+		ReconciliationRepo:           reconciliationRepo,
+		TransactionAttachmentRepo:    transactionAttachmentRepo,
+		Storage:                      mediaStorage,
 	}
 
 	utils.InitLogger()
@@ -58,7 +103,7 @@ func main() {
 	router := routes.NewRouter(ginEngine, &deps, database.Pool)
 	router.SetupRouter()
 
-	workers.ScheduleWorkers(mediaRepo)
+	workers.ScheduleWorkers(mediaRepo, budgetRepo, recurringTransactionRepo, scheduledTransferRepo, exportRepo, netWorthRepo, reconciliationRepo, mediaStorage)
 
 	for _, route := range ginEngine.Routes() {
 		log.Println(route.Method, route.Path)
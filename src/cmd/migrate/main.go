@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"shirinec.com/config"
+	"shirinec.com/src/internal/db"
+)
+
+const usage = `Usage: migrate <command> [steps]
+
+Commands:
+  up [steps]     apply pending migrations, all of them when steps is omitted
+  down [steps]   revert the latest applied migrations, one when steps is omitted
+  status         list migrations and whether they are applied
+  baseline       mark the initial schema as applied on a database created
+                 before the migrations existed, then apply the pending ones`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	steps := 0
+	if len(os.Args) > 2 {
+		var err error
+		steps, err = strconv.Atoi(os.Args[2])
+		if err != nil || steps < 1 {
+			fmt.Println(usage)
+			os.Exit(2)
+		}
+	}
+
+	config.Load()
+
+	database, err := db.NewDatabase()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	migrator := db.NewMigrator(database.Pool, config.AppConfig.MigrationsFolder)
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx, steps)
+		if err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+		log.Printf("%d migrations applied", applied)
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatalf("Failed to revert migrations: %v", err)
+		}
+		log.Printf("%d migrations reverted", reverted)
+	case "baseline":
+		if err := migrator.Baseline(ctx); err != nil {
+			log.Fatalf("Failed to baseline the database: %v", err)
+		}
+		log.Printf("Initial schema marked as applied")
+		applied, err := migrator.Up(ctx, 0)
+		if err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+		log.Printf("%d migrations applied", applied)
+	case "status":
+		statusList, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migrations status: %v", err)
+		}
+		for _, status := range statusList {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.Missing {
+				state += " (file missing)"
+			}
+			fmt.Printf("%06d_%s\t%s\n", status.Version, status.Name, state)
+		}
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+}
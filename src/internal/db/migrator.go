@@ -0,0 +1,354 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockID is the key of the postgres advisory lock held while
+// migrations run, so two instances starting together never apply the same
+// migration twice.
+const migrationLockID int64 = 7_325_014_522
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	ErrMissingDownMigration = errors.New("down migration file is missing")
+	// ErrUnversionedDatabase is returned by Up for databases created before
+	// the migrations existed, they have to be adopted with Baseline first.
+	ErrUnversionedDatabase = errors.New("database has tables but no migration history, run the baseline command first")
+	ErrAlreadyVersioned    = errors.New("database already has a migration history")
+)
+
+// baselineVersion is the migration holding the schema databases created
+// before the migrations existed already have.
+const baselineVersion int64 = 1
+
+type Migration struct {
+	Version  int64
+	Name     string
+	UpFile   string
+	DownFile string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Missing is set when the database has a version with no file on disk.
+	Missing bool
+}
+
+type Migrator struct {
+	pool   *pgxpool.Pool
+	folder string
+}
+
+func NewMigrator(pool *pgxpool.Pool, folder string) *Migrator {
+	return &Migrator{pool: pool, folder: folder}
+}
+
+// Up applies pending migrations in version order, steps <= 0 applies all of
+// them. It returns the number of applied migrations.
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	migrations, err := m.load()
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	err = m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(done) == 0 {
+			unversioned, err := hasExistingSchema(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if unversioned {
+				return ErrUnversionedDatabase
+			}
+		}
+
+		for _, migration := range migrations {
+			if steps > 0 && applied >= steps {
+				break
+			}
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			log.Printf("Applying migration %d_%s", migration.Version, migration.Name)
+			insert := "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)"
+			if err := m.run(ctx, conn, migration.UpFile, insert, migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Baseline adopts a database created before the migrations existed, the
+// initial schema migration is recorded as applied without running it. The
+// later migrations then upgrade the database with Up.
+func (m *Migrator) Baseline(ctx context.Context) error {
+	migrations, err := m.load()
+	if err != nil {
+		return err
+	}
+	var baseline *Migration
+	for i := range migrations {
+		if migrations[i].Version == baselineVersion {
+			baseline = &migrations[i]
+		}
+	}
+	if baseline == nil {
+		return fmt.Errorf("migration %d is missing", baselineVersion)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(done) > 0 {
+			return ErrAlreadyVersioned
+		}
+
+		log.Printf("Marking migration %d_%s as applied", baseline.Version, baseline.Name)
+		insert := "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)"
+		_, err = conn.Exec(ctx, insert, baseline.Version, baseline.Name)
+		return err
+	})
+}
+
+// Down reverts the latest applied migrations, steps <= 0 reverts one.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	migrations, err := m.load()
+	if err != nil {
+		return 0, err
+	}
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	reverted := 0
+	err = m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT $1", steps)
+		if err != nil {
+			return err
+		}
+		versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return err
+		}
+
+		for _, version := range versions {
+			migration, ok := byVersion[version]
+			if !ok || migration.DownFile == "" {
+				return fmt.Errorf("migration %d: %w", version, ErrMissingDownMigration)
+			}
+
+			log.Printf("Reverting migration %d_%s", migration.Version, migration.Name)
+			remove := "DELETE FROM schema_migrations WHERE version = $1"
+			if err := m.run(ctx, conn, migration.DownFile, remove, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration, applied or not, in version order.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var done map[int64]migrationRecord
+	err = m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		done, err = appliedVersions(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := done[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(done, migration.Version)
+		}
+		result = append(result, status)
+	}
+	for version, record := range done {
+		appliedAt := record.AppliedAt
+		result = append(result, MigrationStatus{Version: version, Name: record.Name, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result, nil
+}
+
+// run executes a migration file and its bookkeeping statement in a single
+// transaction, a failing migration leaves neither the schema nor
+// schema_migrations half updated.
+func (m *Migrator) run(ctx context.Context, conn *pgxpool.Conn, file, bookkeeping string, args ...any) (err error) {
+	content, err := os.ReadFile(filepath.Join(m.folder, file))
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, string(content)); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock,
+// the tracking table is created first so fn can always read it.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	createTable := `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version BIGINT PRIMARY KEY,
+            name TEXT NOT NULL,
+            applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )
+    `
+	if _, err := conn.Exec(ctx, createTable); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// load reads the migrations folder, files are named
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+func (m *Migrator) load() ([]Migration, error) {
+	entries, err := os.ReadDir(m.folder)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %q: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.UpFile = entry.Name()
+		} else {
+			migration.DownFile = entry.Name()
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpFile == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// hasExistingSchema reports whether the tables of the initial schema exist,
+// the users table is the first one it creates.
+func hasExistingSchema(ctx context.Context, conn *pgxpool.Conn) (bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT to_regclass('users') IS NOT NULL").Scan(&exists)
+	return exists, err
+}
+
+type migrationRecord struct {
+	Name      string
+	AppliedAt time.Time
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]migrationRecord, error) {
+	rows, err := conn.Query(ctx, "SELECT version, name, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int64]migrationRecord)
+	for rows.Next() {
+		var version int64
+		var record migrationRecord
+		if err := rows.Scan(&version, &record.Name, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		result[version] = record
+	}
+	return result, rows.Err()
+}
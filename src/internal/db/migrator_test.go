@@ -0,0 +1,115 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMigratorLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name:  "sorted by version",
+			files: []string{"000010_add_index.up.sql", "000002_add_table.up.sql", "000002_add_table.down.sql", "000010_add_index.down.sql"},
+			want: []Migration{
+				{Version: 2, Name: "add_table", UpFile: "000002_add_table.up.sql", DownFile: "000002_add_table.down.sql"},
+				{Version: 10, Name: "add_index", UpFile: "000010_add_index.up.sql", DownFile: "000010_add_index.down.sql"},
+			},
+		},
+		{
+			name:  "versions may have gaps and no padding",
+			files: []string{"1_initial.up.sql", "1_initial.down.sql", "7_later.up.sql"},
+			want: []Migration{
+				{Version: 1, Name: "initial", UpFile: "1_initial.up.sql", DownFile: "1_initial.down.sql"},
+				{Version: 7, Name: "later", UpFile: "7_later.up.sql"},
+			},
+		},
+		{
+			name: "other files are ignored",
+			files: []string{
+				"000001_initial.up.sql",
+				"README.md",
+				"000002_Upper_Case.up.sql",
+				"000003_no_direction.sql",
+				"000004_wrong.sideways.sql",
+				"v5_prefixed.up.sql",
+				"000006_backup.up.sql.bak",
+			},
+			want: []Migration{
+				{Version: 1, Name: "initial", UpFile: "000001_initial.up.sql"},
+			},
+		},
+		{
+			name:  "no files",
+			files: nil,
+			want:  []Migration{},
+		},
+		{
+			name:    "down file without up file",
+			files:   []string{"000001_initial.up.sql", "000002_orphan.down.sql"},
+			wantErr: true,
+		},
+		{
+			name:    "version used twice",
+			files:   []string{"000002_first.up.sql", "000002_second.up.sql"},
+			wantErr: true,
+		},
+		{
+			name:    "version out of range",
+			files:   []string{"99999999999999999999_huge.up.sql"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			folder := t.TempDir()
+			for _, file := range test.files {
+				if err := os.WriteFile(filepath.Join(folder, file), []byte("SELECT 1;"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Folders are skipped even when their name looks like a migration
+			if err := os.Mkdir(filepath.Join(folder, "000099_folder.up.sql"), 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := NewMigrator(nil, folder).load()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("load error = %v, want error %t", err, test.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("load = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMigratorLoadMissingFolder(t *testing.T) {
+	if _, err := NewMigrator(nil, filepath.Join(t.TempDir(), "missing")).load(); err == nil {
+		t.Fatal("load of a missing folder succeeded")
+	}
+}
+
+// TestMigrationsFolder checks the migrations shipped with the repository, the
+// first one is the baseline and every migration can be reverted.
+func TestMigrationsFolder(t *testing.T) {
+	migrations, err := NewMigrator(nil, filepath.Join("..", "..", "..", "migrations")).load()
+	if err != nil {
+		t.Fatalf("load error = %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != baselineVersion {
+		t.Fatalf("the first migration must be the baseline version %d, got %+v", baselineVersion, migrations)
+	}
+	for _, migration := range migrations {
+		if migration.DownFile == "" {
+			t.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+		}
+	}
+}
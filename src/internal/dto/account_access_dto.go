@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type AccountAccessCreateRequest struct {
+	Email  string            `json:"email" binding:"required,email"`
+	Access enums.AccessLevel `json:"access" binding:"required,oneof=view edit all"`
+}
+
+type AccountAccessUpdateRequest struct {
+	Access enums.AccessLevel `json:"access" binding:"required,oneof=view edit all"`
+}
+
+type AccountAccessResponse struct {
+	ID           int               `json:"id"`
+	AccountID    int               `json:"accountID"`
+	UserID       uuid.UUID         `json:"userID"`
+	Email        string            `json:"email"`
+	Access       enums.AccessLevel `json:"access"`
+	CreationDate time.Time         `json:"creationDate"`
+	UpdateDate   time.Time         `json:"updateDate"`
+}
+
+type AccountAccessListResponse struct {
+	AccountAccess *[]AccountAccessResponse `json:"accountAccess"`
+}
@@ -5,27 +5,35 @@ import (
 
 	"github.com/google/uuid"
 	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
 )
 
 type AccountJoinedResponse struct {
-	ID              int               `json:"id"`
-	UserID          uuid.UUID         `json:"userID"`
-	Name            string            `json:"name"`
-	CategoryID      int               `json:"categoryID"`
-	CategoryName    string            `json:"categoryName"`
-	CategoryColor   string            `json:"categoryColor"`
-	CategoryIconURL *string           `json:"categoryIconURL"`
-	Balance         float64           `json:"balance"`
-	CreationDate    time.Time         `json:"creationDate"`
-	UpdateDate      time.Time         `json:"updateDate"`
-	Type            enums.AccountType `json:"accountType"`
+	ID               int               `json:"id"`
+	UserID           uuid.UUID         `json:"userID"`
+	Name             string            `json:"name"`
+	CategoryID       int               `json:"categoryID"`
+	CategoryName     string            `json:"categoryName"`
+	CategoryColor    string            `json:"categoryColor"`
+	CategoryIconURL  *string           `json:"categoryIconURL"`
+	Balance          models.Money      `json:"balance"`
+	CreationDate     time.Time         `json:"creationDate"`
+	UpdateDate       time.Time         `json:"updateDate"`
+	Type             enums.AccountType `json:"accountType"`
+	Currency         string            `json:"currency"`
+	Access           enums.AccessLevel `json:"access"`
+	FinancialGroupID *int              `json:"financialGroupID"`
 }
 
+// AccountCreateRequest.Balance becomes the opening balance transaction, a
+// zero or missing balance creates the account without one.
 type AccountCreateRequest struct {
-	Name       string            `json:"name" binding:"required,alphaNumericSpace"`
-	CategoryID int               `json:"categoryID" binding:"required,number"`
-	Balance    float64           `json:"balance" binding:"required,number"`
-	Type       enums.AccountType `json:"accountType" binding:"omitempty,accountType"`
+	Name             string            `json:"name" binding:"required,alphaNumericSpace"`
+	CategoryID       int               `json:"categoryID" binding:"required,number"`
+	Balance          models.Money      `json:"balance" binding:"number"`
+	Type             enums.AccountType `json:"accountType" binding:"omitempty,accountType"`
+	Currency         string            `json:"currency" binding:"required,iso4217"`
+	FinancialGroupID *int              `json:"financialGroupID" binding:"omitempty,number"`
 }
 
 type AccountListResponse struct {
@@ -34,21 +42,31 @@ type AccountListResponse struct {
 }
 
 type AccountUpdateRequest struct {
-	Name       *string  `json:"name" binding:"omitempty,alphaNumericSpace"`
-	CategoryID *int     `json:"categoryID" binding:"omitempty,number"`
-	Balance    *float64 `json:"balance" binding:"omitempty,number"`
+	Name       *string       `json:"name" binding:"omitempty,alphaNumericSpace"`
+	CategoryID *int          `json:"categoryID" binding:"omitempty,number"`
+	Balance    *models.Money `json:"balance" binding:"omitempty,number"`
 }
 
 type AccountTransferResultItem struct {
-	ID      int               `json:"id"`
-	Name    string            `json:"name"`
-	Balance float64           `json:"balance"`
-	Change  float64           `json:"change"`
-	Type    enums.AccountType `json:"accountType"`
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	Balance       models.Money      `json:"balance"`
+	Change        models.Money      `json:"change"`
+	Currency      string            `json:"currency"`
+	Type          enums.AccountType `json:"accountType"`
+	TransactionID int               `json:"transactionID"`
 }
 
 type AccountTransferResult struct {
 	From AccountTransferResultItem `json:"from"`
 	Dest AccountTransferResultItem `json:"dest"`
+	Rate *string                   `json:"rate,omitempty"`
 	Date time.Time                 `json:"date"`
 }
+
+// AccountOpeningBalanceRequest sets the balance the account had before its
+// first transaction, the current balance moves by the difference.
+type AccountOpeningBalanceRequest struct {
+	Amount models.Money `json:"amount" binding:"number"`
+	Date   time.Time    `json:"date" binding:"required"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+type BudgetCreateRequest struct {
+	CategoryID     int                `json:"categoryID" binding:"required,number"`
+	Amount         models.Money       `json:"amount" binding:"required,gt=0"`
+	Period         enums.BudgetPeriod `json:"period" binding:"required,oneof=monthly weekly custom"`
+	StartDate      *time.Time         `json:"startDate"`
+	EndDate        *time.Time         `json:"endDate"`
+	Rollover       bool               `json:"rollover"`
+	AlertThreshold *int               `json:"alertThreshold" binding:"omitempty,min=1,max=100"`
+}
+
+type BudgetUpdateRequest struct {
+	Amount         *models.Money `json:"amount" binding:"omitempty,gt=0"`
+	Rollover       *bool         `json:"rollover"`
+	AlertThreshold *int          `json:"alertThreshold" binding:"omitempty,min=1,max=100"`
+}
+
+// BudgetResponse is a budget with its progress in the current period. Limit is
+// the budget amount plus what was carried over from previous periods when
+// rollover is enabled.
+type BudgetResponse struct {
+	models.Budget
+	CategoryName string       `json:"categoryName"`
+	PeriodStart  time.Time    `json:"periodStart"`
+	PeriodEnd    time.Time    `json:"periodEnd"`
+	CarriedOver  models.Money `json:"carriedOver"`
+	Limit        models.Money `json:"limit"`
+	Spent        models.Money `json:"spent"`
+	Remaining    models.Money `json:"remaining"`
+	Percent      int          `json:"percent"`
+	Overspent    bool         `json:"overspent"`
+}
+
+type BudgetListResponse struct {
+	Pagination PaginationData    `json:"pagination"`
+	Budgets    *[]BudgetResponse `json:"budgets"`
+}
+
+type BudgetAlertResponse struct {
+	ID           int          `json:"id"`
+	BudgetID     int          `json:"budgetID"`
+	CategoryID   int          `json:"categoryID"`
+	CategoryName string       `json:"categoryName"`
+	PeriodStart  time.Time    `json:"periodStart"`
+	Threshold    int          `json:"threshold"`
+	Spent        models.Money `json:"spent"`
+	Limit        models.Money `json:"limit"`
+	CreationDate time.Time    `json:"creationDate"`
+}
+
+type BudgetAlertListResponse struct {
+	Pagination PaginationData         `json:"pagination"`
+	Alerts     *[]BudgetAlertResponse `json:"alerts"`
+}
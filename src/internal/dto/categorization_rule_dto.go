@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+// CategorizationRuleRequest is used to create a rule and to replace one on
+// update, so conditions can be removed. Without Priority the rule runs after
+// the existing ones.
+type CategorizationRuleRequest struct {
+	CategoryID          int                   `json:"categoryID" binding:"required,number"`
+	Type                enums.TransactionType `json:"type" binding:"required,oneof=income expense"`
+	Priority            *int                  `json:"priority" binding:"omitempty,min=0"`
+	AccountID           *int                  `json:"accountID" binding:"omitempty,number"`
+	DescriptionContains *string               `json:"descriptionContains" binding:"omitempty,min=1,max=255"`
+	MinAmount           *models.Money         `json:"minAmount" binding:"omitempty,gte=0"`
+	MaxAmount           *models.Money         `json:"maxAmount" binding:"omitempty,gte=0"`
+}
+
+// CategorizationRuleApplyRequest runs the rules again on existing
+// transactions. Only uncategorized ones are changed unless Overwrite is set.
+type CategorizationRuleApplyRequest struct {
+	AccountID *int       `json:"accountID" binding:"omitempty,number"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	Overwrite bool       `json:"overwrite"`
+}
+
+type CategorizationRuleApplyResponse struct {
+	Updated int `json:"updated"`
+}
+
+type CategorizationRuleResponse struct {
+	models.CategorizationRule
+	CategoryName string  `json:"categoryName"`
+	AccountName  *string `json:"accountName"`
+}
+
+type CategorizationRuleListResponse struct {
+	Pagination PaginationData                `json:"pagination"`
+	Rules      *[]CategorizationRuleResponse `json:"rules"`
+}
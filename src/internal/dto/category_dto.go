@@ -11,10 +11,11 @@ type CategoriesListResponse struct {
 }
 
 type CategoryCreateRequest struct {
-	Name   string             `json:"name" binding:"required,alphaNumericSpace"`
-	Color  string             `json:"color" binding:"required,hexcolor"`
-	IconID *int               `json:"iconID" binding:"omitempty,number"`
-	Type   enums.CategoryType `json:"type" binding:"required,categoryCreateType"`
+	Name             string             `json:"name" binding:"required,alphaNumericSpace"`
+	Color            string             `json:"color" binding:"required,hexcolor"`
+	IconID           *int               `json:"iconID" binding:"omitempty,number"`
+	Type             enums.CategoryType `json:"type" binding:"required,categoryCreateType"`
+	FinancialGroupID *int               `json:"financialGroupID" binding:"omitempty,number"`
 }
 
 type CategoryUpdateRequest struct {
@@ -0,0 +1,18 @@
+package dto
+
+import "shirinec.com/src/internal/models"
+
+type ExchangeRateCreateRequest struct {
+	BaseCurrency  string `json:"baseCurrency" binding:"required,iso4217"`
+	QuoteCurrency string `json:"quoteCurrency" binding:"required,iso4217,nefield=BaseCurrency"`
+	Rate          string `json:"rate" binding:"required,numeric"`
+}
+
+type ExchangeRateUpdateRequest struct {
+	Rate string `json:"rate" binding:"required,numeric"`
+}
+
+type ExchangeRateListResponse struct {
+	Pagination    PaginationData         `json:"pagination"`
+	ExchangeRates *[]models.ExchangeRate `json:"exchangeRates"`
+}
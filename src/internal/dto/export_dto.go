@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+// ExportRequest limits the exported transactions and their purchase list
+// lines to the From and To days, both included. Accounts, categories and
+// items are always exported in full.
+type ExportRequest struct {
+	Format enums.ExportFormat `form:"format" binding:"required,oneof=csv json ofx"`
+	From   *time.Time         `form:"from" time_format:"2006-01-02"`
+	To     *time.Time         `form:"to" time_format:"2006-01-02"`
+}
+
+// ExportJobResponse has the URL of the file once the job is completed, it is
+// served by the media file endpoint until the media cleaner removes it.
+type ExportJobResponse struct {
+	models.ExportJob
+	URL *string `json:"url"`
+}
+
+type ExportJobListResponse struct {
+	Pagination PaginationData       `json:"pagination"`
+	Jobs       *[]ExportJobResponse `json:"jobs"`
+}
+
+type ExportAccount struct {
+	ID               int               `json:"id"`
+	Name             string            `json:"name"`
+	Type             enums.AccountType `json:"type"`
+	Currency         string            `json:"currency"`
+	Balance          models.Money      `json:"balance"`
+	CategoryID       int               `json:"categoryID"`
+	FinancialGroupID *int              `json:"financialGroupID"`
+	CreationDate     time.Time         `json:"creationDate"`
+}
+
+type ExportCategory struct {
+	ID               int                `json:"id"`
+	Name             string             `json:"name"`
+	Color            string             `json:"color"`
+	EntityType       enums.CategoryType `json:"entityType"`
+	FinancialGroupID *int               `json:"financialGroupID"`
+}
+
+type ExportItem struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	CategoryID       int    `json:"categoryID"`
+	FinancialGroupID *int   `json:"financialGroupID"`
+}
+
+type ExportTransaction struct {
+	ID                  int                     `json:"id"`
+	AccountID           int                     `json:"accountID"`
+	CategoryID          *int                    `json:"categoryID"`
+	Type                enums.TransactionType   `json:"type"`
+	Status              enums.TransactionStatus `json:"status"`
+	Amount              models.Money            `json:"amount"`
+	Description         *string                 `json:"description"`
+	ExchangeRate        *string                 `json:"exchangeRate"`
+	LinkedTransactionID *int                    `json:"linkedTransactionID"`
+	ReversalOfID        *int                    `json:"reversalOfID"`
+	Date                time.Time               `json:"date"`
+}
+
+type ExportPurchaseListItem struct {
+	ID            int          `json:"id"`
+	TransactionID int          `json:"transactionID"`
+	ItemID        int          `json:"itemID"`
+	Count         int          `json:"count"`
+	UnitPrice     models.Money `json:"unitPrice"`
+}
+
+// ExportHeader describes the export as a whole, it is written ahead of the
+// records.
+type ExportHeader struct {
+	ExportDate time.Time  `json:"exportDate"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+}
@@ -12,21 +12,30 @@ type FinancialGroupCreateRequest struct {
 	ImageID *int   `json:"imageID" binding:"omitempty,number"`
 }
 
-type FinancialGroupAddUser struct {
-	UserID uuid.UUID `json:"userID" binding:"required,uuid4"`
-}
-
 type FinancialGroup struct {
 	ID           int
 	Name         string
 	ImageURL     *string
 	OwnerID      uuid.UUID
-	Users        []*UserGetResponse
+	Users        []*FinancialGroupMember
 	UserRole     enums.FinancialGroupRole
 	CreationDate time.Time
 	UpdateDate   time.Time
 }
 
+type FinancialGroupMember struct {
+	UserGetResponse
+	Role enums.FinancialGroupRole `json:"role"`
+}
+
+type FinancialGroupMemberRoleRequest struct {
+	Role enums.FinancialGroupRole `json:"role" binding:"required,oneof=viewer contributor admin"`
+}
+
+type FinancialGroupTransferRequest struct {
+	UserID uuid.UUID `json:"userID" binding:"required"`
+}
+
 type FinancialGroupListRequest struct {
 	Page int                      `form:"page,default=0" binding:"number"`
 	Size int                      `form:"size,default=10" binding:"number"`
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type FinancialGroupInvitationCreateRequest struct {
+	Email string                   `json:"email" binding:"required,email"`
+	Role  enums.FinancialGroupRole `json:"role" binding:"omitempty,oneof=viewer contributor admin"`
+}
+
+type FinancialGroupInvitationResponse struct {
+	ID                 int                      `json:"id"`
+	FinancialGroupID   int                      `json:"financialGroupID"`
+	FinancialGroupName string                   `json:"financialGroupName"`
+	InvitedBy          uuid.UUID                `json:"invitedBy"`
+	Email              string                   `json:"email"`
+	Token              string                   `json:"token"`
+	Status             enums.InvitationStatus   `json:"status"`
+	Role               enums.FinancialGroupRole `json:"role"`
+	ExpiresAt          time.Time                `json:"expiresAt"`
+	CreationDate       time.Time                `json:"creationDate"`
+	UpdateDate         time.Time                `json:"updateDate"`
+}
+
+type FinancialGroupInvitationListResponse struct {
+	Invitations *[]FinancialGroupInvitationResponse `json:"invitations"`
+}
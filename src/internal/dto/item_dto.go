@@ -8,24 +8,26 @@ import (
 )
 
 type ItemCreateRequest struct {
-	Name       string `json:"name" binding:"required,alphaNumericSpace"`
-	ImageID    *int   `json:"imageID" binding:"omitempty,number"`
-	CategoryID int    `json:"categoryID" binding:"number"`
+	Name             string `json:"name" binding:"required,alphaNumericSpace"`
+	ImageID          *int   `json:"imageID" binding:"omitempty,number"`
+	CategoryID       int    `json:"categoryID" binding:"number"`
+	FinancialGroupID *int   `json:"financialGroupID" binding:"omitempty,number"`
 }
 
 type ItemJoinedResponse struct {
-	ID              int                `json:"id"`
-	UserID          uuid.UUID          `json:"userID"`
-	Name            string             `json:"name"`
-	ImageID         *int               `json:"imageID"`
-	ImageURL        *string            `json:"imageURL"`
-	ImageMetadata   *string            `json:"imageMetadata"`
-	CategoryID      int                `json:"categoryID"`
-	CategoryName    string             `json:"categoryName"`
-	CategoryIconURL *string            `json:"categoryIconURL"`
-	CategoryType    enums.CategoryType `json:"categoryType"`
-	CreationDate    time.Time          `json:"creationDate"`
-	UpdateDate      time.Time          `json:"updateDate"`
+	ID               int                `json:"id"`
+	UserID           uuid.UUID          `json:"userID"`
+	Name             string             `json:"name"`
+	ImageID          *int               `json:"imageID"`
+	ImageURL         *string            `json:"imageURL"`
+	ImageMetadata    *string            `json:"imageMetadata"`
+	CategoryID       int                `json:"categoryID"`
+	CategoryName     string             `json:"categoryName"`
+	CategoryIconURL  *string            `json:"categoryIconURL"`
+	CategoryType     enums.CategoryType `json:"categoryType"`
+	FinancialGroupID *int               `json:"financialGroupID"`
+	CreationDate     time.Time          `json:"creationDate"`
+	UpdateDate       time.Time          `json:"updateDate"`
 }
 
 type ItemsListResponse struct {
@@ -34,7 +36,7 @@ type ItemsListResponse struct {
 }
 
 type ItemUpdateRequest struct {
-    Name       *string `json:"name" binding:"omitempty,alphaNumericSpace"`
-    CategoryID *int    `json:"categoryID" bining:"omitEmpty,number"`
-    ImageID    *int    `json:"imageID" binding:"omitempty,number"`
+	Name       *string `json:"name" binding:"omitempty,alphaNumericSpace"`
+	CategoryID *int    `json:"categoryID" bining:"omitEmpty,number"`
+	ImageID    *int    `json:"imageID" binding:"omitempty,number"`
 }
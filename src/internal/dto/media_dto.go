@@ -11,12 +11,27 @@ type MediaUploadQuery struct {
 	FinancialGroupID int               `form:"group" binding:"required,number"`
 }
 
+// MediaFileQuery picks a thumbnail variant, the original is served without it.
+type MediaFileQuery struct {
+	Size string `form:"size" binding:"omitempty,oneof=small medium large"`
+}
+
 type MediaUploadResponse struct {
-	ID           int       `json:"id"`
-	URL          string    `json:"url"`
-	Metadata     *string   `json:"metadata"`
-	CreationDate time.Time `json:"creationDate"`
-	UpdateDate   time.Time `json:"updateDate"`
+	ID               int               `json:"id"`
+	URL              string            `json:"url"`
+	Metadata         *string           `json:"metadata"`
+	Access           enums.MediaAccess `json:"access"`
+	FinancialGroupID *int              `json:"financialGroupID"`
+	CreationDate     time.Time         `json:"creationDate"`
+	UpdateDate       time.Time         `json:"updateDate"`
+}
+
+type MediaAccessRequest struct {
+	Access enums.MediaAccess `json:"access" binding:"required,oneof=owner group public"`
+}
+
+type MediaMoveRequest struct {
+	FinancialGroupID int `json:"financialGroupID" binding:"required,number"`
 }
 
 type MediaListForCleanupResult struct {
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+// NetWorthRequest returns a point for each day, week or month between From
+// and To, both included. Week and month points are at the end of the period,
+// or on To for the last one.
+type NetWorthRequest struct {
+	From     time.Time           `form:"from" binding:"required" time_format:"2006-01-02"`
+	To       time.Time           `form:"to" binding:"required" time_format:"2006-01-02"`
+	Interval enums.ReportGroupBy `form:"interval" binding:"omitempty,oneof=day week month"`
+}
+
+// NetWorthBalance is the sum of the snapshots of the accounts sharing a
+// currency, type and category on a day.
+type NetWorthBalance struct {
+	Date         time.Time
+	Currency     string
+	AccountType  enums.AccountType
+	CategoryID   int
+	CategoryName string
+	Balance      models.Money
+}
+
+type NetWorthCategory struct {
+	CategoryID   int          `json:"categoryID"`
+	CategoryName string       `json:"categoryName"`
+	Balance      models.Money `json:"balance"`
+}
+
+// NetWorthPoint is the net worth in one currency on a day, Total is Self plus
+// External. Accounts created after Date are not counted.
+type NetWorthPoint struct {
+	Date       time.Time          `json:"date"`
+	Currency   string             `json:"currency"`
+	Total      models.Money       `json:"total"`
+	Self       models.Money       `json:"self"`
+	External   models.Money       `json:"external"`
+	Categories []NetWorthCategory `json:"categories"`
+}
+
+type NetWorthResponse struct {
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Interval enums.ReportGroupBy `json:"interval"`
+	Points   []NetWorthPoint     `json:"points"`
+}
+
+type NetWorthBackfillResponse struct {
+	Snapshots int `json:"snapshots"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/models"
+)
+
+type PurchaseListItemCreateRequest struct {
+	ItemID    int          `json:"itemID" binding:"required,number"`
+	Count     int          `json:"count" binding:"required,gt=0"`
+	UnitPrice models.Money `json:"unitPrice" binding:"gte=0"`
+}
+
+type PurchaseListItemUpdateRequest struct {
+	ItemID    *int          `json:"itemID" binding:"omitempty,number"`
+	Count     *int          `json:"count" binding:"omitempty,gt=0"`
+	UnitPrice *models.Money `json:"unitPrice" binding:"omitempty,gte=0"`
+}
+
+type PurchaseListItemJoinedResponse struct {
+	ID              int          `json:"id"`
+	UserID          uuid.UUID    `json:"userID"`
+	TransactionID   int          `json:"transactionID"`
+	ItemID          int          `json:"itemID"`
+	ItemName        string       `json:"itemName"`
+	ItemImageURL    *string      `json:"itemImageURL"`
+	CategoryID      int          `json:"categoryID"`
+	CategoryName    string       `json:"categoryName"`
+	CategoryIconURL *string      `json:"categoryIconURL"`
+	Count           int          `json:"count"`
+	UnitPrice       models.Money `json:"unitPrice"`
+	Total           models.Money `json:"total"`
+	CreationDate    time.Time    `json:"creationDate"`
+	UpdateDate      time.Time    `json:"updateDate"`
+}
+
+type PurchaseListResponse struct {
+	TransactionID int                               `json:"transactionID"`
+	Amount        models.Money                      `json:"amount"`
+	Items         *[]PurchaseListItemJoinedResponse `json:"items"`
+}
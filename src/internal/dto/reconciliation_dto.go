@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/models"
+)
+
+// AccountReconcileRequest has the balance of a bank statement at the end of
+// Date. With Adjust, the difference is recorded as an adjustment transaction
+// on Date so the account matches the statement.
+type AccountReconcileRequest struct {
+	Balance models.Money `json:"balance" binding:"number"`
+	Date    time.Time    `json:"date" binding:"required"`
+	Adjust  bool         `json:"adjust"`
+}
+
+type AccountReconciliationListResponse struct {
+	Pagination      PaginationData                  `json:"pagination"`
+	Reconciliations *[]models.AccountReconciliation `json:"reconciliations"`
+}
+
+// BalanceDiscrepancyResponse is an account whose stored balance is not the
+// sum of its transactions, Difference is the stored balance minus the ledger
+// balance.
+type BalanceDiscrepancyResponse struct {
+	AccountID     int          `json:"accountID"`
+	AccountName   string       `json:"accountName"`
+	Currency      string       `json:"currency"`
+	StoredBalance models.Money `json:"storedBalance"`
+	LedgerBalance models.Money `json:"ledgerBalance"`
+	Difference    models.Money `json:"difference"`
+	DetectionDate time.Time    `json:"detectionDate"`
+}
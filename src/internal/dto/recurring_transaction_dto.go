@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+type RecurringTransactionCreateRequest struct {
+	AccountID   int                       `json:"accountID" binding:"required,number"`
+	CategoryID  int                       `json:"categoryID" binding:"required,number"`
+	Type        enums.TransactionType     `json:"type" binding:"required,oneof=income expense"`
+	Amount      models.Money              `json:"amount" binding:"required,gt=0"`
+	Description *string                   `json:"description" binding:"omitempty,max=255"`
+	Frequency   enums.RecurrenceFrequency `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+	Interval    *int                      `json:"interval" binding:"omitempty,min=1,max=366"`
+	StartDate   *time.Time                `json:"startDate"`
+	EndDate     *time.Time                `json:"endDate"`
+}
+
+// RecurringTransactionUpdateRequest changes the occurrences that were not
+// materialized yet, transactions already created by the rule are kept as they
+// are.
+type RecurringTransactionUpdateRequest struct {
+	AccountID   *int                       `json:"accountID" binding:"omitempty,number"`
+	CategoryID  *int                       `json:"categoryID" binding:"omitempty,number"`
+	Amount      *models.Money              `json:"amount" binding:"omitempty,gt=0"`
+	Description *string                    `json:"description" binding:"omitempty,max=255"`
+	Frequency   *enums.RecurrenceFrequency `json:"frequency" binding:"omitempty,oneof=daily weekly monthly yearly"`
+	Interval    *int                       `json:"interval" binding:"omitempty,min=1,max=366"`
+	StartDate   *time.Time                 `json:"startDate"`
+	EndDate     *time.Time                 `json:"endDate"`
+}
+
+type RecurringTransactionResponse struct {
+	models.RecurringTransaction
+	AccountName  string `json:"accountName"`
+	CategoryName string `json:"categoryName"`
+}
+
+type RecurringTransactionListResponse struct {
+	Pagination            PaginationData                  `json:"pagination"`
+	RecurringTransactions *[]RecurringTransactionResponse `json:"recurringTransactions"`
+}
+
+// RecurringOccurrenceOverrideRequest either skips an upcoming occurrence or
+// changes the amount and description it will be created with.
+type RecurringOccurrenceOverrideRequest struct {
+	Skip        bool          `json:"skip"`
+	Amount      *models.Money `json:"amount" binding:"omitempty,gt=0"`
+	Description *string       `json:"description" binding:"omitempty,max=255"`
+}
+
+type RecurringOccurrenceListRequest struct {
+	Count int `form:"count,default=10" binding:"min=1,max=100"`
+}
+
+type RecurringOccurrenceResponse struct {
+	Date        time.Time    `json:"date"`
+	Amount      models.Money `json:"amount"`
+	Description *string      `json:"description"`
+	Skipped     bool         `json:"skipped"`
+	Overridden  bool         `json:"overridden"`
+}
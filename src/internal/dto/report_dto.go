@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+// ReportRequest covers the From and To days, both included, on every account
+// the user can see or only on AccountID.
+type ReportRequest struct {
+	From      time.Time `form:"from" binding:"required" time_format:"2006-01-02"`
+	To        time.Time `form:"to" binding:"required" time_format:"2006-01-02"`
+	AccountID *int      `form:"accountID" binding:"omitempty,number"`
+}
+
+type ReportBreakdownRequest struct {
+	ReportRequest
+	GroupBy enums.ReportGroupBy    `form:"groupBy" binding:"required,oneof=category account day week month year"`
+	Type    *enums.TransactionType `form:"type" binding:"omitempty,oneof=income expense"`
+}
+
+type ReportTopRequest struct {
+	ReportRequest
+	Type  enums.TransactionType `form:"type" binding:"omitempty,oneof=income expense"`
+	Limit int                   `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// ReportTotals sums the income and expense transactions of one currency,
+// Expense is positive and Net is Income minus Expense. Transfers are not
+// counted.
+type ReportTotals struct {
+	Currency string       `json:"currency"`
+	Income   models.Money `json:"income"`
+	Expense  models.Money `json:"expense"`
+	Net      models.Money `json:"net"`
+	Count    int          `json:"count"`
+}
+
+type ReportSummaryResponse struct {
+	From   time.Time      `json:"from"`
+	To     time.Time      `json:"to"`
+	Totals []ReportTotals `json:"totals"`
+}
+
+// ReportBreakdownRow has the category or account in Key and Label when
+// grouping by them, Key is null for uncategorized transactions. Time groupings
+// set PeriodStart instead and have a row for every period of the range, even
+// the ones without transactions.
+type ReportBreakdownRow struct {
+	Key         *int       `json:"key"`
+	Label       *string    `json:"label"`
+	PeriodStart *time.Time `json:"periodStart"`
+	ReportTotals
+}
+
+type ReportBreakdownResponse struct {
+	From    time.Time            `json:"from"`
+	To      time.Time            `json:"to"`
+	GroupBy enums.ReportGroupBy  `json:"groupBy"`
+	Rows    []ReportBreakdownRow `json:"rows"`
+}
+
+// ReportComparisonRow compares a month with the one before it, the changes
+// are percentages and are null when the previous month had nothing.
+type ReportComparisonRow struct {
+	Month time.Time `json:"month"`
+	ReportTotals
+	PreviousIncome  models.Money `json:"previousIncome"`
+	PreviousExpense models.Money `json:"previousExpense"`
+	IncomeChange    *float64     `json:"incomeChange"`
+	ExpenseChange   *float64     `json:"expenseChange"`
+}
+
+type ReportComparisonResponse struct {
+	From time.Time             `json:"from"`
+	To   time.Time             `json:"to"`
+	Rows []ReportComparisonRow `json:"rows"`
+}
+
+// ReportTopRow groups transactions whose descriptions only differ in case and
+// spacing, Description is the most used spelling.
+type ReportTopRow struct {
+	Description string       `json:"description"`
+	Currency    string       `json:"currency"`
+	Total       models.Money `json:"total"`
+	Count       int          `json:"count"`
+}
+
+type ReportTopResponse struct {
+	From time.Time             `json:"from"`
+	To   time.Time             `json:"to"`
+	Type enums.TransactionType `json:"type"`
+	Rows []ReportTopRow        `json:"rows"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+// ScheduledTransferCreateRequest schedules a transfer on StartDate, it repeats
+// when Frequency is set. Without Rate, transfers between currencies use the
+// exchange rate saved at the time each run executes.
+type ScheduledTransferCreateRequest struct {
+	From      int                        `json:"from" binding:"required,number"`
+	Dest      int                        `json:"dest" binding:"required,number,nefield=From"`
+	Amount    models.Money               `json:"amount" binding:"required,gt=0"`
+	Rate      *string                    `json:"rate" binding:"omitempty,numeric"`
+	Frequency *enums.RecurrenceFrequency `json:"frequency" binding:"omitempty,oneof=daily weekly monthly yearly"`
+	Interval  *int                       `json:"interval" binding:"omitempty,min=1,max=366"`
+	StartDate time.Time                  `json:"startDate" binding:"required"`
+	EndDate   *time.Time                 `json:"endDate"`
+}
+
+type ScheduledTransferResponse struct {
+	models.ScheduledTransfer
+	FromAccountName *string `json:"fromName"`
+	DestAccountName *string `json:"destName"`
+}
+
+type ScheduledTransferListResponse struct {
+	Pagination         PaginationData               `json:"pagination"`
+	ScheduledTransfers *[]ScheduledTransferResponse `json:"scheduledTransfers"`
+}
+
+type ScheduledTransferRunListResponse struct {
+	Pagination PaginationData                 `json:"pagination"`
+	Runs       *[]models.ScheduledTransferRun `json:"runs"`
+}
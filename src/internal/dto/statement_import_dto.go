@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+// StatementImportRequest holds the form fields sent with the statement file.
+// The column fields are only used for CSV, they are header names when
+// HasHeader is set and 1-based positions otherwise. DateFormat is written
+// with YYYY, YY, MM and DD, e.g. DD/MM/YYYY.
+type StatementImportRequest struct {
+	AccountID         int                   `form:"accountID" binding:"required,number"`
+	Format            enums.StatementFormat `form:"format" binding:"required,oneof=csv ofx qif"`
+	Delimiter         string                `form:"delimiter" binding:"omitempty,len=1"`
+	HasHeader         bool                  `form:"hasHeader"`
+	DateColumn        string                `form:"dateColumn" binding:"required_if=Format csv,max=100"`
+	DateFormat        string                `form:"dateFormat" binding:"max=20"`
+	DescriptionColumn string                `form:"descriptionColumn" binding:"required_if=Format csv,max=100"`
+	AmountColumn      string                `form:"amountColumn" binding:"max=100"`
+	DebitColumn       string                `form:"debitColumn" binding:"max=100"`
+	CreditColumn      string                `form:"creditColumn" binding:"max=100"`
+	DecimalSeparator  string                `form:"decimalSeparator" binding:"omitempty,oneof=. 0x2C"`
+}
+
+// StatementImportCommitRequest lets the user leave rows out of the commit,
+// rows detected as duplicates are skipped unless IncludeDuplicates is set.
+type StatementImportCommitRequest struct {
+	ExcludedRows      []int `json:"excludedRows" binding:"omitempty,dive,number"`
+	IncludeDuplicates bool  `json:"includeDuplicates"`
+}
+
+type StatementImportResponse struct {
+	models.StatementImport
+	AccountName    string                       `json:"accountName"`
+	RowCount       int                          `json:"rowCount"`
+	DuplicateCount int                          `json:"duplicateCount"`
+	Rows           *[]models.StatementImportRow `json:"rows,omitempty"`
+}
+
+type StatementImportListResponse struct {
+	Pagination PaginationData             `json:"pagination"`
+	Imports    *[]StatementImportResponse `json:"imports"`
+}
@@ -1,7 +1,128 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
 type TransferRequest struct {
-	From   int     `json:"from" binding:"required,number"`
-	Dest   int     `json:"dest" binding:"required,number"`
-	Amount float64 `json:"amount" binding:"required,number"`
+	From   int          `json:"from" binding:"required,number"`
+	Dest   int          `json:"dest" binding:"required,number"`
+	Amount models.Money `json:"amount" binding:"required,gt=0"`
+	Rate   *string      `json:"rate" binding:"omitempty,numeric"`
+}
+
+// TransactionCreateRequest leaves the category to the user's categorization
+// rules when CategoryID is not set, the transaction stays uncategorized when
+// none matches.
+type TransactionCreateRequest struct {
+	AccountID   int          `json:"accountID" binding:"required,number"`
+	CategoryID  *int         `json:"categoryID" binding:"omitempty,number"`
+	Amount      models.Money `json:"amount" binding:"required,gt=0"`
+	Description *string      `json:"description" binding:"omitempty,max=255"`
+	Date        *time.Time   `json:"date"`
+}
+
+type TransactionUpdateRequest struct {
+	AccountID   *int          `json:"accountID" binding:"omitempty,number"`
+	CategoryID  *int          `json:"categoryID" binding:"omitempty,number"`
+	Amount      *models.Money `json:"amount" binding:"omitempty,gt=0"`
+	Description *string       `json:"description" binding:"omitempty,max=255"`
+	Date        *time.Time    `json:"date"`
+}
+
+type TransactionJoinedResponse struct {
+	ID                  int                     `json:"id"`
+	UserID              uuid.UUID               `json:"userID"`
+	AccountID           int                     `json:"accountID"`
+	AccountName         string                  `json:"accountName"`
+	CategoryID          *int                    `json:"categoryID"`
+	CategoryName        *string                 `json:"categoryName"`
+	CategoryColor       *string                 `json:"categoryColor"`
+	CategoryIconURL     *string                 `json:"categoryIconURL"`
+	Amount              models.Money            `json:"amount"`
+	Description         *string                 `json:"description"`
+	Type                enums.TransactionType   `json:"transactionType"`
+	Status              enums.TransactionStatus `json:"status"`
+	LinkedTransactionID *int                    `json:"linkedTransactionID"`
+	ReversalOfID        *int                    `json:"reversalOfID"`
+	Date                time.Time               `json:"date"`
+	CreationDate        time.Time               `json:"creationDate"`
+	UpdateDate          time.Time               `json:"updateDate"`
+	Attachments         []TransactionAttachment `json:"attachments"`
+}
+
+// TransactionAttachment is a media attached to a transaction, CreationDate is
+// when it was attached.
+type TransactionAttachment struct {
+	MediaID      int               `json:"mediaID"`
+	URL          string            `json:"url"`
+	Metadata     *string           `json:"metadata"`
+	Access       enums.MediaAccess `json:"access"`
+	CreationDate time.Time         `json:"creationDate"`
+}
+
+type TransactionAttachRequest struct {
+	MediaID int `json:"mediaID" binding:"required,number"`
+}
+
+// TransactionReversalResponse lists the transactions that were voided and the
+// reversals recorded for them, transfers have one of each per leg.
+type TransactionReversalResponse struct {
+	Voided    []TransactionJoinedResponse `json:"voided"`
+	Reversals []TransactionJoinedResponse `json:"reversals"`
+}
+
+type TransactionListRequest struct {
+	Page       int                    `form:"page,default=0" binding:"number"`
+	Size       int                    `form:"size,default=10" binding:"number,min=1,max=100"`
+	Paging     string                 `form:"paging,default=offset" binding:"oneof=offset cursor"`
+	Cursor     string                 `form:"cursor"`
+	AccountID  *int                   `form:"account" binding:"omitempty,number"`
+	CategoryID *int                   `form:"category" binding:"omitempty,number"`
+	Type       *enums.TransactionType `form:"type" binding:"omitempty,transactionType"`
+	From       *time.Time             `form:"from" time_format:"2006-01-02"`
+	To         *time.Time             `form:"to" time_format:"2006-01-02"`
+	// MinAmount and MaxAmount bound the absolute amount, an expense of 30.00
+	// is stored as -30.00 and still matches minAmount=10&maxAmount=50.
+	// Sorting by amount orders by the absolute amount too.
+	MinAmount   *string `form:"minAmount" binding:"omitempty,numeric"`
+	MaxAmount   *string `form:"maxAmount" binding:"omitempty,numeric"`
+	Description *string `form:"description" binding:"omitempty,max=255"`
+	SortBy      string  `form:"sortBy,default=date" binding:"oneof=date amount"`
+	Order       string  `form:"order,default=desc" binding:"oneof=asc desc"`
+}
+
+type TransactionListResponse struct {
+	Pagination   *PaginationData              `json:"pagination,omitempty"`
+	NextCursor   *string                      `json:"nextCursor,omitempty"`
+	Transactions *[]TransactionJoinedResponse `json:"transactions"`
+}
+
+// TransactionCursor points at the last row of a page in keyset pagination,
+// only the field matching the sort column is used alongside the ID.
+type TransactionCursor struct {
+	Date   time.Time    `json:"d"`
+	Amount models.Money `json:"a,omitempty"`
+	ID     int          `json:"i"`
+}
+
+type TransactionListFilter struct {
+	UserID      uuid.UUID
+	AccountID   *int
+	CategoryID  *int
+	Type        *enums.TransactionType
+	From        *time.Time
+	To          *time.Time
+	MinAmount   *models.Money
+	MaxAmount   *models.Money
+	Description *string
+	SortBy      string
+	Ascending   bool
+	Limit       int
+	Offset      int
+	After       *TransactionCursor
 }
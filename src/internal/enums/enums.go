@@ -46,6 +46,12 @@ type FinancialGroupRole string
 const (
 	FinancialGroupOwner  FinancialGroupRole = "owner"
 	FinancialGroupMember FinancialGroupRole = "member"
+
+	// Roles stored per membership, ordered from the weakest to the strongest.
+	// The owner always holds the admin role on its own membership.
+	FinancialGroupViewer      FinancialGroupRole = "viewer"
+	FinancialGroupContributor FinancialGroupRole = "contributor"
+	FinancialGroupAdmin       FinancialGroupRole = "admin"
 )
 
 type AccountType string
@@ -54,3 +60,114 @@ const (
 	AccountTypeSelf    AccountType = "self"
 	AccoutTypeExternal AccountType = "external"
 )
+
+type TransactionType string
+
+const (
+	TransactionIncome     TransactionType = "income"
+	TransactionExpense    TransactionType = "expense"
+	TransactionTransfer   TransactionType = "transfer"
+	TransactionOpening    TransactionType = "opening"
+	TransactionAdjustment TransactionType = "adjustment"
+)
+
+type TransactionStatus string
+
+const (
+	TransactionPosted   TransactionStatus = "posted"
+	TransactionVoided   TransactionStatus = "voided"
+	TransactionReversal TransactionStatus = "reversal"
+)
+
+type AccessLevel string
+
+const (
+	AccessView AccessLevel = "view"
+	AccessEdit AccessLevel = "edit"
+	AccessAll  AccessLevel = "all"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+type BudgetPeriod string
+
+const (
+	BudgetMonthly BudgetPeriod = "monthly"
+	BudgetWeekly  BudgetPeriod = "weekly"
+	BudgetCustom  BudgetPeriod = "custom"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "daily"
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+	RecurrenceYearly  RecurrenceFrequency = "yearly"
+)
+
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferActive    ScheduledTransferStatus = "active"
+	ScheduledTransferFinished  ScheduledTransferStatus = "finished"
+	ScheduledTransferCancelled ScheduledTransferStatus = "cancelled"
+)
+
+type ScheduledTransferRunStatus string
+
+const (
+	ScheduledTransferRunSucceeded ScheduledTransferRunStatus = "succeeded"
+	ScheduledTransferRunFailed    ScheduledTransferRunStatus = "failed"
+)
+
+type StatementFormat string
+
+const (
+	StatementCSV StatementFormat = "csv"
+	StatementOFX StatementFormat = "ofx"
+	StatementQIF StatementFormat = "qif"
+)
+
+type StatementImportStatus string
+
+const (
+	StatementImportPending   StatementImportStatus = "pending"
+	StatementImportCommitted StatementImportStatus = "committed"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+	ExportOFX  ExportFormat = "ofx"
+)
+
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+type ReportGroupBy string
+
+const (
+	ReportByCategory ReportGroupBy = "category"
+	ReportByAccount  ReportGroupBy = "account"
+	ReportByDay      ReportGroupBy = "day"
+	ReportByWeek     ReportGroupBy = "week"
+	ReportByMonth    ReportGroupBy = "month"
+	ReportByYear     ReportGroupBy = "year"
+)
@@ -9,6 +9,8 @@ import (
 
 const (
 	PGForeignKeyViolation  = "23503"
+	PGUniqueViolation      = "23505"
+	PGCheckViolation       = "23514"
 	PGExceptionDefault     = "P0001"
 	PGCategoryNotFound     = "S0001"
 	PGInvalidMediaRefrence = "S0002"
@@ -23,6 +25,8 @@ func AsPgError(err error) error {
 			return &InvalidInput
 		case PGForeignKeyViolation:
 			return &InvalidRefrencedEntity
+		case PGCheckViolation:
+			return &InvalidInput
 		case PGCategoryNotFound:
 			return &CategoryNotFound
         case PGInvalidMediaRefrence:
@@ -48,6 +48,12 @@ func AsValidatorError(err error) *[]string {
 							length,
 						),
 					)
+				case "max":
+					errList = append(errList, fmt.Sprintf("%s should be at most %s", err.Field(), err.Param()))
+				case "gt":
+					errList = append(errList, fmt.Sprintf("%s should be greater than %s", err.Field(), err.Param()))
+				case "gte":
+					errList = append(errList, fmt.Sprintf("%s should be at least %s", err.Field(), err.Param()))
 				case "jwt":
 					errList = append(errList, fmt.Sprintf("%s is not a correct jwt", err.Field()))
 				case "required":
@@ -56,6 +62,16 @@ func AsValidatorError(err error) *[]string {
 					errList = append(errList, fmt.Sprintf("%s field should be a hex color", err.Field()))
 				case "categoryCreateType":
 					errList = append(errList, fmt.Sprintf("%s field should be 'income', 'expense' or 'account'", err.Field()))
+				case "transactionType":
+					errList = append(errList, fmt.Sprintf("%s field should be 'income', 'expense' or 'transfer'", err.Field()))
+				case "numeric":
+					errList = append(errList, fmt.Sprintf("%s field should be a number", err.Field()))
+				case "iso4217":
+					errList = append(errList, fmt.Sprintf("%s field should be an ISO 4217 currency code", err.Field()))
+				case "nefield":
+					errList = append(errList, fmt.Sprintf("%s field should not be equal to %s", err.Field(), err.Param()))
+				case "oneof":
+					errList = append(errList, fmt.Sprintf("%s field should be one of '%s'", err.Field(), err.Param()))
 				case "alphanum":
 					errList = append(errList, fmt.Sprintf("%s field must contain only letters and numbers characters", err.Field()))
 				case "alphaNumericSpace":
@@ -1,6 +1,7 @@
 package server_errors
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -42,13 +43,50 @@ var (
 	AccountIsNotActive          = SError{Code: http.StatusForbidden, Message: "Requested account is not active", ErrorCode: 113}
 	InvalidVerificationCode     = SError{Code: http.StatusBadRequest, Message: "Invalid verification code!", ErrorCode: 114}
 	FileRequired                = SError{Code: http.StatusBadRequest, Message: "File is required", ErrorCode: 115}
-	InvalidFileFormat           = SError{Code: http.StatusBadRequest, Message: "Only .png, .jpg and .jpeg files are allowed", ErrorCode: 116}
+	InvalidFileFormat           = SError{Code: http.StatusBadRequest, Message: "Only PNG, JPEG and HEIC images and PDF documents are allowed", ErrorCode: 116}
 	CategoryNotFound            = SError{Code: http.StatusBadRequest, Message: "Requested category was not found!", ErrorCode: 117}
 	InvalidRefrencedEntity      = SError{Code: http.StatusBadRequest, Message: "Request refrence field error", ErrorCode: 118}
 	InvalidMediaRefrence        = SError{Code: http.StatusBadRequest, Message: "Request media field is invalid", ErrorCode: 119}
 	UserAlreadyInFinancialGroup = SError{Code: http.StatusBadRequest, Message: "User is already in selected group", ErrorCode: 120}
+	ExchangeRateNotFound        = SError{Code: http.StatusBadRequest, Message: "No exchange rate is defined between account currencies", ErrorCode: 121}
+	AmountManagedByItems        = SError{Code: http.StatusBadRequest, Message: "Expense amount is calculated from its purchase list items", ErrorCode: 122}
+	AccountAccessDenied         = SError{Code: http.StatusForbidden, Message: "Your access level on this account does not allow this action", ErrorCode: 123}
+	AccountAlreadyShared        = SError{Code: http.StatusBadRequest, Message: "Account is already shared with this user", ErrorCode: 124}
+	NotFinancialGroupMember     = SError{Code: http.StatusForbidden, Message: "You are not a member of the selected financial group", ErrorCode: 125}
+	InvitationAlreadyPending    = SError{Code: http.StatusBadRequest, Message: "There is already a pending invitation for this email", ErrorCode: 126}
+	InvitationExpired           = SError{Code: http.StatusBadRequest, Message: "Invitation has expired", ErrorCode: 127}
+	FinancialGroupRoleDenied    = SError{Code: http.StatusForbidden, Message: "Your role in this financial group does not allow this action", ErrorCode: 128}
+	FinancialGroupOwnerChange   = SError{Code: http.StatusBadRequest, Message: "The group owner can not be removed or have their role changed, transfer the ownership first", ErrorCode: 129}
+	BudgetCategoryNotExpense    = SError{Code: http.StatusBadRequest, Message: "Budgets can only be set on expense categories", ErrorCode: 130}
+	InvalidBudgetPeriod         = SError{Code: http.StatusBadRequest, Message: "Custom budgets need an end date after the start date, other periods can not have one", ErrorCode: 131}
+	InvalidRecurrence           = SError{Code: http.StatusBadRequest, Message: "Recurring transaction can not end before it starts", ErrorCode: 132}
+	RecurringCategoryMismatch   = SError{Code: http.StatusBadRequest, Message: "Category type must match the recurring transaction type", ErrorCode: 133}
+	OccurrenceNotUpcoming       = SError{Code: http.StatusBadRequest, Message: "Date is not an upcoming occurrence of the recurring transaction", ErrorCode: 134}
+	ScheduleInPast              = SError{Code: http.StatusBadRequest, Message: "Scheduled transfers can not start in the past", ErrorCode: 135}
+	StatementFileTooLarge       = SError{Code: http.StatusBadRequest, Message: "Statement file is too large", ErrorCode: 136}
+	StatementIsEmpty            = SError{Code: http.StatusBadRequest, Message: "No transactions were found in the statement", ErrorCode: 137}
+	ImportNotPending            = SError{Code: http.StatusBadRequest, Message: "Import is already committed", ErrorCode: 138}
+	EmptyCategorizationRule     = SError{Code: http.StatusBadRequest, Message: "Categorization rules need at least one condition", ErrorCode: 140}
+	RuleCategoryMismatch        = SError{Code: http.StatusBadRequest, Message: "Category type must match the categorization rule type", ErrorCode: 141}
+	InvalidAmountRange          = SError{Code: http.StatusBadRequest, Message: "Minimum amount can not be greater than the maximum amount", ErrorCode: 142}
+	InvalidDateRange            = SError{Code: http.StatusBadRequest, Message: "Start date can not be after the end date", ErrorCode: 143}
+	ReportRangeTooLong          = SError{Code: http.StatusBadRequest, Message: "Daily reports can cover at most 366 days", ErrorCode: 144}
+	TransactionNotPosted        = SError{Code: http.StatusBadRequest, Message: "Voided transactions and reversals can not be changed", ErrorCode: 145}
+	OpeningNotReversible        = SError{Code: http.StatusBadRequest, Message: "Opening balances are changed through their account, they can not be reversed", ErrorCode: 146}
+	MediaFileTooLarge           = SError{Code: http.StatusBadRequest, Message: "File is too large", ErrorCode: 147}
+	MediaInUse                  = SError{Code: http.StatusBadRequest, Message: "Media is still used as a category icon, item image, profile picture or group image", ErrorCode: 148}
+	MediaWithoutGroup           = SError{Code: http.StatusBadRequest, Message: "Media without a financial group can not be shared with a group", ErrorCode: 149}
 )
 
+// StatementErrorBuilder reports why a statement file could not be parsed.
+func StatementErrorBuilder(err error) *SError {
+	return &SError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Statement file could not be read: %s", err.Error()),
+		ErrorCode: 139,
+	}
+}
+
 func ValidationErrorBuilder(errList *[]string) *SError {
 	message := strings.Join(*errList, "\n")
 	return &SError{
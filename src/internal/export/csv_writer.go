@@ -0,0 +1,142 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"shirinec.com/src/internal/dto"
+)
+
+var csvFiles = [sectionCount]struct {
+	name   string
+	header []string
+}{
+	{"accounts.csv", []string{"id", "name", "type", "currency", "balance", "category_id", "financial_group_id", "creation_date"}},
+	{"categories.csv", []string{"id", "name", "color", "entity_type", "financial_group_id"}},
+	{"items.csv", []string{"id", "name", "category_id", "financial_group_id"}},
+	{"transactions.csv", []string{"id", "account_id", "category_id", "type", "status", "amount", "description", "exchange_rate", "linked_transaction_id", "reversal_of_id", "date"}},
+	{"purchase_list_items.csv", []string{"id", "transaction_id", "item_id", "count", "unit_price"}},
+}
+
+// csvWriter writes one CSV file per kind of record into a zip archive.
+type csvWriter struct {
+	archive  *zip.Writer
+	records  *csv.Writer
+	sections *sectionSequence
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	writer := &csvWriter{archive: zip.NewWriter(w)}
+	writer.sections = newSectionSequence(writer.openFile, writer.closeFile)
+	return writer
+}
+
+func (w *csvWriter) openFile(section int) error {
+	file, err := w.archive.Create(csvFiles[section].name)
+	if err != nil {
+		return err
+	}
+	w.records = csv.NewWriter(file)
+	return w.records.Write(csvFiles[section].header)
+}
+
+// closeFile flushes the records, a zip entry can not be written to once the
+// next one is created.
+func (w *csvWriter) closeFile(section int) error {
+	w.records.Flush()
+	return w.records.Error()
+}
+
+func (w *csvWriter) write(section int, record []string) error {
+	if err := w.sections.moveTo(section); err != nil {
+		return err
+	}
+	return w.records.Write(record)
+}
+
+func (w *csvWriter) WriteAccount(account *dto.ExportAccount) error {
+	return w.write(sectionAccounts, []string{
+		strconv.Itoa(account.ID),
+		account.Name,
+		string(account.Type),
+		account.Currency,
+		account.Balance.String(),
+		strconv.Itoa(account.CategoryID),
+		optionalInt(account.FinancialGroupID),
+		account.CreationDate.Format(time.RFC3339),
+	})
+}
+
+func (w *csvWriter) WriteCategory(category *dto.ExportCategory) error {
+	return w.write(sectionCategories, []string{
+		strconv.Itoa(category.ID),
+		category.Name,
+		category.Color,
+		string(category.EntityType),
+		optionalInt(category.FinancialGroupID),
+	})
+}
+
+func (w *csvWriter) WriteItem(item *dto.ExportItem) error {
+	return w.write(sectionItems, []string{
+		strconv.Itoa(item.ID),
+		item.Name,
+		strconv.Itoa(item.CategoryID),
+		optionalInt(item.FinancialGroupID),
+	})
+}
+
+func (w *csvWriter) WriteTransaction(transaction *dto.ExportTransaction) error {
+	return w.write(sectionTransactions, []string{
+		strconv.Itoa(transaction.ID),
+		strconv.Itoa(transaction.AccountID),
+		optionalInt(transaction.CategoryID),
+		string(transaction.Type),
+		string(transaction.Status),
+		transaction.Amount.String(),
+		optionalString(transaction.Description),
+		optionalString(transaction.ExchangeRate),
+		optionalInt(transaction.LinkedTransactionID),
+		optionalInt(transaction.ReversalOfID),
+		transaction.Date.Format(time.RFC3339),
+	})
+}
+
+func (w *csvWriter) WritePurchaseListItem(line *dto.ExportPurchaseListItem) error {
+	return w.write(sectionPurchaseListItems, []string{
+		strconv.Itoa(line.ID),
+		strconv.Itoa(line.TransactionID),
+		strconv.Itoa(line.ItemID),
+		strconv.Itoa(line.Count),
+		line.UnitPrice.String(),
+	})
+}
+
+func (w *csvWriter) TransactionsByAccount() bool {
+	return false
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.sections.finish(); err != nil {
+		w.archive.Close()
+		return err
+	}
+	return w.archive.Close()
+}
+
+func optionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
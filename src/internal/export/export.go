@@ -0,0 +1,110 @@
+// Package export writes a user's financial data in the formats offered for
+// download.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+)
+
+var errRecordOrder = errors.New("export records are out of order")
+
+// Writer encodes the records of an export as they are read, in the order of
+// repositories.ExportRecordWriter. Nothing is written to the underlying
+// writer before the first record or Close, so a failing query can still be
+// answered with an error.
+type Writer interface {
+	WriteAccount(account *dto.ExportAccount) error
+	WriteCategory(category *dto.ExportCategory) error
+	WriteItem(item *dto.ExportItem) error
+	WriteTransaction(transaction *dto.ExportTransaction) error
+	WritePurchaseListItem(line *dto.ExportPurchaseListItem) error
+	TransactionsByAccount() bool
+	// Close completes the file, kinds of records that had none are written
+	// empty.
+	Close() error
+}
+
+// NewWriter returns the Writer of format, CSV and OFX exports hold several
+// files and are written as a zip archive.
+func NewWriter(format enums.ExportFormat, w io.Writer, header *dto.ExportHeader) (Writer, error) {
+	switch format {
+	case enums.ExportJSON:
+		return newJSONWriter(w, header), nil
+	case enums.ExportOFX:
+		return newOFXWriter(w, header), nil
+	case enums.ExportCSV:
+		return newCSVWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// FileExtension is the extension of the file written for format.
+func FileExtension(format enums.ExportFormat) string {
+	if format == enums.ExportJSON {
+		return ".json"
+	}
+	return ".zip"
+}
+
+// ContentType is the MIME type of the file written for format.
+func ContentType(format enums.ExportFormat) string {
+	if format == enums.ExportJSON {
+		return "application/json"
+	}
+	return "application/zip"
+}
+
+// The kinds of records in the order they are written, the CSV and JSON
+// formats have one section for each.
+const (
+	sectionAccounts = iota
+	sectionCategories
+	sectionItems
+	sectionTransactions
+	sectionPurchaseListItems
+	sectionCount
+)
+
+// sectionSequence walks through the sections in order, the sections without
+// records are opened and closed too so every file or key is present.
+type sectionSequence struct {
+	current int
+	open    func(section int) error
+	close   func(section int) error
+}
+
+func newSectionSequence(open, close func(section int) error) *sectionSequence {
+	return &sectionSequence{current: -1, open: open, close: close}
+}
+
+func (s *sectionSequence) moveTo(section int) error {
+	if section < s.current {
+		return errRecordOrder
+	}
+	for s.current < section {
+		if s.current >= 0 {
+			if err := s.close(s.current); err != nil {
+				return err
+			}
+		}
+		s.current++
+		if err := s.open(s.current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish opens the remaining sections and closes the last one.
+func (s *sectionSequence) finish() error {
+	if err := s.moveTo(sectionCount - 1); err != nil {
+		return err
+	}
+	return s.close(s.current)
+}
@@ -0,0 +1,215 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+)
+
+var testHeader = dto.ExportHeader{ExportDate: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)}
+
+func TestJSONWriter(t *testing.T) {
+	description := "Coffee"
+	tests := []struct {
+		name  string
+		write func(w Writer) error
+		want  map[string]int
+	}{
+		{
+			name:  "no records",
+			write: func(w Writer) error { return nil },
+			want:  map[string]int{"accounts": 0, "categories": 0, "items": 0, "transactions": 0, "purchaseListItems": 0},
+		},
+		{
+			name: "skipped sections are empty",
+			write: func(w Writer) error {
+				return errors.Join(
+					w.WriteAccount(&dto.ExportAccount{ID: 1, Name: "Wallet"}),
+					w.WriteAccount(&dto.ExportAccount{ID: 2, Name: "Bank"}),
+					w.WriteTransaction(&dto.ExportTransaction{ID: 3, AccountID: 1, Amount: -350, Description: &description}),
+				)
+			},
+			want: map[string]int{"accounts": 2, "categories": 0, "items": 0, "transactions": 1, "purchaseListItems": 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			writer, err := NewWriter(enums.ExportJSON, &buffer, &testHeader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := test.write(writer); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			var decoded map[string]json.RawMessage
+			if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+				t.Fatalf("output is not valid JSON: %v\n%s", err, buffer.String())
+			}
+			if string(decoded["exportDate"]) != `"2024-03-01T12:00:00Z"` {
+				t.Errorf("exportDate = %s", decoded["exportDate"])
+			}
+			for key, count := range test.want {
+				var records []json.RawMessage
+				if err := json.Unmarshal(decoded[key], &records); err != nil || records == nil {
+					t.Fatalf("%s = %s, want an array", key, decoded[key])
+				}
+				if len(records) != count {
+					t.Errorf("%s has %d records, want %d", key, len(records), count)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterRejectsRecordsOutOfOrder(t *testing.T) {
+	for _, format := range []enums.ExportFormat{enums.ExportJSON, enums.ExportCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			writer, err := NewWriter(format, io.Discard, &testHeader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.WriteItem(&dto.ExportItem{ID: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.WriteAccount(&dto.ExportAccount{ID: 1}); !errors.Is(err, errRecordOrder) {
+				t.Fatalf("WriteAccount after WriteItem error = %v, want %v", err, errRecordOrder)
+			}
+		})
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(enums.ExportCSV, &buffer, &testHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteCategory(&dto.ExportCategory{ID: 4, Name: "Food, drinks"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WritePurchaseListItem(&dto.ExportPurchaseListItem{ID: 5, TransactionID: 3, ItemID: 2, Count: 2, UnitPrice: 125}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := readZip(t, buffer.Bytes())
+	wantRows := map[string]int{"accounts.csv": 1, "categories.csv": 2, "items.csv": 1, "transactions.csv": 1, "purchase_list_items.csv": 2}
+	if len(files) != len(wantRows) {
+		t.Fatalf("archive has %d files, want %d", len(files), len(wantRows))
+	}
+	for name, count := range wantRows {
+		records, err := csv.NewReader(strings.NewReader(files[name])).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(records) != count {
+			t.Errorf("%s has %d rows, want %d", name, len(records), count)
+		}
+	}
+	if !strings.Contains(files["categories.csv"], `4,"Food, drinks"`) {
+		t.Errorf("categories.csv = %q", files["categories.csv"])
+	}
+	if !strings.Contains(files["purchase_list_items.csv"], "5,3,2,2,1.25") {
+		t.Errorf("purchase_list_items.csv = %q", files["purchase_list_items.csv"])
+	}
+}
+
+func TestOFXWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(enums.ExportOFX, &buffer, &testHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !writer.TransactionsByAccount() {
+		t.Fatal("the OFX writer needs the transactions grouped by account")
+	}
+	for _, account := range []dto.ExportAccount{{ID: 1, Name: "Cash"}, {ID: 2, Name: "Main bank"}, {ID: 3, Name: "Savings"}} {
+		if err := writer.WriteAccount(&account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	longDescription := "Monthly rent for the apartment on Main Street"
+	transactions := []dto.ExportTransaction{
+		{ID: 10, AccountID: 2, Type: enums.TransactionExpense, Amount: -90000, Description: &longDescription, Date: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 11, AccountID: 2, Type: enums.TransactionIncome, Amount: 250000, Date: time.Date(2024, time.February, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, transaction := range transactions {
+		if err := writer.WriteTransaction(&transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := readZip(t, buffer.Bytes())
+	if len(files) != 3 {
+		t.Fatalf("archive has %d files, want one statement per account", len(files))
+	}
+	for name, wantTransactions := range map[string]int{"1_Cash.ofx": 0, "2_Main_bank.ofx": 2, "3_Savings.ofx": 0} {
+		statement, ok := files[name]
+		if !ok {
+			t.Fatalf("statement %s is missing", name)
+		}
+		if count := strings.Count(statement, "<STMTTRN>"); count != wantTransactions {
+			t.Errorf("%s has %d transactions, want %d", name, count, wantTransactions)
+		}
+		if !strings.HasSuffix(statement, "</OFX>\n") {
+			t.Errorf("%s is not complete", name)
+		}
+	}
+
+	statement := files["2_Main_bank.ofx"]
+	for _, want := range []string{
+		"<DTSTART>20240201000000</DTSTART><DTEND>20240301120000</DTEND>",
+		"<TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240201000000</DTPOSTED><TRNAMT>-900.00</TRNAMT><FITID>10</FITID>",
+		"<NAME>Monthly rent for the apartment o</NAME><MEMO>" + longDescription + "</MEMO>",
+		"<TRNTYPE>CREDIT</TRNTYPE>",
+	} {
+		if !strings.Contains(statement, want) {
+			t.Errorf("2_Main_bank.ofx does not contain %q:\n%s", want, statement)
+		}
+	}
+	if !strings.Contains(files["1_Cash.ofx"], "<DTSTART>20240301120000</DTSTART>") {
+		t.Errorf("a statement without transactions should start at the export date:\n%s", files["1_Cash.ofx"])
+	}
+}
+
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+	files := make(map[string]string, len(archive.File))
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = string(content)
+	}
+	return files
+}
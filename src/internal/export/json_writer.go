@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"shirinec.com/src/internal/dto"
+)
+
+var jsonSectionKeys = [sectionCount]string{"accounts", "categories", "items", "transactions", "purchaseListItems"}
+
+// jsonWriter writes a single indented JSON object, the header fields followed
+// by one array per kind of record. The arrays are written element by element.
+type jsonWriter struct {
+	out      *bufio.Writer
+	header   *dto.ExportHeader
+	sections *sectionSequence
+	// empty is set while the open array has no element yet
+	empty bool
+}
+
+func newJSONWriter(w io.Writer, header *dto.ExportHeader) *jsonWriter {
+	writer := &jsonWriter{out: bufio.NewWriter(w), header: header}
+	writer.sections = newSectionSequence(writer.openArray, writer.closeArray)
+	return writer
+}
+
+func (w *jsonWriter) openArray(section int) error {
+	if section == 0 {
+		header, err := json.MarshalIndent(w.header, "", "  ")
+		if err != nil {
+			return err
+		}
+		// The header object is left open, the arrays follow its fields
+		w.out.Write(header[:len(header)-2])
+		w.out.WriteString(",\n")
+	}
+	w.out.WriteString(`  "` + jsonSectionKeys[section] + `": [`)
+	w.empty = true
+	return nil
+}
+
+func (w *jsonWriter) closeArray(section int) error {
+	if !w.empty {
+		w.out.WriteString("\n  ")
+	}
+	w.out.WriteString("]")
+	if section < sectionCount-1 {
+		w.out.WriteString(",")
+	}
+	_, err := w.out.WriteString("\n")
+	return err
+}
+
+func (w *jsonWriter) write(section int, value any) error {
+	if err := w.sections.moveTo(section); err != nil {
+		return err
+	}
+	element, err := json.MarshalIndent(value, "    ", "  ")
+	if err != nil {
+		return err
+	}
+	if !w.empty {
+		w.out.WriteString(",")
+	}
+	w.out.WriteString("\n    ")
+	_, err = w.out.Write(element)
+	w.empty = false
+	return err
+}
+
+func (w *jsonWriter) WriteAccount(account *dto.ExportAccount) error {
+	return w.write(sectionAccounts, account)
+}
+
+func (w *jsonWriter) WriteCategory(category *dto.ExportCategory) error {
+	return w.write(sectionCategories, category)
+}
+
+func (w *jsonWriter) WriteItem(item *dto.ExportItem) error {
+	return w.write(sectionItems, item)
+}
+
+func (w *jsonWriter) WriteTransaction(transaction *dto.ExportTransaction) error {
+	return w.write(sectionTransactions, transaction)
+}
+
+func (w *jsonWriter) WritePurchaseListItem(line *dto.ExportPurchaseListItem) error {
+	return w.write(sectionPurchaseListItems, line)
+}
+
+func (w *jsonWriter) TransactionsByAccount() bool {
+	return false
+}
+
+func (w *jsonWriter) Close() error {
+	if err := w.sections.finish(); err != nil {
+		return err
+	}
+	w.out.WriteString("}\n")
+	return w.out.Flush()
+}
@@ -0,0 +1,198 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+
+const ofxDateLayout = "20060102150405"
+
+// ofxNameLength is the longest NAME the OFX specification allows, the full
+// description is kept in MEMO.
+const ofxNameLength = 32
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// ofxWriter writes one OFX 2.2 bank statement per account into a zip archive.
+// The accounts are kept until the transactions arrive, ordered by account
+// like the accounts are, so the statements are written in account order.
+type ofxWriter struct {
+	archive  *zip.Writer
+	header   *dto.ExportHeader
+	accounts []dto.ExportAccount
+	// next is the index in accounts of the first statement not started yet
+	next int
+	// statement is the open statement, nil before the first transaction and
+	// between accounts
+	statement *ofxStatement
+}
+
+type ofxStatement struct {
+	out     *bufio.Writer
+	account *dto.ExportAccount
+}
+
+func newOFXWriter(w io.Writer, header *dto.ExportHeader) *ofxWriter {
+	return &ofxWriter{archive: zip.NewWriter(w), header: header}
+}
+
+func (w *ofxWriter) WriteAccount(account *dto.ExportAccount) error {
+	if w.next > 0 || w.statement != nil {
+		return errRecordOrder
+	}
+	w.accounts = append(w.accounts, *account)
+	return nil
+}
+
+// Categories, items and purchase list lines have no place in a bank statement.
+
+func (w *ofxWriter) WriteCategory(category *dto.ExportCategory) error {
+	return nil
+}
+
+func (w *ofxWriter) WriteItem(item *dto.ExportItem) error {
+	return nil
+}
+
+func (w *ofxWriter) WritePurchaseListItem(line *dto.ExportPurchaseListItem) error {
+	return nil
+}
+
+func (w *ofxWriter) TransactionsByAccount() bool {
+	return true
+}
+
+func (w *ofxWriter) WriteTransaction(transaction *dto.ExportTransaction) error {
+	if w.statement != nil && w.statement.account.ID != transaction.AccountID {
+		if err := w.closeStatement(); err != nil {
+			return err
+		}
+	}
+	if w.statement == nil {
+		// Accounts before the one of transaction have no transactions
+		for w.next < len(w.accounts) && w.accounts[w.next].ID != transaction.AccountID {
+			if err := w.writeEmptyStatement(); err != nil {
+				return err
+			}
+		}
+		if w.next == len(w.accounts) {
+			return errRecordOrder
+		}
+		if err := w.openStatement(transaction.Date); err != nil {
+			return err
+		}
+	}
+	return w.statement.writeTransaction(transaction)
+}
+
+func (w *ofxWriter) Close() error {
+	err := w.closeStatement()
+	for err == nil && w.next < len(w.accounts) {
+		err = w.writeEmptyStatement()
+	}
+	if err != nil {
+		w.archive.Close()
+		return err
+	}
+	return w.archive.Close()
+}
+
+// openStatement starts the statement of the next account, the statement
+// starts at From or else at the date of its first transaction.
+func (w *ofxWriter) openStatement(start time.Time) error {
+	account := &w.accounts[w.next]
+	w.next++
+
+	name := fmt.Sprintf("%d_%s.ofx", account.ID, unsafeFileNameCharacters.ReplaceAllString(account.Name, "_"))
+	file, err := w.archive.Create(name)
+	if err != nil {
+		return err
+	}
+	end := w.header.ExportDate
+	if w.header.From != nil {
+		start = *w.header.From
+	}
+	if w.header.To != nil {
+		end = *w.header.To
+	}
+
+	out := bufio.NewWriter(file)
+	out.WriteString(ofxHeader)
+	out.WriteString("<OFX>\n")
+	out.WriteString("<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	fmt.Fprintf(out, "<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n", w.header.ExportDate.UTC().Format(ofxDateLayout))
+	out.WriteString("<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(out, "<STMTRS><CURDEF>%s</CURDEF>\n", escapeXML(account.Currency))
+	fmt.Fprintf(out, "<BANKACCTFROM><BANKID>0</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", account.ID)
+	fmt.Fprintf(out, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", start.UTC().Format(ofxDateLayout), end.UTC().Format(ofxDateLayout))
+
+	w.statement = &ofxStatement{out: out, account: account}
+	return nil
+}
+
+func (w *ofxWriter) closeStatement() error {
+	if w.statement == nil {
+		return nil
+	}
+	out := w.statement.out
+	out.WriteString("</BANKTRANLIST>\n")
+	fmt.Fprintf(out, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", w.statement.account.Balance.String(), w.header.ExportDate.UTC().Format(ofxDateLayout))
+	out.WriteString("</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n</OFX>\n")
+	w.statement = nil
+	return out.Flush()
+}
+
+func (w *ofxWriter) writeEmptyStatement() error {
+	if err := w.openStatement(w.header.ExportDate); err != nil {
+		return err
+	}
+	return w.closeStatement()
+}
+
+func (s *ofxStatement) writeTransaction(transaction *dto.ExportTransaction) error {
+	transactionType := "DEBIT"
+	if transaction.Type == enums.TransactionTransfer {
+		transactionType = "XFER"
+	} else if transaction.Amount > 0 {
+		transactionType = "CREDIT"
+	}
+
+	out := s.out
+	out.WriteString("<STMTTRN>")
+	fmt.Fprintf(out, "<TRNTYPE>%s</TRNTYPE>", transactionType)
+	fmt.Fprintf(out, "<DTPOSTED>%s</DTPOSTED>", transaction.Date.UTC().Format(ofxDateLayout))
+	fmt.Fprintf(out, "<TRNAMT>%s</TRNAMT>", transaction.Amount.String())
+	fmt.Fprintf(out, "<FITID>%s</FITID>", strconv.Itoa(transaction.ID))
+	if transaction.Description != nil && *transaction.Description != "" {
+		name := []rune(*transaction.Description)
+		if len(name) > ofxNameLength {
+			name = name[:ofxNameLength]
+		}
+		fmt.Fprintf(out, "<NAME>%s</NAME>", escapeXML(string(name)))
+		if len(name) < len([]rune(*transaction.Description)) {
+			fmt.Fprintf(out, "<MEMO>%s</MEMO>", escapeXML(*transaction.Description))
+		}
+	}
+	_, err := out.WriteString("</STMTTRN>\n")
+	return err
+}
+
+func escapeXML(value string) string {
+	var escaped bytes.Buffer
+	xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
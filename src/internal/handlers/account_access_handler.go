@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type AccountAccessHandler interface {
+	List(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type accountAccessHandler struct {
+	accountAccessService services.AccountAccessService
+}
+
+func NewAccountAccessHandler(accountAccessService services.AccountAccessService) AccountAccessHandler {
+	return &accountAccessHandler{accountAccessService: accountAccessService}
+}
+
+func (h *accountAccessHandler) List(c *gin.Context) {
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.List - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	accountAccessList, err := h.accountAccessService.List(context.Background(), accountID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, accountAccessList)
+}
+
+func (h *accountAccessHandler) Create(c *gin.Context) {
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.Create - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.AccountAccessCreateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("accountAccessHandler.Create - Binding user input to dto.AccountAccessCreateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	accountAccess, err := h.accountAccessService.Create(context.Background(), &input, accountID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[dto.AccountAccessResponse]{Result: *accountAccess})
+}
+
+func (h *accountAccessHandler) Update(c *gin.Context) {
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.Update - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		utils.Logger.Infof("accountAccessHandler.Update - Parsing userID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.Update - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	var input dto.AccountAccessUpdateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("accountAccessHandler.Update - Binding user input to dto.AccountAccessUpdateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	accountAccess, err := h.accountAccessService.Update(context.Background(), &input, accountID, targetUserID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, accountAccess)
+}
+
+func (h *accountAccessHandler) Delete(c *gin.Context) {
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.Delete - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		utils.Logger.Infof("accountAccessHandler.Delete - Parsing userID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("accountAccessHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.accountAccessService.Delete(context.Background(), accountID, targetUserID, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
@@ -20,6 +20,7 @@ type AccountHandler interface {
 	GetByID(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
+	SetOpeningBalance(c *gin.Context)
 }
 
 type accountHandler struct {
@@ -177,3 +178,37 @@ func (h *accountHandler) Update(c *gin.Context) {
 
 	c.JSON(http.StatusOK, item)
 }
+
+func (h *accountHandler) SetOpeningBalance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("Parsing id from param: %s", err)
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("Parsing uuid from user_id string: %s", err)
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	var input dto.AccountOpeningBalanceRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	item, err := h.accountService.SetOpeningBalance(context.Background(), &input, id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type BudgetHandler interface {
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	GetByID(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	ListAlerts(c *gin.Context)
+}
+
+type budgetHandler struct {
+	budgetService services.BudgetService
+}
+
+func NewBudgetHandler(budgetService services.BudgetService) BudgetHandler {
+	return &budgetHandler{budgetService: budgetService}
+}
+
+func (h *budgetHandler) Create(c *gin.Context) {
+	var input dto.BudgetCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("budgetHandler.Create - Binding user input to dto.BudgetCreateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	budget, err := h.budgetService.Create(context.Background(), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[dto.BudgetResponse]{Result: *budget})
+}
+
+func (h *budgetHandler) List(c *gin.Context) {
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("budgetHandler.List - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	budgets, err := h.budgetService.List(context.Background(), input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+func (h *budgetHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.GetByID - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.GetByID - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	budget, err := h.budgetService.GetByID(context.Background(), id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *budgetHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.Update - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.Update - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	var input dto.BudgetUpdateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("budgetHandler.Update - Binding user input to dto.BudgetUpdateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	budget, err := h.budgetService.Update(context.Background(), &input, id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *budgetHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.Delete - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.budgetService.Delete(context.Background(), id, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
+
+func (h *budgetHandler) ListAlerts(c *gin.Context) {
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("budgetHandler.ListAlerts - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("budgetHandler.ListAlerts - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	alerts, err := h.budgetService.ListAlerts(context.Background(), input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
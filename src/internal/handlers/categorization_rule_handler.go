@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type CategorizationRuleHandler interface {
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	GetByID(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Apply(c *gin.Context)
+}
+
+type categorizationRuleHandler struct {
+	categorizationRuleService services.CategorizationRuleService
+}
+
+func NewCategorizationRuleHandler(categorizationRuleService services.CategorizationRuleService) CategorizationRuleHandler {
+	return &categorizationRuleHandler{categorizationRuleService: categorizationRuleService}
+}
+
+func (h *categorizationRuleHandler) Create(c *gin.Context) {
+	var input dto.CategorizationRuleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("categorizationRuleHandler.Create - Binding user input to dto.CategorizationRuleRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	rule, err := h.categorizationRuleService.Create(context.Background(), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[dto.CategorizationRuleResponse]{Result: *rule})
+}
+
+func (h *categorizationRuleHandler) List(c *gin.Context) {
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("categorizationRuleHandler.List - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	rules, err := h.categorizationRuleService.List(context.Background(), input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *categorizationRuleHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.GetByID - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.GetByID - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	rule, err := h.categorizationRuleService.GetByID(context.Background(), id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *categorizationRuleHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.Update - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.Update - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	var input dto.CategorizationRuleRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("categorizationRuleHandler.Update - Binding user input to dto.CategorizationRuleRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	rule, err := h.categorizationRuleService.Update(context.Background(), &input, id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *categorizationRuleHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.Delete - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.categorizationRuleService.Delete(context.Background(), id, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
+
+func (h *categorizationRuleHandler) Apply(c *gin.Context) {
+	var input dto.CategorizationRuleApplyRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("categorizationRuleHandler.Apply - Binding user input to dto.CategorizationRuleApplyRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleHandler.Apply - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	result, err := h.categorizationRuleService.Apply(context.Background(), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
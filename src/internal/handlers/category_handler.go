@@ -61,6 +61,7 @@ func (h *categoryHandler) Create(c *gin.Context) {
 	category.Color = &input.Color
 	category.IconID = input.IconID
 	category.EntityType = &input.Type
+	category.FinancialGroupID = input.FinancialGroupID
 
 	err = h.categoryService.Create(&category)
 	if err != nil {
@@ -2,14 +2,30 @@ package handler
 
 import (
 	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/storage"
 )
 
 type Dependencies struct {
-	UserRepo           repositories.UserRepository
-	CategoryRepo       repositories.CategoryRepository
-	ItemRepo           repositories.ItemRepository
-	AccountRepo        repositories.AccountRepository
-	MediaRepo          repositories.MediaRepository
-	FinancialGroupRepo repositories.FinancialGroupRepository
-	TransactionRepo    repositories.TransactionRepository
+	UserRepo                     repositories.UserRepository
+	CategoryRepo                 repositories.CategoryRepository
+	ItemRepo                     repositories.ItemRepository
+	AccountRepo                  repositories.AccountRepository
+	MediaRepo                    repositories.MediaRepository
+	FinancialGroupRepo           repositories.FinancialGroupRepository
+	TransactionRepo              repositories.TransactionRepository
+	ExchangeRateRepo             repositories.ExchangeRateRepository
+	PurchaseListItemRepo         repositories.PurchaseListItemRepository
+	AccountAccessRepo            repositories.AccountAccessRepository
+	FinancialGroupInvitationRepo repositories.FinancialGroupInvitationRepository
+	BudgetRepo                   repositories.BudgetRepository
+	RecurringTransactionRepo     repositories.RecurringTransactionRepository
+	ScheduledTransferRepo        repositories.ScheduledTransferRepository
+	StatementImportRepo          repositories.StatementImportRepository
+	CategorizationRuleRepo       repositories.CategorizationRuleRepository
+	ExportRepo                   repositories.ExportRepository
+	ReportRepo                   repositories.ReportRepository
+	NetWorthRepo                 repositories.NetWorthRepository
+	ReconciliationRepo           repositories.ReconciliationRepository
+	TransactionAttachmentRepo    repositories.TransactionAttachmentRepository
+	Storage                      storage.Storage
 }
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type ExchangeRateHandler interface {
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	GetByID(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type exchangeRateHandler struct {
+	exchangeRateService services.ExchangeRateService
+}
+
+func NewExchangeRateHandler(exchangeRateService services.ExchangeRateService) ExchangeRateHandler {
+	return &exchangeRateHandler{exchangeRateService: exchangeRateService}
+}
+
+func (h *exchangeRateHandler) Create(c *gin.Context) {
+	var input dto.ExchangeRateCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("exchangeRateHandler.Create - Binding user input to dto.ExchangeRateCreateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	exchangeRate, err := h.exchangeRateService.Create(context.Background(), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[models.ExchangeRate]{Result: *exchangeRate})
+}
+
+func (h *exchangeRateHandler) List(c *gin.Context) {
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("exchangeRateHandler.List - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	exchangeRates, err := h.exchangeRateService.List(context.Background(), input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, exchangeRates)
+}
+
+func (h *exchangeRateHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.GetByID - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.GetByID - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	exchangeRate, err := h.exchangeRateService.GetByID(context.Background(), id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, exchangeRate)
+}
+
+func (h *exchangeRateHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.Update - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.Update - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	var input dto.ExchangeRateUpdateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("exchangeRateHandler.Update - Binding user input to dto.ExchangeRateUpdateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	exchangeRate, err := h.exchangeRateService.Update(context.Background(), &input, id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, exchangeRate)
+}
+
+func (h *exchangeRateHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.Delete - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.exchangeRateService.Delete(context.Background(), id, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
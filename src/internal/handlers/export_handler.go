@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/export"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type ExportHandler interface {
+	Export(c *gin.Context)
+	ListJobs(c *gin.Context)
+	GetJob(c *gin.Context)
+}
+
+type exportHandler struct {
+	exportService services.ExportService
+}
+
+func NewExportHandler(exportService services.ExportService) ExportHandler {
+	return &exportHandler{exportService: exportService}
+}
+
+// Export streams the file as an attachment, or answers 202 with the export
+// job when the export is too large to be written during the request.
+func (h *exportHandler) Export(c *gin.Context) {
+	var input dto.ExportRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("exportHandler.Export - Binding input query to dto.ExportRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exportHandler.Export - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	job, err := h.exportService.Export(context.Background(), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	if job != nil {
+		c.JSON(http.StatusAccepted, dto.CreateResponse[dto.ExportJobResponse]{Result: *job})
+		return
+	}
+
+	exportDate := time.Now()
+	fileName := fmt.Sprintf("export-%s%s", exportDate.Format("2006-01-02"), export.FileExtension(input.Format))
+	c.Header("Content-Type", export.ContentType(input.Format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	if err := h.exportService.Write(context.Background(), &input, userID, exportDate, c.Writer); err != nil {
+		// Once records are written the headers are sent, the client only sees
+		// a truncated file
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(err.(*server_errors.SError).Unwrap())
+		}
+	}
+}
+
+func (h *exportHandler) ListJobs(c *gin.Context) {
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("exportHandler.ListJobs - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exportHandler.ListJobs - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	jobs, err := h.exportService.ListJobs(context.Background(), input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+func (h *exportHandler) GetJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("exportHandler.GetJob - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("exportHandler.GetJob - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	job, err := h.exportService.GetJob(context.Background(), id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -15,11 +15,12 @@ import (
 
 type FinancialGroupHandler interface {
 	Create(c *gin.Context)
-	AddUser(c *gin.Context)
 	GetByID(c *gin.Context)
 	List(c *gin.Context)
 	Delete(c *gin.Context)
 	RemoveGroupMember(c *gin.Context)
+	UpdateMemberRole(c *gin.Context)
+	TransferOwnership(c *gin.Context)
 }
 
 type financialGroupHandler struct {
@@ -62,36 +63,6 @@ func (h *financialGroupHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusOK, item)
 }
 
-func (h *financialGroupHandler) AddUser(c *gin.Context) {
-	financialGroupID, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		utils.Logger.Errorf("financialGroupHandler.AddUser - Parsing id: %s", err.Error())
-		c.JSON(server_errors.InvalidInput.Unwrap())
-		return
-	}
-
-	memberID, err := uuid.Parse(c.Param("userID"))
-	if err != nil {
-		utils.Logger.Errorf("financialGroupHandler.AddUser - Getting userID param: %s", err.Error())
-		c.JSON(server_errors.InvalidInput.Unwrap())
-		return
-	}
-
-	userID, err := uuid.Parse(c.GetString("user_id"))
-	if err != nil {
-		utils.Logger.Errorf("financialGroupHandler.AddUser - Parsing uuid from user_id: %s", err.Error())
-		c.JSON(server_errors.InternalError.Unwrap())
-		return
-	}
-
-	if err = h.financialGroupService.AddUserToGroup(context.Background(), financialGroupID, memberID, userID); err != nil {
-		c.JSON(err.(*server_errors.SError).Unwrap())
-		return
-	}
-
-	c.Status(http.StatusOK)
-}
-
 func (h *financialGroupHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -190,3 +161,78 @@ func (h *financialGroupHandler) RemoveGroupMember(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+func (h *financialGroupHandler) UpdateMemberRole(c *gin.Context) {
+	financialGroupID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupHandler.UpdateMemberRole - Parsing id: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupHandler.UpdateMemberRole - Getting userID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.FinancialGroupMemberRoleRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("financialGroupHandler.UpdateMemberRole - Binding request body to dto.FinancialGroupMemberRoleRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupHandler.UpdateMemberRole - Parsing uuid from user_id: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err := h.financialGroupService.UpdateMemberRole(context.Background(), &input, financialGroupID, memberID, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *financialGroupHandler) TransferOwnership(c *gin.Context) {
+	financialGroupID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupHandler.TransferOwnership - Parsing id: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.FinancialGroupTransferRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("financialGroupHandler.TransferOwnership - Binding request body to dto.FinancialGroupTransferRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupHandler.TransferOwnership - Parsing uuid from user_id: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err := h.financialGroupService.TransferOwnership(context.Background(), &input, financialGroupID, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type FinancialGroupInvitationHandler interface {
+	Create(c *gin.Context)
+	ListByGroup(c *gin.Context)
+	Revoke(c *gin.Context)
+	ListPending(c *gin.Context)
+	Accept(c *gin.Context)
+	Decline(c *gin.Context)
+}
+
+type financialGroupInvitationHandler struct {
+	invitationService services.FinancialGroupInvitationService
+}
+
+func NewFinancialGroupInvitationHandler(invitationService services.FinancialGroupInvitationService) FinancialGroupInvitationHandler {
+	return &financialGroupInvitationHandler{invitationService: invitationService}
+}
+
+func (h *financialGroupInvitationHandler) Create(c *gin.Context) {
+	financialGroupID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Create - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.FinancialGroupInvitationCreateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("financialGroupInvitationHandler.Create - Binding user input to dto.FinancialGroupInvitationCreateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	invitation, err := h.invitationService.Create(context.Background(), &input, financialGroupID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+func (h *financialGroupInvitationHandler) ListByGroup(c *gin.Context) {
+	financialGroupID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.ListByGroup - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.ListByGroup - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	invitations, err := h.invitationService.ListByGroup(context.Background(), financialGroupID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *financialGroupInvitationHandler) Revoke(c *gin.Context) {
+	financialGroupID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Revoke - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	invitationID, err := strconv.Atoi(c.Param("invitationID"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Revoke - Parsing invitationID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Revoke - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.invitationService.Revoke(context.Background(), invitationID, financialGroupID, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *financialGroupInvitationHandler) ListPending(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.ListPending - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	invitations, err := h.invitationService.ListPending(context.Background(), userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *financialGroupInvitationHandler) Accept(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Accept - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	invitation, err := h.invitationService.Accept(context.Background(), c.Param("token"), userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+func (h *financialGroupInvitationHandler) Decline(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationHandler.Decline - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.invitationService.Decline(context.Background(), c.Param("token"), userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -2,9 +2,9 @@ package handler
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"mime/multipart"
 	"net/http"
-	"path/filepath"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -13,6 +13,7 @@ import (
 	"shirinec.com/src/internal/enums"
 	"shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/storage"
 	"shirinec.com/src/internal/utils"
 )
 
@@ -20,23 +21,46 @@ type MediaHandler interface {
 	Upload(c *gin.Context)
 	GetMedia(c *gin.Context)
 	UpdateMedia(c *gin.Context)
+	UpdateAccess(c *gin.Context)
+	Move(c *gin.Context)
+	Delete(c *gin.Context)
 }
 
 type mediaHandler struct {
 	mediaService services.MediaService
+	storage      storage.Storage
 }
 
-func NewMediaHandler(mediaService services.MediaService) MediaHandler {
-	return &mediaHandler{mediaService: mediaService}
+func NewMediaHandler(mediaService services.MediaService, mediaStorage storage.Storage) MediaHandler {
+	return &mediaHandler{mediaService: mediaService, storage: mediaStorage}
 }
 
-func (h *mediaHandler) Upload(c *gin.Context) {
+// openUpload opens the uploaded file after checking its declared size, the
+// content is checked too, the declared size only saves reading a file that
+// is too large anyway.
+func openUpload(c *gin.Context, caller string) (multipart.File, *server_errors.SError) {
+	// Documents are sent as "file", "image" is kept for the existing clients
 	file, err := c.FormFile("image")
+	if errors.Is(err, http.ErrMissingFile) {
+		file, err = c.FormFile("file")
+	}
 	if err != nil {
-		c.JSON(server_errors.FileRequired.Unwrap())
-		return
+		return nil, &server_errors.FileRequired
+	}
+
+	if file.Size > config.AppConfig.MediaMaxUploadSize {
+		return nil, &server_errors.MediaFileTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		utils.Logger.Errorf("%s - Opening uploaded file: %s", caller, err.Error())
+		return nil, &server_errors.InternalError
 	}
+	return src, nil
+}
 
+func (h *mediaHandler) Upload(c *gin.Context) {
 	var input dto.MediaUploadQuery
 	input.Access = enums.Owner
 	if err := c.ShouldBindQuery(&input); err != nil {
@@ -51,21 +75,14 @@ func (h *mediaHandler) Upload(c *gin.Context) {
 		return
 	}
 
-	ext := filepath.Ext(file.Filename)
-	if ext != ".png" && ext != ".jpg" && ext != ".jpeg" {
-		c.JSON(server_errors.InvalidFileFormat.Unwrap())
-		return
-	}
-
-	fileName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
-	savePath := fmt.Sprintf("%s/%s", config.AppConfig.UploadFolder, fileName)
-	if err = c.SaveUploadedFile(file, savePath); err != nil {
-		utils.Logger.Errorf("mediaHandler.Upload - Saving file: %s", err.Error())
-		c.JSON(server_errors.InternalError.Unwrap())
+	src, sErr := openUpload(c, "mediaHandler.Upload")
+	if sErr != nil {
+		c.JSON(sErr.Unwrap())
 		return
 	}
+	defer src.Close()
 
-	media, err := h.mediaService.Create(context.Background(), fileName, userID, &input)
+	media, err := h.mediaService.Create(context.Background(), src, userID, &input)
 	if err != nil {
 		c.JSON(err.(*server_errors.SError).Unwrap())
 		return
@@ -81,16 +98,141 @@ func (h *mediaHandler) GetMedia(c *gin.Context) {
 		return
 	}
 
+	var input dto.MediaFileQuery
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	ctx := context.Background()
 	mediaName := c.Param("fileName")
-	mediaPath, err := h.mediaService.GetMedia(context.Background(), mediaName, userID)
+	key, err := h.mediaService.GetMedia(ctx, mediaName, input.Size, userID)
 	if err != nil {
 		c.JSON(err.(*server_errors.SError).Unwrap())
 		return
 	}
 
-	c.File(mediaPath)
+	// The access check above is the only one, a presigned URL is short lived
+	// so the client reads the file straight from the storage
+	url, err := h.storage.PresignedURL(ctx, key)
+	if err != nil {
+		utils.Logger.Errorf("mediaHandler.GetMedia - Presigning %s: %s", key, err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+	if url != "" {
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	object, err := h.storage.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.JSON(server_errors.ItemNotFound.Unwrap())
+			return
+		}
+		utils.Logger.Errorf("mediaHandler.GetMedia - Opening %s: %s", key, err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+	defer object.Close()
+
+	c.DataFromReader(http.StatusOK, object.Size, object.ContentType, object, nil)
 }
 
+// UpdateMedia replaces the content of the media, its URL stays the same.
 func (h *mediaHandler) UpdateMedia(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("mediaHandler.UpdateMedia - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	src, sErr := openUpload(c, "mediaHandler.UpdateMedia")
+	if sErr != nil {
+		c.JSON(sErr.Unwrap())
+		return
+	}
+	defer src.Close()
+
+	media, err := h.mediaService.Replace(context.Background(), c.Param("fileName"), src, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+func (h *mediaHandler) UpdateAccess(c *gin.Context) {
+	var input dto.MediaAccessRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("mediaHandler.UpdateAccess - Binding user input to dto.MediaAccessRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("mediaHandler.UpdateAccess - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	media, err := h.mediaService.UpdateAccess(context.Background(), c.Param("fileName"), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+func (h *mediaHandler) Move(c *gin.Context) {
+	var input dto.MediaMoveRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("mediaHandler.Move - Binding user input to dto.MediaMoveRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("mediaHandler.Move - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	media, err := h.mediaService.Move(context.Background(), c.Param("fileName"), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+func (h *mediaHandler) Delete(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("mediaHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err := h.mediaService.Delete(context.Background(), c.Param("fileName"), userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
 
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
 }
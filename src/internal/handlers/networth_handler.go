@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type NetWorthHandler interface {
+	History(c *gin.Context)
+	Backfill(c *gin.Context)
+}
+
+type netWorthHandler struct {
+	netWorthService services.NetWorthService
+}
+
+func NewNetWorthHandler(netWorthService services.NetWorthService) NetWorthHandler {
+	return &netWorthHandler{netWorthService: netWorthService}
+}
+
+func (h *netWorthHandler) History(c *gin.Context) {
+	var input dto.NetWorthRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("netWorthHandler.History - Binding input query to dto.NetWorthRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("netWorthHandler.History - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	history, err := h.netWorthService.History(context.Background(), &input, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *netWorthHandler) Backfill(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("netWorthHandler.Backfill - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	result, err := h.netWorthService.Backfill(context.Background(), userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type PurchaseListItemHandler interface {
+	List(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type purchaseListItemHandler struct {
+	purchaseListItemService services.PurchaseListItemService
+}
+
+func NewPurchaseListItemHandler(purchaseListItemService services.PurchaseListItemService) PurchaseListItemHandler {
+	return &purchaseListItemHandler{purchaseListItemService: purchaseListItemService}
+}
+
+func (h *purchaseListItemHandler) List(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.List - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	purchaseList, err := h.purchaseListItemService.List(context.Background(), transactionID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseList)
+}
+
+func (h *purchaseListItemHandler) Create(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Create - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.PurchaseListItemCreateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("purchaseListItemHandler.Create - Binding user input to dto.PurchaseListItemCreateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	purchaseListItem, err := h.purchaseListItemService.Create(context.Background(), &input, transactionID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[dto.PurchaseListItemJoinedResponse]{Result: *purchaseListItem})
+}
+
+func (h *purchaseListItemHandler) Update(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Update - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("lineID"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Update - Parsing lineID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Update - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	var input dto.PurchaseListItemUpdateRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("purchaseListItemHandler.Update - Binding user input to dto.PurchaseListItemUpdateRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	purchaseListItem, err := h.purchaseListItemService.Update(context.Background(), &input, id, transactionID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseListItem)
+}
+
+func (h *purchaseListItemHandler) Delete(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Delete - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("lineID"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Delete - Parsing lineID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("purchaseListItemHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.purchaseListItemService.Delete(context.Background(), id, transactionID, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
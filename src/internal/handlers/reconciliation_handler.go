@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type ReconciliationHandler interface {
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	ListDiscrepancies(c *gin.Context)
+}
+
+type reconciliationHandler struct {
+	reconciliationService services.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService services.ReconciliationService) ReconciliationHandler {
+	return &reconciliationHandler{reconciliationService: reconciliationService}
+}
+
+func (h *reconciliationHandler) Create(c *gin.Context) {
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("reconciliationHandler.Create - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.AccountReconcileRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("reconciliationHandler.Create - Binding user input to dto.AccountReconcileRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("reconciliationHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Create(context.Background(), &input, accountID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[models.AccountReconciliation]{Result: *reconciliation})
+}
+
+func (h *reconciliationHandler) List(c *gin.Context) {
+	accountID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("reconciliationHandler.List - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("reconciliationHandler.List - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("reconciliationHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	reconciliations, err := h.reconciliationService.List(context.Background(), accountID, input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliations)
+}
+
+func (h *reconciliationHandler) ListDiscrepancies(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("reconciliationHandler.ListDiscrepancies - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	discrepancies, err := h.reconciliationService.ListDiscrepancies(context.Background(), userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"discrepancies": discrepancies})
+}
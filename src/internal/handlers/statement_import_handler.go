@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+// maxStatementSize is far above what a bank exports for the row limit of a
+// statement.
+const maxStatementSize = 5 << 20
+
+type StatementImportHandler interface {
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	GetByID(c *gin.Context)
+	Commit(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type statementImportHandler struct {
+	statementImportService services.StatementImportService
+}
+
+func NewStatementImportHandler(statementImportService services.StatementImportService) StatementImportHandler {
+	return &statementImportHandler{statementImportService: statementImportService}
+}
+
+func (h *statementImportHandler) Create(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(server_errors.FileRequired.Unwrap())
+		return
+	}
+	if fileHeader.Size > maxStatementSize {
+		c.JSON(server_errors.StatementFileTooLarge.Unwrap())
+		return
+	}
+
+	var input dto.StatementImportRequest
+	if err := c.ShouldBind(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("statementImportHandler.Create - Binding user input to dto.StatementImportRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.Create - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.Create - Opening uploaded file: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+	defer file.Close()
+
+	statementImport, err := h.statementImportService.Create(context.Background(), &input, file, fileHeader.Filename, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[dto.StatementImportResponse]{Result: *statementImport})
+}
+
+func (h *statementImportHandler) List(c *gin.Context) {
+	var input dto.ListRequest
+	if err := c.ShouldBindQuery(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("statementImportHandler.List - Binding input query to dto.ListRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	imports, err := h.statementImportService.List(context.Background(), input.Page, input.Size, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, imports)
+}
+
+func (h *statementImportHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.GetByID - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.GetByID - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	statementImport, err := h.statementImportService.GetByID(context.Background(), id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, statementImport)
+}
+
+func (h *statementImportHandler) Commit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.Commit - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	// The body is optional, committing without one imports every row that is
+	// not a duplicate
+	var input dto.StatementImportCommitRequest
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&input); err != nil {
+			if errList := server_errors.AsValidatorError(err); errList != nil {
+				c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+				return
+			}
+			utils.Logger.Warnf("statementImportHandler.Commit - Binding user input to dto.StatementImportCommitRequest: %s", err.Error())
+			c.JSON(server_errors.InvalidInput.Unwrap())
+			return
+		}
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.Commit - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	statementImport, err := h.statementImportService.Commit(context.Background(), &input, id, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, statementImport)
+}
+
+func (h *statementImportHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.Delete - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("statementImportHandler.Delete - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.statementImportService.Delete(context.Background(), id, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/services"
+	"shirinec.com/src/internal/utils"
+)
+
+type TransactionAttachmentHandler interface {
+	Attach(c *gin.Context)
+	List(c *gin.Context)
+	Detach(c *gin.Context)
+}
+
+type transactionAttachmentHandler struct {
+	transactionAttachmentService services.TransactionAttachmentService
+}
+
+func NewTransactionAttachmentHandler(transactionAttachmentService services.TransactionAttachmentService) TransactionAttachmentHandler {
+	return &transactionAttachmentHandler{transactionAttachmentService: transactionAttachmentService}
+}
+
+func (h *transactionAttachmentHandler) Attach(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.Attach - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	var input dto.TransactionAttachRequest
+	if err = c.ShouldBindJSON(&input); err != nil {
+		if errList := server_errors.AsValidatorError(err); errList != nil {
+			c.JSON(server_errors.ValidationErrorBuilder(errList).Unwrap())
+			return
+		}
+		utils.Logger.Warnf("transactionAttachmentHandler.Attach - Binding user input to dto.TransactionAttachRequest: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.Attach - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	attachment, err := h.transactionAttachmentService.Attach(context.Background(), &input, transactionID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CreateResponse[dto.TransactionAttachment]{Result: *attachment})
+}
+
+func (h *transactionAttachmentHandler) List(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.List - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.List - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	attachments, err := h.transactionAttachmentService.List(context.Background(), transactionID, userID)
+	if err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+func (h *transactionAttachmentHandler) Detach(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.Detach - Parsing id param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	mediaID, err := strconv.Atoi(c.Param("mediaID"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.Detach - Parsing mediaID param: %s", err.Error())
+		c.JSON(server_errors.InvalidInput.Unwrap())
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		utils.Logger.Errorf("transactionAttachmentHandler.Detach - Parsing uuid from user_id string: %s", err.Error())
+		c.JSON(server_errors.InternalError.Unwrap())
+		return
+	}
+
+	if err = h.transactionAttachmentService.Detach(context.Background(), transactionID, mediaID, userID); err != nil {
+		c.JSON(err.(*server_errors.SError).Unwrap())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "Item deleted successfully!"})
+}
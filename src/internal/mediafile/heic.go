@@ -0,0 +1,45 @@
+package mediafile
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// processHEIC converts photos taken by phones into JPEG with converter, which
+// is heif-convert or a tool taking the same arguments. HEIC holds HEVC coded
+// images Go has no decoder for, without a converter the upload is rejected.
+func processHEIC(ctx context.Context, data []byte, converter string) (*Result, error) {
+	if converter == "" {
+		return nil, ErrUnsupportedFormat
+	}
+	if _, err := exec.LookPath(converter); err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	dir, err := os.MkdirTemp("", "shirinec-heic-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.heic")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, converterTimeout)
+	defer cancel()
+	output := filepath.Join(dir, "output.jpg")
+	if err := exec.CommandContext(ctx, converter, "-q", "100", input, output).Run(); err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	converted, err := os.ReadFile(output)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	// The converter already applied the rotation stored in the HEIC container
+	return processImage(converted, "image/jpeg", false)
+}
@@ -0,0 +1,247 @@
+// Package mediafile validates uploaded media by their content and prepares
+// what gets stored: images are decoded, re-encoded without their metadata
+// and resized into thumbnail variants. PDFs are stored as they are with a
+// preview of their first page as variants.
+package mediafile
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"golang.org/x/image/draw"
+)
+
+// maxPixels caps the decoded size of an image, a small file can otherwise
+// claim dimensions that take gigabytes to decode.
+const maxPixels = 40_000_000
+
+const jpegQuality = 88
+
+// converterTimeout bounds the external tools rendering PDFs and decoding HEIC.
+const converterTimeout = 30 * time.Second
+
+var (
+	ErrTooLarge          = errors.New("file is too large")
+	ErrUnsupportedFormat = errors.New("file format is not supported")
+)
+
+// Size is a thumbnail variant, images are scaled down to fit a square of
+// MaxSide pixels and never scaled up.
+type Size struct {
+	Name    string
+	MaxSide int
+}
+
+var Sizes = []Size{
+	{Name: "small", MaxSide: 160},
+	{Name: "medium", MaxSide: 480},
+	{Name: "large", MaxSide: 1280},
+}
+
+// File is an encoded file ready to be stored.
+type File struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+type VariantMetadata struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	Size   int `json:"size"`
+}
+
+// Metadata is saved as JSON in media.metadata, it describes the stored file
+// and not the upload, which may have been larger or carried EXIF data.
+type Metadata struct {
+	ContentType string                     `json:"contentType"`
+	Width       int                        `json:"width,omitempty"`
+	Height      int                        `json:"height,omitempty"`
+	Pages       int                        `json:"pages,omitempty"`
+	Size        int                        `json:"size"`
+	SHA256      string                     `json:"sha256"`
+	Variants    map[string]VariantMetadata `json:"variants,omitempty"`
+}
+
+// Options are the limits of an upload and the external tools used for the
+// formats Go can not decode, an empty tool disables its format or preview.
+type Options struct {
+	MaxSize       int64
+	PDFRenderer   string
+	HEICConverter string
+}
+
+// Result holds the file to store under the media key, its variants keyed by
+// size name and the extension the key should use.
+type Result struct {
+	Original  File
+	Variants  map[string]File
+	Extension string
+	Metadata  Metadata
+}
+
+// Process reads at most options.MaxSize bytes from reader and detects the
+// format from the content, the file name is never trusted.
+func Process(ctx context.Context, reader io.Reader, options Options) (*Result, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, options.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > options.MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	switch contentType := mimetype.Detect(data).String(); contentType {
+	case "image/png", "image/jpeg":
+		return processImage(data, contentType, true)
+	case "image/heic", "image/heif":
+		return processHEIC(ctx, data, options.HEICConverter)
+	case "application/pdf":
+		return processPDF(ctx, data, options.PDFRenderer)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// decodeImage decodes a PNG or JPEG after checking its dimensions.
+func decodeImage(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	return img, nil
+}
+
+// processImage re-encodes the image, orient tells whether the EXIF
+// orientation still has to be applied to the pixels.
+func processImage(data []byte, contentType string, orient bool) (*Result, error) {
+	img, err := decodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+	// Re-encoding drops the EXIF data, the orientation it held is applied to
+	// the pixels so photos are not shown sideways
+	if orient && contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	original, err := encodeImage(img, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newResult(*original, contentType)
+	result.Metadata.Width = original.Width
+	result.Metadata.Height = original.Height
+	if err := result.addVariants(img, contentType); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func newResult(original File, contentType string) *Result {
+	hash := sha256.Sum256(original.Data)
+	return &Result{
+		Original:  original,
+		Variants:  make(map[string]File, len(Sizes)),
+		Extension: extensions[contentType],
+		Metadata: Metadata{
+			ContentType: contentType,
+			Size:        len(original.Data),
+			SHA256:      hex.EncodeToString(hash[:]),
+			Variants:    make(map[string]VariantMetadata, len(Sizes)),
+		},
+	}
+}
+
+// addVariants encodes img scaled to every size.
+func (r *Result) addVariants(img image.Image, contentType string) error {
+	for _, size := range Sizes {
+		variant, err := encodeImage(fit(img, size.MaxSide), contentType)
+		if err != nil {
+			return err
+		}
+		r.Variants[size.Name] = *variant
+		r.Metadata.Variants[size.Name] = VariantMetadata{Width: variant.Width, Height: variant.Height, Size: len(variant.Data)}
+	}
+	return nil
+}
+
+var extensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"application/pdf": ".pdf",
+}
+
+// variantExtensions are the extensions of the variants of files that are not
+// images themselves, the variants of images keep the original one.
+var variantExtensions = map[string]string{
+	".pdf": ".jpg",
+}
+
+func encodeImage(img image.Image, contentType string) (*File, error) {
+	var buffer bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buffer, img)
+	} else {
+		err = jpeg.Encode(&buffer, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	return &File{Data: buffer.Bytes(), ContentType: contentType, Width: bounds.Dx(), Height: bounds.Dy()}, nil
+}
+
+// fit scales img down so its longest side is at most maxSide.
+func fit(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*maxSide/width)
+		width = maxSide
+	} else {
+		width = max(1, width*maxSide/height)
+		height = maxSide
+	}
+
+	scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, bounds, draw.Src, nil)
+	return scaled
+}
+
+// VariantKey is the storage key of a variant of the file stored under key.
+func VariantKey(key, size string) string {
+	extension := path.Ext(key)
+	variantExtension, ok := variantExtensions[extension]
+	if !ok {
+		variantExtension = extension
+	}
+	return strings.TrimSuffix(key, extension) + "_" + size + variantExtension
+}
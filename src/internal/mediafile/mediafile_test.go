@@ -0,0 +1,278 @@
+package mediafile
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		maxSize         int64
+		wantContentType string
+		wantExtension   string
+		wantWidth       int
+		wantHeight      int
+		wantErr         error
+	}{
+		{name: "png", data: encodePNG(t, 200, 100), maxSize: 1 << 20, wantContentType: "image/png", wantExtension: ".png", wantWidth: 200, wantHeight: 100},
+		{name: "jpeg", data: encodeJPEG(t, 100, 300), maxSize: 1 << 20, wantContentType: "image/jpeg", wantExtension: ".jpg", wantWidth: 100, wantHeight: 300},
+		{name: "gif", data: encodeGIF(t, 10, 10), maxSize: 1 << 20, wantErr: ErrUnsupportedFormat},
+		{name: "text", data: []byte("just some text"), maxSize: 1 << 20, wantErr: ErrUnsupportedFormat},
+		{name: "png name on other content", data: append([]byte("\x89PNX"), encodePNG(t, 10, 10)[4:]...), maxSize: 1 << 20, wantErr: ErrUnsupportedFormat},
+		{name: "truncated jpeg", data: encodeJPEG(t, 100, 100)[:200], maxSize: 1 << 20, wantErr: ErrUnsupportedFormat},
+		{name: "larger than the limit", data: encodePNG(t, 64, 64), maxSize: 10, wantErr: ErrTooLarge},
+		{name: "too many pixels", data: pngHeader(10_000, 10_000), maxSize: 1 << 20, wantErr: ErrTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Process(context.Background(), bytes.NewReader(test.data), Options{MaxSize: test.maxSize})
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Process error = %v, want %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Metadata.ContentType != test.wantContentType || result.Original.ContentType != test.wantContentType {
+				t.Errorf("content type = %q and %q, want %q", result.Metadata.ContentType, result.Original.ContentType, test.wantContentType)
+			}
+			if result.Extension != test.wantExtension {
+				t.Errorf("extension = %q, want %q", result.Extension, test.wantExtension)
+			}
+			if result.Metadata.Width != test.wantWidth || result.Metadata.Height != test.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d", result.Metadata.Width, result.Metadata.Height, test.wantWidth, test.wantHeight)
+			}
+			if result.Metadata.Size != len(result.Original.Data) {
+				t.Errorf("metadata size = %d, want %d", result.Metadata.Size, len(result.Original.Data))
+			}
+			checkVariants(t, result, test.wantWidth, test.wantHeight)
+		})
+	}
+}
+
+func TestProcessSizeLimitIsInclusive(t *testing.T) {
+	data := encodePNG(t, 16, 16)
+	if _, err := Process(context.Background(), bytes.NewReader(data), Options{MaxSize: int64(len(data))}); err != nil {
+		t.Fatalf("Process of a file of exactly MaxSize bytes error = %v", err)
+	}
+	if _, err := Process(context.Background(), bytes.NewReader(data), Options{MaxSize: int64(len(data) - 1)}); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("Process of a file one byte over MaxSize error = %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestProcessDocuments(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		options         Options
+		wantContentType string
+		wantExtension   string
+		wantPages       int
+		wantErr         error
+	}{
+		{
+			name:            "pdf with a scanned page",
+			data:            imagePDF(t, encodeJPEG(t, 600, 800)),
+			options:         Options{MaxSize: 1 << 20},
+			wantContentType: "application/pdf",
+			wantExtension:   ".pdf",
+			wantPages:       1,
+		},
+		{
+			name:            "missing renderer falls back to the embedded image",
+			data:            imagePDF(t, encodeJPEG(t, 600, 800)),
+			options:         Options{MaxSize: 1 << 20, PDFRenderer: "shirinec-missing-renderer"},
+			wantContentType: "application/pdf",
+			wantExtension:   ".pdf",
+			wantPages:       1,
+		},
+		{
+			name:    "broken pdf",
+			data:    []byte("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n%%EOF\n"),
+			options: Options{MaxSize: 1 << 20},
+			wantErr: ErrUnsupportedFormat,
+		},
+		{
+			name:    "pdf larger than the limit",
+			data:    imagePDF(t, encodeJPEG(t, 600, 800)),
+			options: Options{MaxSize: 100},
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "heic without converter",
+			data:    heicHeader(),
+			options: Options{MaxSize: 1 << 20},
+			wantErr: ErrUnsupportedFormat,
+		},
+		{
+			name:    "heic with a missing converter",
+			data:    heicHeader(),
+			options: Options{MaxSize: 1 << 20, HEICConverter: "shirinec-missing-converter"},
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Process(context.Background(), bytes.NewReader(test.data), test.options)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Process error = %v, want %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Metadata.ContentType != test.wantContentType || result.Extension != test.wantExtension {
+				t.Errorf("content type and extension = %q %q, want %q %q", result.Metadata.ContentType, result.Extension, test.wantContentType, test.wantExtension)
+			}
+			// Documents are kept byte for byte
+			if !bytes.Equal(result.Original.Data, test.data) {
+				t.Errorf("original data was changed")
+			}
+			if result.Metadata.Pages != test.wantPages {
+				t.Errorf("pages = %d, want %d", result.Metadata.Pages, test.wantPages)
+			}
+			checkVariants(t, result, 600, 800)
+			for name, variant := range result.Variants {
+				if variant.ContentType != "image/jpeg" {
+					t.Errorf("variant %q content type = %q, want image/jpeg", name, variant.ContentType)
+				}
+			}
+		})
+	}
+}
+
+func TestVariantKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		size string
+		want string
+	}{
+		{key: "a1b2.png", size: "small", want: "a1b2_small.png"},
+		{key: "a1b2.jpg", size: "large", want: "a1b2_large.jpg"},
+		{key: "a1b2.pdf", size: "medium", want: "a1b2_medium.jpg"},
+		{key: "a1b2", size: "small", want: "a1b2_small"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			if got := VariantKey(test.key, test.size); got != test.want {
+				t.Errorf("VariantKey(%q, %q) = %q, want %q", test.key, test.size, got, test.want)
+			}
+		})
+	}
+}
+
+// checkVariants verifies every size fits its square, keeps the aspect ratio
+// and never scales the image up.
+func checkVariants(t *testing.T, result *Result, width, height int) {
+	t.Helper()
+	if len(result.Variants) != len(Sizes) {
+		t.Fatalf("got %d variants, want %d", len(result.Variants), len(Sizes))
+	}
+	for _, size := range Sizes {
+		variant, ok := result.Variants[size.Name]
+		if !ok {
+			t.Fatalf("variant %q is missing", size.Name)
+		}
+		wantWidth, wantHeight := width, height
+		if width > size.MaxSide || height > size.MaxSide {
+			if width >= height {
+				wantWidth, wantHeight = size.MaxSide, max(1, height*size.MaxSide/width)
+			} else {
+				wantWidth, wantHeight = max(1, width*size.MaxSide/height), size.MaxSide
+			}
+		}
+		if variant.Width != wantWidth || variant.Height != wantHeight {
+			t.Errorf("variant %q = %dx%d, want %dx%d", size.Name, variant.Width, variant.Height, wantWidth, wantHeight)
+		}
+		if metadata := result.Metadata.Variants[size.Name]; metadata.Size != len(variant.Data) {
+			t.Errorf("variant %q metadata size = %d, want %d", size.Name, metadata.Size, len(variant.Data))
+		}
+	}
+}
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, testImage(width, height)); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func encodeGIF(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := gif.Encode(&buffer, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// pngHeader returns the signature and IHDR chunk of a PNG claiming the given
+// dimensions without any pixel data.
+func pngHeader(width, height uint32) []byte {
+	chunk := make([]byte, 0, 17)
+	chunk = append(chunk, "IHDR"...)
+	chunk = binary.BigEndian.AppendUint32(chunk, width)
+	chunk = binary.BigEndian.AppendUint32(chunk, height)
+	// 8 bit RGBA, default compression, filter and no interlacing
+	chunk = append(chunk, 8, 6, 0, 0, 0)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)-4))
+	data = append(data, chunk...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+}
+
+// imagePDF returns a single page PDF showing the image, like a scanner writes.
+func imagePDF(t *testing.T, img []byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := api.ImportImages(nil, &buffer, []io.Reader{bytes.NewReader(img)}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// heicHeader returns the ftyp box HEIC files start with.
+func heicHeader() []byte {
+	box := []byte("ftypheic\x00\x00\x00\x00mif1heic")
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(box)+4))
+	return append(data, box...)
+}
@@ -0,0 +1,120 @@
+package mediafile
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func init() {
+	// pdfcpu otherwise creates its configuration in the user config folder,
+	// and exits the process when it can not
+	api.DisableConfigDir()
+}
+
+// processPDF validates the document and keeps it byte for byte, receipts and
+// statements may be signed. The first page preview is rendered by renderer,
+// an external tool since there is no PDF renderer in pure Go, and falls back
+// to the largest image embedded in the first page, which is what scanned
+// documents consist of.
+func processPDF(ctx context.Context, data []byte, renderer string) (*Result, error) {
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+	conf.Cmd = model.EXTRACTIMAGES
+	document, err := api.ReadValidateAndOptimize(bytes.NewReader(data), conf)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	result := newResult(File{Data: data, ContentType: "application/pdf"}, "application/pdf")
+	result.Metadata.Pages = document.PageCount
+
+	preview, err := renderPDFPage(ctx, data, renderer)
+	if err != nil || preview == nil {
+		preview = embeddedPreview(document)
+	}
+	if preview == nil {
+		return result, nil
+	}
+
+	if err := result.addVariants(preview, "image/jpeg"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// renderPDFPage renders the first page with pdftoppm or a tool taking the same
+// arguments, it returns nil when no renderer is configured or installed.
+func renderPDFPage(ctx context.Context, data []byte, renderer string) (image.Image, error) {
+	if renderer == "" {
+		return nil, nil
+	}
+	if _, err := exec.LookPath(renderer); err != nil {
+		return nil, nil
+	}
+
+	dir, err := os.MkdirTemp("", "shirinec-pdf-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, converterTimeout)
+	defer cancel()
+	output := filepath.Join(dir, "preview")
+	largest := strconv.Itoa(Sizes[len(Sizes)-1].MaxSide)
+	command := exec.CommandContext(ctx, renderer, "-f", "1", "-l", "1", "-singlefile", "-jpeg", "-scale-to", largest, input, output)
+	if err := command.Run(); err != nil {
+		return nil, err
+	}
+
+	rendered, err := os.ReadFile(output + ".jpg")
+	if err != nil {
+		return nil, err
+	}
+	return decodeImage(rendered)
+}
+
+// embeddedPreview decodes the largest JPEG or PNG image of the first page.
+func embeddedPreview(document *model.Context) image.Image {
+	images, err := pdfcpu.ExtractPageImages(document, 1, false)
+	if err != nil {
+		return nil
+	}
+
+	var preview image.Image
+	area := 0
+	for _, embedded := range images {
+		if embedded.FileType != "jpg" && embedded.FileType != "png" {
+			continue
+		}
+		data, err := io.ReadAll(embedded)
+		if err != nil {
+			continue
+		}
+		// The dimensions are not always filled in by pdfcpu, the decoded ones are compared
+		img, err := decodeImage(data)
+		if err != nil {
+			continue
+		}
+		if bounds := img.Bounds(); bounds.Dx()*bounds.Dy() > area {
+			preview = img
+			area = bounds.Dx() * bounds.Dy()
+		}
+	}
+	return preview
+}
@@ -8,12 +8,14 @@ import (
 )
 
 type Account struct {
-	ID           int
-	UserID       uuid.UUID
-	Name         *string
-	CategoryID   *int
-	Balance      *float64
-	Type         *enums.AccountType
-	CreationDate time.Time
-	UpdateDate   time.Time
+	ID               int
+	UserID           uuid.UUID
+	Name             *string
+	CategoryID       *int
+	Balance          *Money
+	Type             *enums.AccountType
+	Currency         *string
+	FinancialGroupID *int
+	CreationDate     time.Time
+	UpdateDate       time.Time
 }
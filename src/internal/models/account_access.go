@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type AccountAccess struct {
+	ID           int
+	UserID       uuid.UUID
+	AccountID    int
+	Access       enums.AccessLevel
+	CreationDate time.Time
+	UpdateDate   time.Time
+}
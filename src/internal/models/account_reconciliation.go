@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountReconciliation compares the balance of a bank statement with the
+// transactions recorded up to the end of StatementDate, Difference is the
+// statement balance minus the ledger balance.
+type AccountReconciliation struct {
+	ID                      int       `json:"id"`
+	AccountID               int       `json:"accountID"`
+	UserID                  uuid.UUID `json:"userID"`
+	StatementDate           time.Time `json:"statementDate"`
+	StatementBalance        Money     `json:"statementBalance"`
+	LedgerBalance           Money     `json:"ledgerBalance"`
+	Difference              Money     `json:"difference"`
+	AdjustmentTransactionID *int      `json:"adjustmentTransactionID"`
+	CreationDate            time.Time `json:"creationDate"`
+}
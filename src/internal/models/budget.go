@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type Budget struct {
+	ID             int                `json:"id"`
+	UserID         uuid.UUID          `json:"userID"`
+	CategoryID     int                `json:"categoryID"`
+	Amount         Money              `json:"amount"`
+	Period         enums.BudgetPeriod `json:"period"`
+	StartDate      time.Time          `json:"startDate"`
+	EndDate        *time.Time         `json:"endDate"`
+	Rollover       bool               `json:"rollover"`
+	AlertThreshold int                `json:"alertThreshold"`
+	CreationDate   time.Time          `json:"creationDate"`
+	UpdateDate     time.Time          `json:"updateDate"`
+}
+
+// BudgetRange is a budget period, Start is inclusive and End exclusive.
+type BudgetRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Periods returns every period of the budget from the first one up to the one
+// containing t, in order. A budget that has not started yet returns its first
+// period and a custom budget always returns its single range.
+func (b *Budget) Periods(t time.Time) []BudgetRange {
+	first := b.periodAt(b.StartDate)
+	if b.Period == enums.BudgetCustom {
+		return []BudgetRange{first}
+	}
+
+	periods := []BudgetRange{first}
+	for current := first; !t.Before(current.End); {
+		current = b.periodAt(current.End)
+		periods = append(periods, current)
+	}
+	return periods
+}
+
+func (b *Budget) periodAt(t time.Time) BudgetRange {
+	year, month, day := t.UTC().Date()
+	switch b.Period {
+	case enums.BudgetWeekly:
+		// time.Weekday starts on Sunday, budget weeks start on Monday
+		offset := (int(t.UTC().Weekday()) + 6) % 7
+		start := time.Date(year, month, day-offset, 0, 0, 0, 0, time.UTC)
+		return BudgetRange{Start: start, End: start.AddDate(0, 0, 7)}
+	case enums.BudgetCustom:
+		end := b.StartDate
+		if b.EndDate != nil {
+			end = *b.EndDate
+		}
+		return BudgetRange{Start: dateOf(b.StartDate), End: dateOf(end).AddDate(0, 0, 1)}
+	default:
+		start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return BudgetRange{Start: start, End: start.AddDate(0, 1, 0)}
+	}
+}
+
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"shirinec.com/src/internal/enums"
+)
+
+func TestBudgetPeriods(t *testing.T) {
+	customEnd := date(2024, time.March, 20)
+
+	tests := []struct {
+		name   string
+		budget Budget
+		at     time.Time
+		want   []BudgetRange
+	}{
+		{
+			name:   "monthly first period",
+			budget: Budget{Period: enums.BudgetMonthly, StartDate: date(2024, time.January, 15)},
+			at:     date(2024, time.January, 31),
+			want:   []BudgetRange{{date(2024, time.January, 1), date(2024, time.February, 1)}},
+		},
+		{
+			name:   "monthly across a leap february",
+			budget: Budget{Period: enums.BudgetMonthly, StartDate: date(2024, time.January, 31)},
+			at:     date(2024, time.March, 1),
+			want: []BudgetRange{
+				{date(2024, time.January, 1), date(2024, time.February, 1)},
+				{date(2024, time.February, 1), date(2024, time.March, 1)},
+				{date(2024, time.March, 1), date(2024, time.April, 1)},
+			},
+		},
+		{
+			name:   "monthly across the year end",
+			budget: Budget{Period: enums.BudgetMonthly, StartDate: date(2023, time.December, 10)},
+			at:     date(2024, time.January, 1),
+			want: []BudgetRange{
+				{date(2023, time.December, 1), date(2024, time.January, 1)},
+				{date(2024, time.January, 1), date(2024, time.February, 1)},
+			},
+		},
+		{
+			name:   "monthly end is exclusive",
+			budget: Budget{Period: enums.BudgetMonthly, StartDate: date(2024, time.April, 1)},
+			at:     time.Date(2024, time.April, 30, 23, 59, 59, 0, time.UTC),
+			want:   []BudgetRange{{date(2024, time.April, 1), date(2024, time.May, 1)}},
+		},
+		{
+			name:   "monthly start in another time zone",
+			budget: Budget{Period: enums.BudgetMonthly, StartDate: time.Date(2024, time.May, 1, 1, 0, 0, 0, time.FixedZone("CEST", 2*60*60))},
+			at:     date(2024, time.April, 30),
+			want:   []BudgetRange{{date(2024, time.April, 1), date(2024, time.May, 1)}},
+		},
+		{
+			name:   "not started yet",
+			budget: Budget{Period: enums.BudgetMonthly, StartDate: date(2024, time.June, 5)},
+			at:     date(2024, time.January, 1),
+			want:   []BudgetRange{{date(2024, time.June, 1), date(2024, time.July, 1)}},
+		},
+		{
+			name:   "weekly starts on monday",
+			budget: Budget{Period: enums.BudgetWeekly, StartDate: date(2024, time.January, 3)},
+			at:     date(2024, time.January, 7),
+			want:   []BudgetRange{{date(2024, time.January, 1), date(2024, time.January, 8)}},
+		},
+		{
+			name:   "weekly sunday belongs to the week before",
+			budget: Budget{Period: enums.BudgetWeekly, StartDate: date(2024, time.January, 7)},
+			at:     date(2024, time.January, 8),
+			want: []BudgetRange{
+				{date(2024, time.January, 1), date(2024, time.January, 8)},
+				{date(2024, time.January, 8), date(2024, time.January, 15)},
+			},
+		},
+		{
+			name:   "weekly across the month end",
+			budget: Budget{Period: enums.BudgetWeekly, StartDate: date(2024, time.January, 29)},
+			at:     date(2024, time.February, 12),
+			want: []BudgetRange{
+				{date(2024, time.January, 29), date(2024, time.February, 5)},
+				{date(2024, time.February, 5), date(2024, time.February, 12)},
+				{date(2024, time.February, 12), date(2024, time.February, 19)},
+			},
+		},
+		{
+			name:   "custom includes its end date",
+			budget: Budget{Period: enums.BudgetCustom, StartDate: date(2024, time.March, 5), EndDate: &customEnd},
+			at:     date(2024, time.December, 1),
+			want:   []BudgetRange{{date(2024, time.March, 5), date(2024, time.March, 21)}},
+		},
+		{
+			name:   "custom without end date lasts one day",
+			budget: Budget{Period: enums.BudgetCustom, StartDate: time.Date(2024, time.March, 5, 15, 30, 0, 0, time.UTC)},
+			at:     date(2024, time.March, 5),
+			want:   []BudgetRange{{date(2024, time.March, 5), date(2024, time.March, 6)}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.budget.Periods(test.at)
+			if len(got) != len(test.want) {
+				t.Fatalf("Periods returned %d periods %v, want %d %v", len(got), got, len(test.want), test.want)
+			}
+			for i := range got {
+				if !got[i].Start.Equal(test.want[i].Start) || !got[i].End.Equal(test.want[i].End) {
+					t.Errorf("period %d = %v - %v, want %v - %v", i, got[i].Start, got[i].End, test.want[i].Start, test.want[i].End)
+				}
+			}
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+// CategorizationRule assigns CategoryID to transactions of TransactionType
+// matching every condition that is set. MinAmount and MaxAmount are compared
+// with the amount without its sign.
+type CategorizationRule struct {
+	ID                  int                   `json:"id"`
+	UserID              uuid.UUID             `json:"userID"`
+	CategoryID          int                   `json:"categoryID"`
+	TransactionType     enums.TransactionType `json:"type"`
+	Priority            int                   `json:"priority"`
+	AccountID           *int                  `json:"accountID"`
+	DescriptionContains *string               `json:"descriptionContains"`
+	MinAmount           *Money                `json:"minAmount"`
+	MaxAmount           *Money                `json:"maxAmount"`
+	CreationDate        time.Time             `json:"creationDate"`
+	UpdateDate          time.Time             `json:"updateDate"`
+}
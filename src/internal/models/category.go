@@ -8,12 +8,13 @@ import (
 )
 
 type Category struct {
-	ID           int
-	UserID       uuid.UUID
-	Name         *string
-	Color        *string
-	IconID       *int
-	EntityType   *enums.CategoryType
-	CreationDate *time.Time
-	UpdateDate   *time.Time
+	ID               int
+	UserID           uuid.UUID
+	Name             *string
+	Color            *string
+	IconID           *int
+	EntityType       *enums.CategoryType
+	FinancialGroupID *int
+	CreationDate     *time.Time
+	UpdateDate       *time.Time
 }
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRate tells how many units of QuoteCurrency one unit of BaseCurrency
+// buys. Rate is kept as the decimal string postgres returns so it is never
+// rounded by a float conversion.
+type ExchangeRate struct {
+	ID            int       `json:"id"`
+	UserID        uuid.UUID `json:"userID"`
+	BaseCurrency  string    `json:"baseCurrency"`
+	QuoteCurrency string    `json:"quoteCurrency"`
+	Rate          string    `json:"rate"`
+	CreationDate  time.Time `json:"creationDate"`
+	UpdateDate    time.Time `json:"updateDate"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type ExportJob struct {
+	ID             int                   `json:"id"`
+	UserID         uuid.UUID             `json:"userID"`
+	Format         enums.ExportFormat    `json:"format"`
+	FromDate       *time.Time            `json:"from"`
+	ToDate         *time.Time            `json:"to"`
+	Status         enums.ExportJobStatus `json:"status"`
+	MediaID        *int                  `json:"-"`
+	ErrorMessage   *string               `json:"errorMessage"`
+	CompletionDate *time.Time            `json:"completionDate"`
+	CreationDate   time.Time             `json:"creationDate"`
+	UpdateDate     time.Time             `json:"updateDate"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type FinancialGroupInvitation struct {
+	ID               int
+	FinancialGroupID int
+	InvitedBy        uuid.UUID
+	Email            string
+	Token            string
+	Status           enums.InvitationStatus
+	Role             enums.FinancialGroupRole
+	ExpiresAt        time.Time
+	CreationDate     time.Time
+	UpdateDate       time.Time
+}
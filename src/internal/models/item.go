@@ -6,13 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
-
 type Item struct {
-    ID int
-    UserID uuid.UUID
-    Name *string
-    CategoryID *int
-    ImageID *int
-    CreationDate time.Time
-    UpdateDate time.Time
+	ID               int
+	UserID           uuid.UUID
+	Name             *string
+	CategoryID       *int
+	ImageID          *int
+	FinancialGroupID *int
+	CreationDate     time.Time
+	UpdateDate       time.Time
 }
@@ -14,7 +14,7 @@ type Media struct {
 	FilePath         string
 	Metadata         *string
 	Access           *enums.MediaAccess
-	FinancialGroupID int
+	FinancialGroupID *int
 	CreationDate     time.Time
 	UpdateDate       time.Time
 }
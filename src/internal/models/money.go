@@ -0,0 +1,151 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Money is an amount kept in minor units (cents) so balances are never
+// calculated with floating point numbers. It is stored as NUMERIC(20, 2) in
+// postgres and encoded as a decimal string in JSON, e.g. "-12.50".
+type Money int64
+
+const (
+	moneyDecimals = 2
+	moneyScale    = 100
+)
+
+var ErrInvalidMoney = errors.New("invalid money value")
+
+// ParseMoney reads a decimal string with at most two fractional digits.
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, ErrInvalidMoney
+	}
+
+	negative := false
+	switch value[0] {
+	case '-':
+		negative = true
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalidMoney
+	}
+	if len(fraction) > moneyDecimals {
+		return 0, ErrInvalidMoney
+	}
+	fraction += strings.Repeat("0", moneyDecimals-len(fraction))
+
+	var units uint64
+	for _, part := range []string{whole, fraction} {
+		for _, digit := range part {
+			if digit < '0' || digit > '9' {
+				return 0, ErrInvalidMoney
+			}
+			digitValue := uint64(digit - '0')
+			if units > (math.MaxInt64-digitValue)/10 {
+				return 0, ErrInvalidMoney
+			}
+			units = units*10 + digitValue
+		}
+	}
+
+	if negative {
+		return -Money(units), nil
+	}
+	return Money(units), nil
+}
+
+// Abs returns the amount without its sign.
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+func (m Money) String() string {
+	units := int64(m)
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, units/moneyScale, units%moneyScale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts both "12.34" and 12.34, numbers are read from their
+// literal text so no precision is lost on the way.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" {
+		return nil
+	}
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam is used by gin to bind query and form values.
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := ParseMoney(param)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(m)), Exp: -moneyDecimals, Valid: true}, nil
+}
+
+// ScanNumeric rounds half away from zero when postgres returns more than two
+// fractional digits, e.g. after multiplying by an exchange rate.
+func (m *Money) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid {
+		*m = 0
+		return nil
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return ErrInvalidMoney
+	}
+
+	units := new(big.Int).Set(v.Int)
+	shift := int64(v.Exp) + moneyDecimals
+	if shift >= 0 {
+		units.Mul(units, new(big.Int).Exp(big.NewInt(10), big.NewInt(shift), nil))
+	} else {
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(-shift), nil)
+		remainder := new(big.Int)
+		units.QuoRem(units, divisor, remainder)
+		if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+			units.Add(units, big.NewInt(int64(remainder.Sign())))
+		}
+	}
+
+	if !units.IsInt64() {
+		return ErrInvalidMoney
+	}
+	*m = Money(units.Int64())
+	return nil
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Money
+		err   error
+	}{
+		{value: "12.34", want: 1234},
+		{value: "12.3", want: 1230},
+		{value: "12", want: 1200},
+		{value: "12.", want: 1200},
+		{value: ".5", want: 50},
+		{value: "0", want: 0},
+		{value: "-12.50", want: -1250},
+		{value: "+7.01", want: 701},
+		{value: "  3.10 ", want: 310},
+		{value: "92233720368547758.07", want: 9223372036854775807},
+		{value: "", err: ErrInvalidMoney},
+		{value: "-", err: ErrInvalidMoney},
+		{value: ".", err: ErrInvalidMoney},
+		{value: "1.234", err: ErrInvalidMoney},
+		{value: "1,50", err: ErrInvalidMoney},
+		{value: "1e3", err: ErrInvalidMoney},
+		{value: "--1", err: ErrInvalidMoney},
+		{value: "1.2.3", err: ErrInvalidMoney},
+		{value: "92233720368547758.08", err: ErrInvalidMoney},
+		{value: "100000000000000000000", err: ErrInvalidMoney},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseMoney(test.value)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ParseMoney(%q) error = %v, want %v", test.value, err, test.err)
+			}
+			if err == nil && got != test.want {
+				t.Errorf("ParseMoney(%q) = %d, want %d", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: 0, want: "0.00"},
+		{money: 5, want: "0.05"},
+		{money: 1234, want: "12.34"},
+		{money: -1250, want: "-12.50"},
+		{money: -5, want: "-0.05"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := test.money.String(); got != test.want {
+				t.Errorf("Money(%d).String() = %q, want %q", test.money, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMoneyScanNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		numeric pgtype.Numeric
+		want    Money
+		err     error
+	}{
+		{name: "two decimals", numeric: numeric(1234, -2), want: 1234},
+		{name: "whole number", numeric: numeric(12, 0), want: 1200},
+		{name: "positive exponent", numeric: numeric(12, 2), want: 120000},
+		{name: "one decimal", numeric: numeric(125, -1), want: 1250},
+		{name: "rounds down", numeric: numeric(12344, -3), want: 1234},
+		{name: "rounds half up", numeric: numeric(12345, -3), want: 1235},
+		{name: "rounds half away from zero", numeric: numeric(-12345, -3), want: -1235},
+		{name: "rounds negative down", numeric: numeric(-12344, -3), want: -1234},
+		{name: "rounds below a cent", numeric: numeric(4999, -6), want: 0},
+		{name: "rounds up to a cent", numeric: numeric(5000, -6), want: 1},
+		{name: "null", numeric: pgtype.Numeric{}, want: 0},
+		{name: "NaN", numeric: pgtype.Numeric{NaN: true, Valid: true}, err: ErrInvalidMoney},
+		{name: "infinity", numeric: pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, err: ErrInvalidMoney},
+		{name: "out of range", numeric: numeric(1, 30), err: ErrInvalidMoney},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			money := Money(-1)
+			err := money.ScanNumeric(test.numeric)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ScanNumeric error = %v, want %v", err, test.err)
+			}
+			if err == nil && money != test.want {
+				t.Errorf("ScanNumeric = %d, want %d", money, test.want)
+			}
+		})
+	}
+}
+
+func TestMoneyNumericValueRoundTrip(t *testing.T) {
+	for _, money := range []Money{0, 1, -1, 1234, -987654321} {
+		value, err := money.NumericValue()
+		if err != nil {
+			t.Fatalf("Money(%d).NumericValue() error = %v", money, err)
+		}
+		var scanned Money
+		if err := scanned.ScanNumeric(value); err != nil {
+			t.Fatalf("ScanNumeric error = %v", err)
+		}
+		if scanned != money {
+			t.Errorf("round trip of %d = %d", money, scanned)
+		}
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want Money
+		err  error
+	}{
+		{data: `"12.34"`, want: 1234},
+		{data: `12.34`, want: 1234},
+		{data: `-0.5`, want: -50},
+		{data: `null`, want: 0},
+		{data: `0.001`, err: ErrInvalidMoney},
+		{data: `"abc"`, err: ErrInvalidMoney},
+	}
+
+	for _, test := range tests {
+		t.Run(test.data, func(t *testing.T) {
+			var money Money
+			err := money.UnmarshalJSON([]byte(test.data))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("UnmarshalJSON(%s) error = %v, want %v", test.data, err, test.err)
+			}
+			if err == nil && money != test.want {
+				t.Errorf("UnmarshalJSON(%s) = %d, want %d", test.data, money, test.want)
+			}
+		})
+	}
+}
+
+func numeric(units int64, exp int32) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(units), Exp: exp, Valid: true}
+}
@@ -7,12 +7,12 @@ import (
 )
 
 type PurchaseListItem struct {
-    ID int
-    UserID uuid.UUID
-    ItemID int
-    Count int
-    UnitPrice float64
-    TransactionID int
-    CreationDate time.Time
-    UpdateDate time.Time
+	ID            int
+	UserID        uuid.UUID
+	ItemID        *int
+	Count         *int
+	UnitPrice     *Money
+	TransactionID int
+	CreationDate  time.Time
+	UpdateDate    time.Time
 }
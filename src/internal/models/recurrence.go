@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"shirinec.com/src/internal/enums"
+)
+
+// Recurrence repeats every Interval days, weeks, months or years from
+// StartDate up to the optional EndDate. Monthly and yearly recurrences keep the
+// day of StartDate and use the last day of shorter months.
+type Recurrence struct {
+	Frequency enums.RecurrenceFrequency `json:"frequency"`
+	Interval  int                       `json:"interval"`
+	StartDate time.Time                 `json:"startDate"`
+	EndDate   *time.Time                `json:"endDate"`
+}
+
+// Occurrence returns the date of the i-th occurrence, the first one being
+// StartDate. It does not look at EndDate.
+func (r *Recurrence) Occurrence(i int) time.Time {
+	start := dateOf(r.StartDate)
+	step := i * r.Interval
+	switch r.Frequency {
+	case enums.RecurrenceDaily:
+		return start.AddDate(0, 0, step)
+	case enums.RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case enums.RecurrenceYearly:
+		return addMonthsClamped(start, 12*step)
+	default:
+		return addMonthsClamped(start, step)
+	}
+}
+
+// OccurrenceOnOrAfter returns the index and date of the first occurrence on or
+// after t, searching from index from. ok is false when the recurrence ends
+// before.
+func (r *Recurrence) OccurrenceOnOrAfter(from int, t time.Time) (index int, date time.Time, ok bool) {
+	t = dateOf(t)
+	index = from
+	date = r.Occurrence(index)
+	for date.Before(t) {
+		index++
+		date = r.Occurrence(index)
+	}
+	return index, date, !r.EndsBefore(date)
+}
+
+// EndsBefore reports whether there is no occurrence on date because the
+// recurrence already ended.
+func (r *Recurrence) EndsBefore(date time.Time) bool {
+	return r.EndDate != nil && dateOf(date).After(dateOf(*r.EndDate))
+}
+
+// addMonthsClamped adds months to t keeping its day, or using the last day of
+// the target month when it is shorter. time.AddDate would overflow instead.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
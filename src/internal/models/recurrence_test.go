@@ -0,0 +1,146 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"shirinec.com/src/internal/enums"
+)
+
+func TestRecurrenceOccurrence(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence Recurrence
+		index      int
+		want       time.Time
+	}{
+		{
+			name:       "first occurrence is the start date",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 1, StartDate: time.Date(2024, time.May, 3, 18, 45, 0, 0, time.UTC)},
+			index:      0,
+			want:       date(2024, time.May, 3),
+		},
+		{
+			name:       "daily",
+			recurrence: Recurrence{Frequency: enums.RecurrenceDaily, Interval: 3, StartDate: date(2024, time.February, 27)},
+			index:      1,
+			want:       date(2024, time.March, 1),
+		},
+		{
+			name:       "weekly",
+			recurrence: Recurrence{Frequency: enums.RecurrenceWeekly, Interval: 2, StartDate: date(2024, time.December, 23)},
+			index:      1,
+			want:       date(2025, time.January, 6),
+		},
+		{
+			name:       "monthly keeps the day",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 1, StartDate: date(2024, time.January, 15)},
+			index:      13,
+			want:       date(2025, time.February, 15),
+		},
+		{
+			name:       "monthly clamps to a leap february",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 1, StartDate: date(2024, time.January, 31)},
+			index:      1,
+			want:       date(2024, time.February, 29),
+		},
+		{
+			name:       "monthly clamps to february",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 1, StartDate: date(2023, time.January, 31)},
+			index:      1,
+			want:       date(2023, time.February, 28),
+		},
+		{
+			name:       "monthly returns to the day after a short month",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 1, StartDate: date(2024, time.January, 31)},
+			index:      2,
+			want:       date(2024, time.March, 31),
+		},
+		{
+			name:       "monthly clamps to a 30 day month",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 2, StartDate: date(2024, time.August, 31)},
+			index:      1,
+			want:       date(2024, time.October, 31),
+		},
+		{
+			name:       "every third month clamps",
+			recurrence: Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 3, StartDate: date(2024, time.March, 31)},
+			index:      1,
+			want:       date(2024, time.June, 30),
+		},
+		{
+			name:       "yearly from a leap day",
+			recurrence: Recurrence{Frequency: enums.RecurrenceYearly, Interval: 1, StartDate: date(2024, time.February, 29)},
+			index:      1,
+			want:       date(2025, time.February, 28),
+		},
+		{
+			name:       "yearly back on a leap day",
+			recurrence: Recurrence{Frequency: enums.RecurrenceYearly, Interval: 1, StartDate: date(2024, time.February, 29)},
+			index:      4,
+			want:       date(2028, time.February, 29),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.recurrence.Occurrence(test.index); !got.Equal(test.want) {
+				t.Errorf("Occurrence(%d) = %v, want %v", test.index, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceEndsBefore(t *testing.T) {
+	endDate := time.Date(2024, time.March, 10, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		endDate *time.Time
+		date    time.Time
+		want    bool
+	}{
+		{name: "no end date", endDate: nil, date: date(2100, time.January, 1), want: false},
+		{name: "before the end date", endDate: &endDate, date: date(2024, time.March, 9), want: false},
+		{name: "on the end date", endDate: &endDate, date: time.Date(2024, time.March, 10, 23, 0, 0, 0, time.UTC), want: false},
+		{name: "after the end date", endDate: &endDate, date: date(2024, time.March, 11), want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recurrence := Recurrence{Frequency: enums.RecurrenceDaily, Interval: 1, StartDate: date(2024, time.January, 1), EndDate: test.endDate}
+			if got := recurrence.EndsBefore(test.date); got != test.want {
+				t.Errorf("EndsBefore(%v) = %t, want %t", test.date, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrenceOnOrAfter(t *testing.T) {
+	endDate := date(2024, time.April, 30)
+	recurrence := Recurrence{Frequency: enums.RecurrenceMonthly, Interval: 1, StartDate: date(2024, time.January, 31), EndDate: &endDate}
+
+	tests := []struct {
+		name      string
+		from      int
+		at        time.Time
+		wantIndex int
+		wantDate  time.Time
+		wantOK    bool
+	}{
+		{name: "on an occurrence", from: 0, at: date(2024, time.February, 29), wantIndex: 1, wantDate: date(2024, time.February, 29), wantOK: true},
+		{name: "between occurrences", from: 0, at: date(2024, time.March, 1), wantIndex: 2, wantDate: date(2024, time.March, 31), wantOK: true},
+		{name: "before the start", from: 0, at: date(2023, time.June, 1), wantIndex: 0, wantDate: date(2024, time.January, 31), wantOK: true},
+		{name: "from a later index", from: 3, at: date(2024, time.January, 1), wantIndex: 3, wantDate: date(2024, time.April, 30), wantOK: true},
+		{name: "after the end", from: 0, at: date(2024, time.May, 1), wantIndex: 4, wantDate: date(2024, time.May, 31), wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			index, got, ok := recurrence.OccurrenceOnOrAfter(test.from, test.at)
+			if index != test.wantIndex || !got.Equal(test.wantDate) || ok != test.wantOK {
+				t.Errorf("OccurrenceOnOrAfter(%d, %v) = %d, %v, %t, want %d, %v, %t", test.from, test.at, index, got, ok, test.wantIndex, test.wantDate, test.wantOK)
+			}
+		})
+	}
+}
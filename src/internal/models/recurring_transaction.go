@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type RecurringTransaction struct {
+	Recurrence
+	ID              int                   `json:"id"`
+	UserID          uuid.UUID             `json:"userID"`
+	AccountID       int                   `json:"accountID"`
+	CategoryID      int                   `json:"categoryID"`
+	TransactionType enums.TransactionType `json:"type"`
+	Amount          Money                 `json:"amount"`
+	Description     *string               `json:"description"`
+	Paused          bool                  `json:"paused"`
+	OccurrenceIndex int                   `json:"-"`
+	NextOccurrence  *time.Time            `json:"nextOccurrence"`
+	CreationDate    time.Time             `json:"creationDate"`
+	UpdateDate      time.Time             `json:"updateDate"`
+}
+
+type RecurringTransactionOverride struct {
+	ID                     int       `json:"id"`
+	RecurringTransactionID int       `json:"recurringTransactionID"`
+	OccurrenceDate         time.Time `json:"occurrenceDate"`
+	Skip                   bool      `json:"skip"`
+	Amount                 *Money    `json:"amount"`
+	Description            *string   `json:"description"`
+	CreationDate           time.Time `json:"creationDate"`
+	UpdateDate             time.Time `json:"updateDate"`
+}
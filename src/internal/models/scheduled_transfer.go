@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type ScheduledTransfer struct {
+	ID              int                           `json:"id"`
+	UserID          uuid.UUID                     `json:"userID"`
+	FromAccountID   *int                          `json:"from"`
+	DestAccountID   *int                          `json:"dest"`
+	Amount          Money                         `json:"amount"`
+	Rate            *string                       `json:"rate"`
+	Frequency       *enums.RecurrenceFrequency    `json:"frequency"`
+	Interval        int                           `json:"interval"`
+	StartDate       time.Time                     `json:"startDate"`
+	EndDate         *time.Time                    `json:"endDate"`
+	Status          enums.ScheduledTransferStatus `json:"status"`
+	OccurrenceIndex int                           `json:"-"`
+	NextRun         *time.Time                    `json:"nextRun"`
+	CreationDate    time.Time                     `json:"creationDate"`
+	UpdateDate      time.Time                     `json:"updateDate"`
+}
+
+// Schedule returns the recurrence of the transfer, a one-off transfer is a
+// daily recurrence that ends on its start date.
+func (t *ScheduledTransfer) Schedule() Recurrence {
+	if t.Frequency == nil {
+		return Recurrence{Frequency: enums.RecurrenceDaily, Interval: 1, StartDate: t.StartDate, EndDate: &t.StartDate}
+	}
+	return Recurrence{Frequency: *t.Frequency, Interval: t.Interval, StartDate: t.StartDate, EndDate: t.EndDate}
+}
+
+type ScheduledTransferRun struct {
+	ID                  int                              `json:"id"`
+	ScheduledTransferID int                              `json:"scheduledTransferID"`
+	RunDate             time.Time                        `json:"runDate"`
+	Status              enums.ScheduledTransferRunStatus `json:"status"`
+	ErrorMessage        *string                          `json:"errorMessage"`
+	FromTransactionID   *int                             `json:"fromTransactionID"`
+	DestTransactionID   *int                             `json:"destTransactionID"`
+	CreationDate        time.Time                        `json:"creationDate"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
+)
+
+type StatementImport struct {
+	ID           int                         `json:"id"`
+	UserID       uuid.UUID                   `json:"userID"`
+	AccountID    int                         `json:"accountID"`
+	Format       enums.StatementFormat       `json:"format"`
+	FileName     string                      `json:"fileName"`
+	Status       enums.StatementImportStatus `json:"status"`
+	CommitDate   *time.Time                  `json:"commitDate"`
+	CreationDate time.Time                   `json:"creationDate"`
+	UpdateDate   time.Time                   `json:"updateDate"`
+}
+
+// StatementImportRow is a parsed statement line, Amount is signed like the
+// transaction it becomes.
+type StatementImportRow struct {
+	ID              int       `json:"id"`
+	ImportID        int       `json:"importID"`
+	LineNumber      int       `json:"lineNumber"`
+	TransactionDate time.Time `json:"transactionDate"`
+	Amount          Money     `json:"amount"`
+	Description     *string   `json:"description"`
+	ExternalID      *string   `json:"externalID"`
+	DuplicateOf     *int      `json:"duplicateOf"`
+	TransactionID   *int      `json:"transactionID"`
+}
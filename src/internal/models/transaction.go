@@ -4,25 +4,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"shirinec.com/src/internal/enums"
 )
 
 type Transaction struct {
 	ID                  int
 	UserID              uuid.UUID
-	AccountID           int
-	CategoryID          int
-	Amount              float32
-	Description         string
-	TransactionType     TransactionTypes
-	LinkedTransactionID int
+	AccountID           *int
+	CategoryID          *int
+	Amount              *Money
+	Description         *string
+	TransactionType     enums.TransactionType
+	LinkedTransactionID *int
+	TransactionDate     *time.Time
 	CreationDate        time.Time
 	UpdateDate          time.Time
 }
-
-type TransactionTypes int
-
-const (
-	Transfer TransactionTypes = iota
-	Income
-	Expense
-)
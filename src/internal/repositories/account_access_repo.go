@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type AccountAccessRepository interface {
+	List(ctx context.Context, accountID int, userID uuid.UUID) (*[]dto.AccountAccessResponse, error)
+	Create(ctx context.Context, accountAccess *models.AccountAccess, email string, grantedBy uuid.UUID) (*dto.AccountAccessResponse, error)
+	Update(ctx context.Context, accountAccess *models.AccountAccess, grantedBy uuid.UUID) (*dto.AccountAccessResponse, error)
+	Delete(ctx context.Context, accountID int, targetUserID, userID uuid.UUID) error
+}
+
+type accountAccessRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewAccountAccessRepository(db *pgxpool.Pool) AccountAccessRepository {
+	return &accountAccessRepository{db: db, tableName: "account_access"}
+}
+
+// queryRower is satisfied by both the pool and a pgx.Tx, so access checks can
+// run inside or outside of a database transaction.
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// querier is the multi row counterpart of queryRower.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// accessibleAccountsQuery selects every account id the user owns, was granted
+// or shares through a financial group, it takes the position of the user id
+// argument.
+const accessibleAccountsQuery = `
+        SELECT id FROM accounts WHERE user_id = $%[1]d AND financial_group_id IS NULL
+        UNION
+        SELECT account_id FROM account_access WHERE user_id = $%[1]d
+        UNION
+        SELECT a.id
+        FROM accounts a
+        JOIN user_financial_groups ufg
+            ON ufg.financial_group_id = a.financial_group_id
+        WHERE ufg.user_id = $%[1]d
+`
+
+// requireAccountAccess returns pgx.ErrNoRows when the account does not exist or
+// is not visible to the user at all, so callers report it as not found, and
+// AccountAccessDenied when the user can see the account with a lower level.
+func requireAccountAccess(ctx context.Context, q queryRower, accountID int, userID uuid.UUID, required enums.AccessLevel) error {
+	query := `
+        SELECT level, level >= $3::AccessLevel
+        FROM (SELECT account_access_level($1, $2) AS level) l
+    `
+	var level *enums.AccessLevel
+	var allowed *bool
+	if err := q.QueryRow(ctx, query, accountID, userID, required).Scan(&level, &allowed); err != nil {
+		return err
+	}
+	if level == nil {
+		return pgx.ErrNoRows
+	}
+	if !*allowed {
+		return &server_errors.AccountAccessDenied
+	}
+	return nil
+}
+
+const accountAccessJoinedSelect = `
+        SELECT aa.id, aa.account_id, aa.user_id, u.email, aa.access, aa.creation_date, aa.update_date
+        FROM account_access aa
+        JOIN users u
+            ON u.id = aa.user_id
+`
+
+func scanAccountAccess(row pgx.Row, item *dto.AccountAccessResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.AccountID,
+		&item.UserID,
+		&item.Email,
+		&item.Access,
+		&item.CreationDate,
+		&item.UpdateDate,
+	)
+}
+
+func (r *accountAccessRepository) List(ctx context.Context, accountID int, userID uuid.UUID) (*[]dto.AccountAccessResponse, error) {
+	if err := requireAccountAccess(ctx, r.db, accountID, userID, enums.AccessAll); err != nil {
+		return nil, err
+	}
+
+	query := accountAccessJoinedSelect + `
+        WHERE aa.account_id = $1
+        ORDER BY aa.id
+    `
+	rows, err := r.db.Query(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accountAccessList = make([]dto.AccountAccessResponse, 0)
+	for rows.Next() {
+		var item dto.AccountAccessResponse
+		if err := scanAccountAccess(rows, &item); err != nil {
+			return nil, err
+		}
+		accountAccessList = append(accountAccessList, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &accountAccessList, nil
+}
+
+// Create shares the account with the user registered with email. The owner
+// already has every right on the account so it can not be granted to them.
+func (r *accountAccessRepository) Create(ctx context.Context, accountAccess *models.AccountAccess, email string, grantedBy uuid.UUID) (*dto.AccountAccessResponse, error) {
+	if err := requireAccountAccess(ctx, r.db, accountAccess.AccountID, grantedBy, enums.AccessAll); err != nil {
+		return nil, err
+	}
+
+	queryFormat := `
+        INSERT INTO %s (user_id, account_id, access, creation_date, update_date)
+        SELECT u.id, a.id, $3, $4, $4
+        FROM users u
+        JOIN accounts a
+            ON a.id = $2
+        WHERE u.email = $1 AND u.id <> a.user_id
+        RETURNING id, user_id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	accountAccess.CreationDate = currentTime
+	accountAccess.UpdateDate = currentTime
+	err := r.db.QueryRow(ctx, query, email, accountAccess.AccountID, accountAccess.Access, currentTime).Scan(&accountAccess.ID, &accountAccess.UserID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == server_errors.PGUniqueViolation {
+			return nil, &server_errors.AccountAlreadyShared
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &server_errors.UserNotFound
+		}
+		return nil, err
+	}
+
+	return r.getByUser(ctx, accountAccess.AccountID, accountAccess.UserID)
+}
+
+func (r *accountAccessRepository) Update(ctx context.Context, accountAccess *models.AccountAccess, grantedBy uuid.UUID) (*dto.AccountAccessResponse, error) {
+	if err := requireAccountAccess(ctx, r.db, accountAccess.AccountID, grantedBy, enums.AccessAll); err != nil {
+		return nil, err
+	}
+
+	queryFormat := "UPDATE %s SET access = $1 WHERE account_id = $2 AND user_id = $3 RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	if err := r.db.QueryRow(ctx, query, accountAccess.Access, accountAccess.AccountID, accountAccess.UserID).Scan(&accountAccess.ID); err != nil {
+		return nil, err
+	}
+
+	return r.getByUser(ctx, accountAccess.AccountID, accountAccess.UserID)
+}
+
+// Delete revokes targetUserID access, users can always drop their own access
+// while revoking anyone else needs 'all' on the account.
+func (r *accountAccessRepository) Delete(ctx context.Context, accountID int, targetUserID, userID uuid.UUID) error {
+	if targetUserID != userID {
+		if err := requireAccountAccess(ctx, r.db, accountID, userID, enums.AccessAll); err != nil {
+			return err
+		}
+	}
+
+	queryFormat := "DELETE FROM %s WHERE account_id = $1 AND user_id = $2 RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, accountID, targetUserID).Scan(&deletedID)
+	return err
+}
+
+func (r *accountAccessRepository) getByUser(ctx context.Context, accountID int, userID uuid.UUID) (*dto.AccountAccessResponse, error) {
+	query := accountAccessJoinedSelect + `
+        WHERE aa.account_id = $1 AND aa.user_id = $2
+    `
+	var item dto.AccountAccessResponse
+	err := scanAccountAccess(r.db.QueryRow(ctx, query, accountID, userID), &item)
+	return &item, err
+}
@@ -2,13 +2,16 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
 	"shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/models"
 )
@@ -19,6 +22,7 @@ type AccountRepository interface {
 	List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.AccountJoinedResponse, int, error)
 	Update(ctx context.Context, account *models.Account) (*dto.AccountJoinedResponse, error)
 	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	SetOpeningBalance(ctx context.Context, id int, input *dto.AccountOpeningBalanceRequest, userID uuid.UUID) (*dto.AccountJoinedResponse, error)
 }
 
 type accountRepository struct {
@@ -30,7 +34,29 @@ func NewAccountRepository(db *pgxpool.Pool) AccountRepository {
 	return &accountRepository{db: db, tableName: "accounts"}
 }
 
-func (r *accountRepository) Create(ctx context.Context, account *models.Account) error {
+// insertLedgerTransaction records an opening balance or an adjustment, the
+// caller changes the account balance.
+func insertLedgerTransaction(ctx context.Context, tx pgx.Tx, userID uuid.UUID, accountID int, amount models.Money, transactionType enums.TransactionType, description string, date time.Time) (int, error) {
+	query := `
+        INSERT INTO transactions
+        (user_id, account_id, amount, description, transaction_type, transaction_date, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+        RETURNING id
+    `
+	var id int
+	err := tx.QueryRow(ctx, query, userID, accountID, amount, description, transactionType, date, time.Now().UTC().Truncate(time.Second)).Scan(&id)
+	return id, err
+}
+
+// Create records the starting balance as the opening balance transaction of
+// the account.
+func (r *accountRepository) Create(ctx context.Context, account *models.Account) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
 	queryFormat := `
         INSERT INTO %s
         (
@@ -39,17 +65,19 @@ func (r *accountRepository) Create(ctx context.Context, account *models.Account)
             category_id,
             type,
             balance,
+            currency,
+            financial_group_id,
             creation_date,
             update_date
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
         RETURNING id
     `
 	query := fmt.Sprintf(queryFormat, r.tableName)
 	currentTime := time.Now().UTC().Truncate(time.Second)
 	account.CreationDate = currentTime
 	account.UpdateDate = currentTime
-	err := r.db.QueryRow(
+	if err = tx.QueryRow(
 		ctx,
 		query,
 		account.UserID,
@@ -57,8 +85,20 @@ func (r *accountRepository) Create(ctx context.Context, account *models.Account)
 		account.CategoryID,
 		account.Type,
 		account.Balance,
+		account.Currency,
+		account.FinancialGroupID,
 		currentTime,
-	).Scan(&account.ID)
+	).Scan(&account.ID); err != nil {
+		return err
+	}
+
+	if account.Balance != nil && *account.Balance != 0 {
+		if _, err = insertLedgerTransaction(ctx, tx, account.UserID, account.ID, *account.Balance, enums.TransactionOpening, "Opening balance", currentTime); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
 	return err
 }
 
@@ -75,7 +115,10 @@ func (r *accountRepository) GetByID(ctx context.Context, id int, userID uuid.UUI
             a.balance,
             a.creation_date,
             a.update_date,
-            a.type
+            a.type,
+            a.currency,
+            account_access_level(a.id, $2),
+            a.financial_group_id
         FROM %s a
         LEFT JOIN categories c
             ON a.category_id = c.id
@@ -84,7 +127,7 @@ func (r *accountRepository) GetByID(ctx context.Context, id int, userID uuid.UUI
         WHERE
             a.id = $1
             AND
-            a.user_id = $2
+            has_account_access(a.id, $2, 'view')
     `
 	query := fmt.Sprintf(queryFormat, r.tableName)
 
@@ -101,16 +144,22 @@ func (r *accountRepository) GetByID(ctx context.Context, id int, userID uuid.UUI
 		&item.CreationDate,
 		&item.UpdateDate,
 		&item.Type,
+		&item.Currency,
+		&item.Access,
+		&item.FinancialGroupID,
 	)
 	return &item, err
 }
 
 func (r *accountRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.AccountJoinedResponse, int, error) {
-	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
-	if err != nil {
+	// Accounts shared with the user are listed next to the owned ones
+	accessibleAccounts := fmt.Sprintf(accessibleAccountsQuery, 1)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) accessible", accessibleAccounts)
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&totalCount); err != nil {
 		return nil, 0, err
 	}
-	// TODO
+
 	var accounts = make([]dto.AccountJoinedResponse, 0, limit)
 	queryFormat := `
         SELECT
@@ -124,15 +173,19 @@ func (r *accountRepository) List(ctx context.Context, limit, offset int, userID
             a.balance,
             a.creation_date,
             a.update_date,
-            a.type
+            a.type,
+            a.currency,
+            account_access_level(a.id, $1),
+            a.financial_group_id
         FROM %s a
         LEFT JOIN categories c
             ON a.category_id = c.id
         LEFT JOIN media cm
             ON c.icon_id = cm.id
-        WHERE a.user_id = $1
+        WHERE a.id IN (%s)
+        ORDER BY a.id
         LIMIT $2 OFFSET $3`
-	query := fmt.Sprintf(queryFormat, r.tableName)
+	query := fmt.Sprintf(queryFormat, r.tableName, accessibleAccounts)
 
 	rows, err := r.db.Query(ctx, query, userID, limit, offset)
 
@@ -155,6 +208,9 @@ func (r *accountRepository) List(ctx context.Context, limit, offset int, userID
 			&item.CreationDate,
 			&item.UpdateDate,
 			&item.Type,
+			&item.Currency,
+			&item.Access,
+			&item.FinancialGroupID,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -164,15 +220,38 @@ func (r *accountRepository) List(ctx context.Context, limit, offset int, userID
 	return &accounts, totalCount, nil
 }
 
-func (r *accountRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
-	queryFormat := "DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id"
+// Delete removes the opening balance and adjustments of the account with it,
+// accounts with other transactions can not be deleted.
+func (r *accountRepository) Delete(ctx context.Context, id int, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = requireAccountAccess(ctx, tx, id, userID, enums.AccessAll); err != nil {
+		return err
+	}
+
+	ledgerQuery := "DELETE FROM transactions WHERE account_id = $1 AND transaction_type IN ('opening', 'adjustment')"
+	if _, err = tx.Exec(ctx, ledgerQuery, id); err != nil {
+		return err
+	}
+
+	queryFormat := "DELETE FROM %s WHERE id = $1 RETURNING id"
 	query := fmt.Sprintf(queryFormat, r.tableName)
 	var deletedID int
-	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
+	if err = tx.QueryRow(ctx, query, id).Scan(&deletedID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
 	return err
 }
 
-func (r *accountRepository) Update(ctx context.Context, account *models.Account) (*dto.AccountJoinedResponse, error) {
+// Update records a new balance as an adjustment transaction of the
+// difference, so the balance stays the sum of the transactions.
+func (r *accountRepository) Update(ctx context.Context, account *models.Account) (_ *dto.AccountJoinedResponse, err error) {
 	var setClauses []string
 	var args []interface{}
 	argIndex := 1
@@ -186,32 +265,99 @@ func (r *accountRepository) Update(ctx context.Context, account *models.Account)
 	if account.CategoryID != nil {
 		setClauses = append(setClauses, fmt.Sprintf("category_id = $%d", argIndex))
 		args = append(args, account.CategoryID)
-		argIndex++
+	}
+
+	if len(setClauses) == 0 && account.Balance == nil {
+		return nil, &server_errors.EmptyUpdate
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = requireAccountAccess(ctx, tx, account.ID, account.UserID, enums.AccessAll); err != nil {
+		return nil, err
+	}
+
+	if len(setClauses) > 0 {
+		query := fmt.Sprintf(
+			"UPDATE %s SET %s WHERE id = %d RETURNING id",
+			r.tableName,
+			strings.Join(setClauses, ", "),
+			account.ID,
+		)
+		if err = tx.QueryRow(ctx, query, args...).Scan(&account.ID); err != nil {
+			return nil, err
+		}
 	}
 
 	if account.Balance != nil {
-		setClauses = append(setClauses, fmt.Sprintf("balance = $%d", argIndex))
-		args = append(args, account.Balance)
+		var currentBalance models.Money
+		balanceQuery := fmt.Sprintf("SELECT balance FROM %s WHERE id = $1 FOR UPDATE", r.tableName)
+		if err = tx.QueryRow(ctx, balanceQuery, account.ID).Scan(&currentBalance); err != nil {
+			return nil, err
+		}
+
+		if difference := *account.Balance - currentBalance; difference != 0 {
+			currentTime := time.Now().UTC().Truncate(time.Second)
+			if _, err = insertLedgerTransaction(ctx, tx, account.UserID, account.ID, difference, enums.TransactionAdjustment, "Balance adjustment", currentTime); err != nil {
+				return nil, err
+			}
+			if err = changeAccountBalance(ctx, tx, account.ID, difference, account.UserID); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	if len(setClauses) == 0 {
-		return nil, &server_errors.EmptyUpdate
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
 	}
 
-	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE id = %d AND user_id = '%s' RETURNING id",
-		r.tableName,
-		strings.Join(setClauses, ", "),
-		account.ID,
-		account.UserID.String(),
-	)
+	return r.GetByID(ctx, account.ID, account.UserID)
+}
 
-	err := r.db.QueryRow(ctx, query, args...).Scan(&account.ID)
+// SetOpeningBalance creates or replaces the opening balance transaction of the
+// account and moves its balance by the difference.
+func (r *accountRepository) SetOpeningBalance(ctx context.Context, id int, input *dto.AccountOpeningBalanceRequest, userID uuid.UUID) (_ *dto.AccountJoinedResponse, err error) {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = requireAccountAccess(ctx, tx, id, userID, enums.AccessAll); err != nil {
+		return nil, err
+	}
+
+	var openingID int
+	var currentAmount models.Money
+	openingQuery := "SELECT id, amount FROM transactions WHERE account_id = $1 AND transaction_type = 'opening' FOR UPDATE"
+	err = tx.QueryRow(ctx, openingQuery, id).Scan(&openingID, &currentAmount)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if _, err = insertLedgerTransaction(ctx, tx, userID, id, input.Amount, enums.TransactionOpening, "Opening balance", input.Date); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		updateQuery := "UPDATE transactions SET amount = $1, transaction_date = $2 WHERE id = $3"
+		if _, err = tx.Exec(ctx, updateQuery, input.Amount, input.Date, openingID); err != nil {
+			return nil, err
+		}
+	}
 
-	accountJoined, err := r.GetByID(ctx, account.ID, account.UserID)
+	if difference := input.Amount - currentAmount; difference != 0 {
+		if err = changeAccountBalance(ctx, tx, id, difference, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 
-	return accountJoined, err
+	return r.GetByID(ctx, id, userID)
 }
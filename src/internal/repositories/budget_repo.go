@@ -0,0 +1,365 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type BudgetRepository interface {
+	Create(ctx context.Context, budget *models.Budget) error
+	GetByID(ctx context.Context, id int, userID uuid.UUID, now time.Time) (*dto.BudgetResponse, error)
+	List(ctx context.Context, limit, offset int, userID uuid.UUID, now time.Time) (*[]dto.BudgetResponse, int, error)
+	Update(ctx context.Context, id int, userID uuid.UUID, input *dto.BudgetUpdateRequest) error
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	ListActive(ctx context.Context, now time.Time) (*[]dto.BudgetResponse, error)
+	CreateAlert(ctx context.Context, budget *dto.BudgetResponse, threshold int) (bool, error)
+	ListAlerts(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.BudgetAlertResponse, int, error)
+}
+
+type budgetRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewBudgetRepository(db *pgxpool.Pool) BudgetRepository {
+	return &budgetRepository{db: db, tableName: "budgets"}
+}
+
+const budgetJoinedSelect = `
+        SELECT
+            b.id,
+            b.user_id,
+            b.category_id,
+            c.name,
+            b.amount,
+            b.period,
+            b.start_date,
+            b.end_date,
+            b.rollover,
+            b.alert_threshold,
+            b.creation_date,
+            b.update_date
+        FROM budgets b
+        JOIN categories c
+            ON c.id = b.category_id
+`
+
+func scanBudget(row pgx.Row, item *dto.BudgetResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.CategoryID,
+		&item.CategoryName,
+		&item.Amount,
+		&item.Period,
+		&item.StartDate,
+		&item.EndDate,
+		&item.Rollover,
+		&item.AlertThreshold,
+		&item.CreationDate,
+		&item.UpdateDate,
+	)
+}
+
+// Create saves a budget on an expense category the user can use.
+func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	categoryQuery := fmt.Sprintf(
+		"SELECT c.entity_type = 'expense' FROM categories c WHERE c.id = $1 AND %s",
+		visibleScopeCondition("c", 2),
+	)
+	var isExpense bool
+	if err := r.db.QueryRow(ctx, categoryQuery, budget.CategoryID, budget.UserID).Scan(&isExpense); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &server_errors.CategoryNotFound
+		}
+		return err
+	}
+	if !isExpense {
+		return &server_errors.BudgetCategoryNotExpense
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, category_id, amount, period, start_date, end_date, rollover, alert_threshold, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+        RETURNING id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	budget.CreationDate = currentTime
+	budget.UpdateDate = currentTime
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		budget.UserID,
+		budget.CategoryID,
+		budget.Amount,
+		budget.Period,
+		budget.StartDate,
+		budget.EndDate,
+		budget.Rollover,
+		budget.AlertThreshold,
+		currentTime,
+	).Scan(&budget.ID)
+	return err
+}
+
+func (r *budgetRepository) GetByID(ctx context.Context, id int, userID uuid.UUID, now time.Time) (*dto.BudgetResponse, error) {
+	query := budgetJoinedSelect + `
+        WHERE b.id = $1 AND b.user_id = $2
+    `
+	var item dto.BudgetResponse
+	if err := scanBudget(r.db.QueryRow(ctx, query, id, userID), &item); err != nil {
+		return nil, err
+	}
+
+	if err := r.fillProgress(ctx, &item, now); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *budgetRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID, now time.Time) (*[]dto.BudgetResponse, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := budgetJoinedSelect + `
+        WHERE b.user_id = $1
+        ORDER BY b.id
+        LIMIT $2 OFFSET $3
+    `
+	budgets, err := r.list(ctx, now, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return budgets, totalCount, nil
+}
+
+func (r *budgetRepository) Update(ctx context.Context, id int, userID uuid.UUID, input *dto.BudgetUpdateRequest) error {
+	var setClauses []string
+	var args []interface{}
+	argIndex := 1
+
+	if input.Amount != nil {
+		setClauses = append(setClauses, fmt.Sprintf("amount = $%d", argIndex))
+		args = append(args, input.Amount)
+		argIndex++
+	}
+
+	if input.Rollover != nil {
+		setClauses = append(setClauses, fmt.Sprintf("rollover = $%d", argIndex))
+		args = append(args, input.Rollover)
+		argIndex++
+	}
+
+	if input.AlertThreshold != nil {
+		setClauses = append(setClauses, fmt.Sprintf("alert_threshold = $%d", argIndex))
+		args = append(args, input.AlertThreshold)
+		argIndex++
+	}
+
+	if len(setClauses) == 0 {
+		return &server_errors.EmptyUpdate
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE id = $%d AND user_id = $%d RETURNING id",
+		r.tableName,
+		strings.Join(setClauses, ", "),
+		argIndex,
+		argIndex+1,
+	)
+	args = append(args, id, userID)
+
+	var updatedID int
+	err := r.db.QueryRow(ctx, query, args...).Scan(&updatedID)
+	return err
+}
+
+func (r *budgetRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	queryFormat := "DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
+	return err
+}
+
+// ListActive returns the budgets of every user that have a running period at
+// now, it is meant for the budget alert worker.
+func (r *budgetRepository) ListActive(ctx context.Context, now time.Time) (*[]dto.BudgetResponse, error) {
+	query := budgetJoinedSelect + `
+        WHERE b.start_date <= $1::DATE AND (b.end_date IS NULL OR b.end_date >= $1::DATE)
+        ORDER BY b.id
+    `
+	return r.list(ctx, now, query, now)
+}
+
+// CreateAlert records that the budget crossed threshold percent in its current
+// period. It reports false when the alert was already raised.
+func (r *budgetRepository) CreateAlert(ctx context.Context, budget *dto.BudgetResponse, threshold int) (bool, error) {
+	query := `
+        INSERT INTO budget_alerts (budget_id, period_start, threshold, spent, budget_limit, creation_date)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT ON CONSTRAINT budget_alerts_period_threshold_key DO NOTHING
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	tag, err := r.db.Exec(ctx, query, budget.ID, budget.PeriodStart, threshold, budget.Spent, budget.Limit, currentTime)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *budgetRepository) ListAlerts(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.BudgetAlertResponse, int, error) {
+	countQuery := `
+        SELECT COUNT(*)
+        FROM budget_alerts ba
+        JOIN budgets b
+            ON b.id = ba.budget_id
+        WHERE b.user_id = $1
+    `
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT ba.id, ba.budget_id, b.category_id, c.name, ba.period_start, ba.threshold, ba.spent, ba.budget_limit, ba.creation_date
+        FROM budget_alerts ba
+        JOIN budgets b
+            ON b.id = ba.budget_id
+        JOIN categories c
+            ON c.id = b.category_id
+        WHERE b.user_id = $1
+        ORDER BY ba.id DESC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var alerts = make([]dto.BudgetAlertResponse, 0, limit)
+	for rows.Next() {
+		var alert dto.BudgetAlertResponse
+		if err := rows.Scan(
+			&alert.ID,
+			&alert.BudgetID,
+			&alert.CategoryID,
+			&alert.CategoryName,
+			&alert.PeriodStart,
+			&alert.Threshold,
+			&alert.Spent,
+			&alert.Limit,
+			&alert.CreationDate,
+		); err != nil {
+			return nil, 0, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &alerts, totalCount, nil
+}
+
+func (r *budgetRepository) list(ctx context.Context, now time.Time, query string, args ...any) (*[]dto.BudgetResponse, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets = make([]dto.BudgetResponse, 0)
+	for rows.Next() {
+		var item dto.BudgetResponse
+		if err := scanBudget(rows, &item); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		budgets = append(budgets, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Progress needs its own queries, they run once the rows are released
+	for i := range budgets {
+		if err := r.fillProgress(ctx, &budgets[i], now); err != nil {
+			return nil, err
+		}
+	}
+	return &budgets, nil
+}
+
+// fillProgress sets the current period of the budget and how much of it was
+// spent. Spending is the sum of expenses in the budget category on every
+// account the budget owner can see. With rollover, what was left of each
+// previous period is added to the limit of the next one.
+func (r *budgetRepository) fillProgress(ctx context.Context, budget *dto.BudgetResponse, now time.Time) error {
+	periods := budget.Periods(now)
+	starts := make([]time.Time, len(periods))
+	ends := make([]time.Time, len(periods))
+	for i, period := range periods {
+		starts[i] = period.Start
+		ends[i] = period.End
+	}
+
+	query := fmt.Sprintf(`
+        SELECT COALESCE(-SUM(t.amount), 0)
+        FROM UNNEST($1::TIMESTAMP[], $2::TIMESTAMP[]) WITH ORDINALITY AS p(period_start, period_end, idx)
+        LEFT JOIN transactions t
+            ON t.category_id = $3
+            AND t.transaction_type = 'expense'
+            AND t.status = 'posted'
+            AND t.transaction_date >= p.period_start
+            AND t.transaction_date < p.period_end
+            AND t.account_id IN (%s)
+        GROUP BY p.idx
+        ORDER BY p.idx
+    `, fmt.Sprintf(accessibleAccountsQuery, 4))
+	rows, err := r.db.Query(ctx, query, starts, ends, budget.CategoryID, budget.UserID)
+	if err != nil {
+		return err
+	}
+	spentList, err := pgx.CollectRows(rows, pgx.RowTo[models.Money])
+	if err != nil {
+		return err
+	}
+
+	var carriedOver models.Money
+	for i, spent := range spentList {
+		limit := budget.Amount + carriedOver
+		if i == len(spentList)-1 {
+			budget.PeriodStart = periods[i].Start
+			budget.PeriodEnd = periods[i].End
+			budget.CarriedOver = carriedOver
+			budget.Limit = limit
+			budget.Spent = spent
+			budget.Remaining = limit - spent
+			budget.Percent = int(int64(spent) * 100 / int64(limit))
+			budget.Overspent = spent > limit
+			break
+		}
+
+		carriedOver = 0
+		if budget.Rollover && spent < limit {
+			carriedOver = limit - spent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type CategorizationRuleRepository interface {
+	Create(ctx context.Context, rule *models.CategorizationRule) error
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.CategorizationRuleResponse, error)
+	List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.CategorizationRuleResponse, int, error)
+	Update(ctx context.Context, id int, userID uuid.UUID, input *dto.CategorizationRuleRequest) error
+	NextPriority(ctx context.Context, userID uuid.UUID) (int, error)
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	Apply(ctx context.Context, input *dto.CategorizationRuleApplyRequest, userID uuid.UUID) (int, error)
+}
+
+type categorizationRuleRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewCategorizationRuleRepository(db *pgxpool.Pool) CategorizationRuleRepository {
+	return &categorizationRuleRepository{db: db, tableName: "categorization_rules"}
+}
+
+// categorizationRuleMatch is a subquery returning the category of the first
+// rule of the user matching a transaction, or NULL. Its arguments are SQL
+// expressions for the transaction fields.
+func categorizationRuleMatch(userID, transactionType, accountID, amount, description string) string {
+	return fmt.Sprintf(`(
+            SELECT cr.category_id
+            FROM categorization_rules cr
+            WHERE cr.user_id = %[1]s
+                AND cr.transaction_type = %[2]s
+                AND (cr.account_id IS NULL OR cr.account_id = %[3]s)
+                AND (cr.min_amount IS NULL OR ABS(%[4]s) >= cr.min_amount)
+                AND (cr.max_amount IS NULL OR ABS(%[4]s) <= cr.max_amount)
+                AND (cr.description_contains IS NULL OR STRPOS(LOWER(COALESCE(%[5]s, '')), LOWER(cr.description_contains)) > 0)
+            ORDER BY cr.priority, cr.id
+            LIMIT 1
+        )`, userID, transactionType, accountID, amount, description)
+}
+
+const categorizationRuleJoinedSelect = `
+        SELECT
+            cr.id,
+            cr.user_id,
+            cr.category_id,
+            cr.transaction_type,
+            cr.priority,
+            cr.account_id,
+            cr.description_contains,
+            cr.min_amount,
+            cr.max_amount,
+            cr.creation_date,
+            cr.update_date,
+            c.name,
+            a.name
+        FROM categorization_rules cr
+        JOIN categories c
+            ON c.id = cr.category_id
+        LEFT JOIN accounts a
+            ON a.id = cr.account_id
+`
+
+func scanCategorizationRuleJoined(row pgx.Row, item *dto.CategorizationRuleResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.CategoryID,
+		&item.TransactionType,
+		&item.Priority,
+		&item.AccountID,
+		&item.DescriptionContains,
+		&item.MinAmount,
+		&item.MaxAmount,
+		&item.CreationDate,
+		&item.UpdateDate,
+		&item.CategoryName,
+		&item.AccountName,
+	)
+}
+
+// checkCategorizationRuleReferences makes sure the category is one of the
+// user's own categories of the rule type and, when the rule is limited to an
+// account, that the user can see it.
+func checkCategorizationRuleReferences(ctx context.Context, q queryRower, rule *models.CategorizationRule) error {
+	categoryQuery := `
+        SELECT c.entity_type::TEXT = $3
+        FROM categories c
+        WHERE c.id = $1 AND c.user_id = $2 AND c.financial_group_id IS NULL
+    `
+	var matches bool
+	if err := q.QueryRow(ctx, categoryQuery, rule.CategoryID, rule.UserID, rule.TransactionType).Scan(&matches); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &server_errors.CategoryNotFound
+		}
+		return err
+	}
+	if !matches {
+		return &server_errors.RuleCategoryMismatch
+	}
+
+	if rule.AccountID != nil {
+		return requireAccountAccess(ctx, q, *rule.AccountID, rule.UserID, enums.AccessView)
+	}
+	return nil
+}
+
+func (r *categorizationRuleRepository) Create(ctx context.Context, rule *models.CategorizationRule) error {
+	if err := checkCategorizationRuleReferences(ctx, r.db, rule); err != nil {
+		return err
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, category_id, transaction_type, priority, account_id, description_contains, min_amount, max_amount, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+        RETURNING id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	rule.CreationDate = currentTime
+	rule.UpdateDate = currentTime
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		rule.UserID,
+		rule.CategoryID,
+		rule.TransactionType,
+		rule.Priority,
+		rule.AccountID,
+		rule.DescriptionContains,
+		rule.MinAmount,
+		rule.MaxAmount,
+		currentTime,
+	).Scan(&rule.ID)
+	return err
+}
+
+func (r *categorizationRuleRepository) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.CategorizationRuleResponse, error) {
+	query := categorizationRuleJoinedSelect + `
+        WHERE cr.id = $1 AND cr.user_id = $2
+    `
+	var item dto.CategorizationRuleResponse
+	err := scanCategorizationRuleJoined(r.db.QueryRow(ctx, query, id, userID), &item)
+	return &item, err
+}
+
+// List returns the rules in the order they are evaluated.
+func (r *categorizationRuleRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.CategorizationRuleResponse, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := categorizationRuleJoinedSelect + `
+        WHERE cr.user_id = $1
+        ORDER BY cr.priority, cr.id
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var rules = make([]dto.CategorizationRuleResponse, 0, limit)
+	for rows.Next() {
+		var item dto.CategorizationRuleResponse
+		if err := scanCategorizationRuleJoined(rows, &item); err != nil {
+			return nil, 0, err
+		}
+		rules = append(rules, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &rules, totalCount, nil
+}
+
+// NextPriority is the priority that puts a rule after every existing rule of
+// the user.
+func (r *categorizationRuleRepository) NextPriority(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := fmt.Sprintf("SELECT COALESCE(MAX(priority) + 1, 0) FROM %s WHERE user_id = $1", r.tableName)
+	var priority int
+	err := r.db.QueryRow(ctx, query, userID).Scan(&priority)
+	return priority, err
+}
+
+// Update replaces every condition of the rule, the priority is kept when the
+// input has none.
+func (r *categorizationRuleRepository) Update(ctx context.Context, id int, userID uuid.UUID, input *dto.CategorizationRuleRequest) error {
+	rule := models.CategorizationRule{
+		UserID:          userID,
+		CategoryID:      input.CategoryID,
+		TransactionType: input.Type,
+		AccountID:       input.AccountID,
+	}
+	if err := checkCategorizationRuleReferences(ctx, r.db, &rule); err != nil {
+		return err
+	}
+
+	queryFormat := `
+        UPDATE %s
+        SET
+            category_id = $1,
+            transaction_type = $2,
+            priority = COALESCE($3, priority),
+            account_id = $4,
+            description_contains = $5,
+            min_amount = $6,
+            max_amount = $7
+        WHERE id = $8 AND user_id = $9
+        RETURNING id
+    `
+	var updatedID int
+	err := r.db.QueryRow(
+		ctx,
+		fmt.Sprintf(queryFormat, r.tableName),
+		input.CategoryID,
+		input.Type,
+		input.Priority,
+		input.AccountID,
+		input.DescriptionContains,
+		input.MinAmount,
+		input.MaxAmount,
+		id,
+		userID,
+	).Scan(&updatedID)
+	return err
+}
+
+func (r *categorizationRuleRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id", r.tableName)
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
+	return err
+}
+
+// Apply runs the rules on the income and expense transactions the user
+// recorded on accounts they can still edit, and returns how many were
+// recategorized. Transactions no rule matches are left as they are.
+func (r *categorizationRuleRepository) Apply(ctx context.Context, input *dto.CategorizationRuleApplyRequest, userID uuid.UUID) (int, error) {
+	conditions := []string{
+		"t.user_id = $1",
+		"t.transaction_type IN ('income', 'expense')",
+		"has_account_access(t.account_id, $1, 'edit')",
+	}
+	args := []any{userID}
+	if !input.Overwrite {
+		conditions = append(conditions, "t.category_id IS NULL")
+	}
+	if input.AccountID != nil {
+		args = append(args, *input.AccountID)
+		conditions = append(conditions, fmt.Sprintf("t.account_id = $%d", len(args)))
+	}
+	if input.From != nil {
+		args = append(args, *input.From)
+		conditions = append(conditions, fmt.Sprintf("t.transaction_date >= $%d", len(args)))
+	}
+	if input.To != nil {
+		args = append(args, *input.To)
+		conditions = append(conditions, fmt.Sprintf("t.transaction_date < $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+        UPDATE transactions tr
+        SET category_id = m.category_id
+        FROM (
+            SELECT t.id, %s AS category_id
+            FROM transactions t
+            WHERE %s
+        ) m
+        WHERE tr.id = m.id AND m.category_id IS NOT NULL AND tr.category_id IS DISTINCT FROM m.category_id
+    `, categorizationRuleMatch("t.user_id", "t.transaction_type", "t.account_id", "t.amount", "t.description"), strings.Join(conditions, " AND "))
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
@@ -31,33 +31,35 @@ func NewCategoryRepository(db *pgxpool.Pool) CategoryRepository {
 }
 
 func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
-	queryFormat := "INSERT INTO %s (user_id, name, color, icon_id, entity_type, update_date, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING id"
+	queryFormat := "INSERT INTO %s (user_id, name, color, icon_id, entity_type, financial_group_id, update_date, creation_date) VALUES ($1, $2, $3, $4, $5, $6, $7, $7) RETURNING id"
     query := fmt.Sprintf(queryFormat, r.tableName)
 	currentTime := time.Now().UTC().Truncate(time.Second)
 	category.CreationDate = &currentTime
 	category.UpdateDate = &currentTime
-	err := r.db.QueryRow(ctx, query, category.UserID.String(), category.Name, category.Color, category.IconID, category.EntityType, currentTime).Scan(&category.ID)
+	err := r.db.QueryRow(ctx, query, category.UserID.String(), category.Name, category.Color, category.IconID, category.EntityType, category.FinancialGroupID, currentTime).Scan(&category.ID)
 	return err
 }
 
 func (r *categoryRepository) GetByID(ctx context.Context, ID int, userID uuid.UUID) (*models.Category, error) {
 	var category models.Category
-	queryFormat := "SELECT id, user_id, name, color, icon_id, entity_type, creation_date, update_date FROM %s WHERE user_id = $1 AND id = $2"
-    query := fmt.Sprintf(queryFormat, r.tableName)
+	queryFormat := "SELECT c.id, c.user_id, c.name, c.color, c.icon_id, c.entity_type, c.financial_group_id, c.creation_date, c.update_date FROM %s c WHERE %s AND c.id = $2"
+    query := fmt.Sprintf(queryFormat, r.tableName, visibleScopeCondition("c", 1))
 
-	err := r.db.QueryRow(ctx, query, userID.String(), ID).Scan(&category.ID, &category.UserID, &category.Name, &category.Color, &category.IconID, &category.EntityType, &category.CreationDate, &category.UpdateDate)
+	err := r.db.QueryRow(ctx, query, userID.String(), ID).Scan(&category.ID, &category.UserID, &category.Name, &category.Color, &category.IconID, &category.EntityType, &category.FinancialGroupID, &category.CreationDate, &category.UpdateDate)
 	return &category, err
 }
 
 func (r *categoryRepository) List(ctx context.Context, limit int, offset int, userID uuid.UUID) (*[]models.Category, int, error) {
-    totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
-    if err != nil {
-        return nil, 0, err
-    }
+	// Personal categories are listed together with the ones of the user groups
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s c WHERE %s", r.tableName, visibleScopeCondition("c", 1))
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
 
 	var categories = make([]models.Category, 0, limit)
-	queryFormat := "SELECT id, user_id, name, color, icon_id, entity_type, creation_date, update_date FROM %s WHERE user_id = $1 LIMIT $2 OFFSET $3"
-    query := fmt.Sprintf(queryFormat, r.tableName)
+	queryFormat := "SELECT c.id, c.user_id, c.name, c.color, c.icon_id, c.entity_type, c.financial_group_id, c.creation_date, c.update_date FROM %s c WHERE %s ORDER BY c.id LIMIT $2 OFFSET $3"
+    query := fmt.Sprintf(queryFormat, r.tableName, visibleScopeCondition("c", 1))
 	rows, err := r.db.Query(ctx, query, userID, limit, offset)
 
 	if err != nil {
@@ -67,7 +69,7 @@ func (r *categoryRepository) List(ctx context.Context, limit int, offset int, us
 
 	for rows.Next() {
 		var category models.Category
-		errScan := rows.Scan(&category.ID, &category.UserID, &category.Name, &category.Color, &category.IconID, &category.EntityType, &category.CreationDate, &category.UpdateDate)
+		errScan := rows.Scan(&category.ID, &category.UserID, &category.Name, &category.Color, &category.IconID, &category.EntityType, &category.FinancialGroupID, &category.CreationDate, &category.UpdateDate)
 		if errScan != nil {
 			return &categories, totalCount, errScan
 		}
@@ -82,8 +84,8 @@ func (r *categoryRepository) List(ctx context.Context, limit int, offset int, us
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
-	queryFormat := "DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id"
-    query := fmt.Sprintf(queryFormat, r.tableName)
+	queryFormat := "DELETE FROM %s c WHERE c.id = $1 AND %s RETURNING c.id"
+    query := fmt.Sprintf(queryFormat, r.tableName, adminScopeCondition("c", 2))
 	var deletedID int
 	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
 	return err
@@ -121,14 +123,16 @@ func (r *categoryRepository) Update(ctx context.Context, category *models.Catego
         log.Printf("No update provided!: %+v\n", setClauses)
 		return &server_errors.EmptyUpdate
 	}
+	argIndex++
 	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE id = '%d' AND user_id = '%s' RETURNING id",
+		"UPDATE %s c SET %s WHERE c.id = '%d' AND %s RETURNING c.id, c.user_id, c.name, c.color, c.icon_id, c.entity_type, c.financial_group_id, c.creation_date, c.update_date",
         r.tableName,
 		strings.Join(setClauses, ", "),
 		category.ID,
-		category.UserID,
+		editableScopeCondition("c", argIndex),
 	)
+	args = append(args, category.UserID)
 
-	err := r.db.QueryRow(ctx, query, args...).Scan(&category.ID)
+	err := r.db.QueryRow(ctx, query, args...).Scan(&category.ID, &category.UserID, &category.Name, &category.Color, &category.IconID, &category.EntityType, &category.FinancialGroupID, &category.CreationDate, &category.UpdateDate)
 	return err
 }
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/models"
+)
+
+type ExchangeRateRepository interface {
+	Upsert(ctx context.Context, exchangeRate *models.ExchangeRate) error
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*models.ExchangeRate, error)
+	List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]models.ExchangeRate, int, error)
+	Update(ctx context.Context, exchangeRate *models.ExchangeRate) error
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	GetRate(ctx context.Context, baseCurrency, quoteCurrency string, userID uuid.UUID) (string, error)
+}
+
+type exchangeRateRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewExchangeRateRepository(db *pgxpool.Pool) ExchangeRateRepository {
+	return &exchangeRateRepository{db: db, tableName: "exchange_rates"}
+}
+
+// Upsert creates the rate or replaces the existing one for the same currency
+// pair, users only ever keep their latest rate.
+func (r *exchangeRateRepository) Upsert(ctx context.Context, exchangeRate *models.ExchangeRate) error {
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, base_currency, quote_currency, rate, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $5)
+        ON CONFLICT (user_id, base_currency, quote_currency)
+        DO UPDATE SET rate = EXCLUDED.rate, update_date = EXCLUDED.update_date
+        RETURNING id, TRIM_SCALE(rate)::TEXT, creation_date
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	exchangeRate.UpdateDate = currentTime
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		exchangeRate.UserID,
+		exchangeRate.BaseCurrency,
+		exchangeRate.QuoteCurrency,
+		exchangeRate.Rate,
+		currentTime,
+	).Scan(&exchangeRate.ID, &exchangeRate.Rate, &exchangeRate.CreationDate)
+	return err
+}
+
+func (r *exchangeRateRepository) GetByID(ctx context.Context, id int, userID uuid.UUID) (*models.ExchangeRate, error) {
+	queryFormat := `
+        SELECT id, user_id, base_currency, quote_currency, TRIM_SCALE(rate)::TEXT, creation_date, update_date
+        FROM %s
+        WHERE id = $1 AND user_id = $2
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+
+	var exchangeRate models.ExchangeRate
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(
+		&exchangeRate.ID,
+		&exchangeRate.UserID,
+		&exchangeRate.BaseCurrency,
+		&exchangeRate.QuoteCurrency,
+		&exchangeRate.Rate,
+		&exchangeRate.CreationDate,
+		&exchangeRate.UpdateDate,
+	)
+	return &exchangeRate, err
+}
+
+func (r *exchangeRateRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]models.ExchangeRate, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queryFormat := `
+        SELECT id, user_id, base_currency, quote_currency, TRIM_SCALE(rate)::TEXT, creation_date, update_date
+        FROM %s
+        WHERE user_id = $1
+        ORDER BY base_currency, quote_currency
+        LIMIT $2 OFFSET $3
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var exchangeRates = make([]models.ExchangeRate, 0, limit)
+	for rows.Next() {
+		var exchangeRate models.ExchangeRate
+		if err := rows.Scan(
+			&exchangeRate.ID,
+			&exchangeRate.UserID,
+			&exchangeRate.BaseCurrency,
+			&exchangeRate.QuoteCurrency,
+			&exchangeRate.Rate,
+			&exchangeRate.CreationDate,
+			&exchangeRate.UpdateDate,
+		); err != nil {
+			return nil, 0, err
+		}
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &exchangeRates, totalCount, nil
+}
+
+func (r *exchangeRateRepository) Update(ctx context.Context, exchangeRate *models.ExchangeRate) error {
+	queryFormat := `
+        UPDATE %s
+        SET rate = $1
+        WHERE id = $2 AND user_id = $3
+        RETURNING base_currency, quote_currency, TRIM_SCALE(rate)::TEXT, creation_date, update_date
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	err := r.db.QueryRow(ctx, query, exchangeRate.Rate, exchangeRate.ID, exchangeRate.UserID).Scan(
+		&exchangeRate.BaseCurrency,
+		&exchangeRate.QuoteCurrency,
+		&exchangeRate.Rate,
+		&exchangeRate.CreationDate,
+		&exchangeRate.UpdateDate,
+	)
+	return err
+}
+
+func (r *exchangeRateRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	queryFormat := "DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
+	return err
+}
+
+// GetRate returns the rate to convert baseCurrency into quoteCurrency, the
+// inverse of the opposite pair is used when only that one is defined.
+func (r *exchangeRateRepository) GetRate(ctx context.Context, baseCurrency, quoteCurrency string, userID uuid.UUID) (string, error) {
+	return exchangeRate(ctx, r.db, baseCurrency, quoteCurrency, userID)
+}
+
+// exchangeRate is GetRate for callers that already hold a database
+// transaction.
+func exchangeRate(ctx context.Context, q queryRower, baseCurrency, quoteCurrency string, userID uuid.UUID) (string, error) {
+	query := `
+        SELECT TRIM_SCALE(rate)::TEXT
+        FROM (
+            SELECT rate, 0 AS priority
+            FROM exchange_rates
+            WHERE user_id = $1 AND base_currency = $2 AND quote_currency = $3
+            UNION ALL
+            SELECT ROUND(1 / rate, 10), 1 AS priority
+            FROM exchange_rates
+            WHERE user_id = $1 AND base_currency = $3 AND quote_currency = $2
+        ) rates
+        ORDER BY priority
+        LIMIT 1
+    `
+	var rate string
+	err := q.QueryRow(ctx, query, userID, baseCurrency, quoteCurrency).Scan(&rate)
+	return rate, err
+}
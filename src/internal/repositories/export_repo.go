@@ -0,0 +1,379 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+type ExportRepository interface {
+	CountTransactions(ctx context.Context, userID uuid.UUID, from, to *time.Time) (int, error)
+	Stream(ctx context.Context, userID uuid.UUID, from, to *time.Time, w ExportRecordWriter) error
+	CreateJob(ctx context.Context, job *models.ExportJob) error
+	GetJob(ctx context.Context, id int, userID uuid.UUID) (*dto.ExportJobResponse, error)
+	ListJobs(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.ExportJobResponse, int, error)
+	ClaimJob(ctx context.Context, staleAfter time.Duration) (*models.ExportJob, error)
+	CompleteJob(ctx context.Context, job *models.ExportJob, fileName string) error
+	FailJob(ctx context.Context, id int, message string) error
+}
+
+type exportRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewExportRepository(db *pgxpool.Pool) ExportRepository {
+	return &exportRepository{db: db, tableName: "export_jobs"}
+}
+
+// exportTransactionScope matches the transactions of the accounts the user can
+// see, optionally limited to [$2, $3). It takes the user id as $1.
+var exportTransactionScope = fmt.Sprintf(`
+            t.account_id IN (%s)
+            AND ($2::TIMESTAMP IS NULL OR t.transaction_date >= $2)
+            AND ($3::TIMESTAMP IS NULL OR t.transaction_date < $3)
+`, fmt.Sprintf(accessibleAccountsQuery, 1))
+
+// exportRange turns the inclusive days of an export request into the bounds
+// used by exportTransactionScope.
+func exportRange(from, to *time.Time) (*time.Time, *time.Time) {
+	if to == nil {
+		return from, nil
+	}
+	end := to.AddDate(0, 0, 1)
+	return from, &end
+}
+
+func (r *exportRepository) CountTransactions(ctx context.Context, userID uuid.UUID, from, to *time.Time) (int, error) {
+	start, end := exportRange(from, to)
+	query := "SELECT COUNT(*) FROM transactions t WHERE" + exportTransactionScope
+	var count int
+	err := r.db.QueryRow(ctx, query, userID, start, end).Scan(&count)
+	return count, err
+}
+
+// ExportRecordWriter receives the exported records one at a time while they
+// are read: accounts first, then categories, items, transactions and purchase
+// list lines.
+type ExportRecordWriter interface {
+	WriteAccount(account *dto.ExportAccount) error
+	WriteCategory(category *dto.ExportCategory) error
+	WriteItem(item *dto.ExportItem) error
+	WriteTransaction(transaction *dto.ExportTransaction) error
+	WritePurchaseListItem(line *dto.ExportPurchaseListItem) error
+	// TransactionsByAccount asks for the transactions grouped by account, they
+	// are in date order across all accounts otherwise.
+	TransactionsByAccount() bool
+}
+
+// Stream reads everything exported for the user in a single repeatable read
+// transaction, so the files are consistent with each other even when
+// transactions are recorded meanwhile. Records are handed to w as rows
+// arrive, nothing is held in memory.
+func (r *exportRepository) Stream(ctx context.Context, userID uuid.UUID, from, to *time.Time, w ExportRecordWriter) (err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	start, end := exportRange(from, to)
+
+	accountsQuery := fmt.Sprintf(`
+        SELECT a.id, a.name, a.type, a.currency, a.balance, a.category_id, a.financial_group_id, a.creation_date
+        FROM accounts a
+        WHERE a.id IN (%s)
+        ORDER BY a.id
+    `, fmt.Sprintf(accessibleAccountsQuery, 1))
+	if err = streamRows(ctx, tx, accountsQuery, []any{userID}, func(rows pgx.Rows) error {
+		var account dto.ExportAccount
+		if err := rows.Scan(&account.ID, &account.Name, &account.Type, &account.Currency, &account.Balance, &account.CategoryID, &account.FinancialGroupID, &account.CreationDate); err != nil {
+			return err
+		}
+		return w.WriteAccount(&account)
+	}); err != nil {
+		return err
+	}
+
+	categoriesQuery := fmt.Sprintf(`
+        SELECT c.id, c.name, c.color, c.entity_type, c.financial_group_id
+        FROM categories c
+        WHERE %s
+        ORDER BY c.id
+    `, visibleScopeCondition("c", 1))
+	if err = streamRows(ctx, tx, categoriesQuery, []any{userID}, func(rows pgx.Rows) error {
+		var category dto.ExportCategory
+		if err := rows.Scan(&category.ID, &category.Name, &category.Color, &category.EntityType, &category.FinancialGroupID); err != nil {
+			return err
+		}
+		return w.WriteCategory(&category)
+	}); err != nil {
+		return err
+	}
+
+	itemsQuery := fmt.Sprintf(`
+        SELECT i.id, i.name, i.category_id, i.financial_group_id
+        FROM items i
+        WHERE %s
+        ORDER BY i.id
+    `, visibleScopeCondition("i", 1))
+	if err = streamRows(ctx, tx, itemsQuery, []any{userID}, func(rows pgx.Rows) error {
+		var item dto.ExportItem
+		if err := rows.Scan(&item.ID, &item.Name, &item.CategoryID, &item.FinancialGroupID); err != nil {
+			return err
+		}
+		return w.WriteItem(&item)
+	}); err != nil {
+		return err
+	}
+
+	transactionsOrder := "t.transaction_date, t.id"
+	if w.TransactionsByAccount() {
+		transactionsOrder = "t.account_id, t.transaction_date, t.id"
+	}
+	transactionsQuery := `
+        SELECT t.id, t.account_id, t.category_id, t.transaction_type, t.status, t.amount, t.description, TRIM_SCALE(t.exchange_rate)::TEXT, t.linked_transaction_id, t.reversal_of_id, t.transaction_date
+        FROM transactions t
+        WHERE` + exportTransactionScope + `
+        ORDER BY ` + transactionsOrder
+	if err = streamRows(ctx, tx, transactionsQuery, []any{userID, start, end}, func(rows pgx.Rows) error {
+		var transaction dto.ExportTransaction
+		if err := rows.Scan(
+			&transaction.ID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Type,
+			&transaction.Status,
+			&transaction.Amount,
+			&transaction.Description,
+			&transaction.ExchangeRate,
+			&transaction.LinkedTransactionID,
+			&transaction.ReversalOfID,
+			&transaction.Date,
+		); err != nil {
+			return err
+		}
+		return w.WriteTransaction(&transaction)
+	}); err != nil {
+		return err
+	}
+
+	purchaseListItemsQuery := `
+        SELECT pli.id, pli.transaction_id, pli.item_id, pli.count, pli.unit_price
+        FROM purchase_list_items pli
+        JOIN transactions t
+            ON t.id = pli.transaction_id
+        WHERE` + exportTransactionScope + `
+        ORDER BY pli.transaction_id, pli.id
+    `
+	if err = streamRows(ctx, tx, purchaseListItemsQuery, []any{userID, start, end}, func(rows pgx.Rows) error {
+		var line dto.ExportPurchaseListItem
+		if err := rows.Scan(&line.ID, &line.TransactionID, &line.ItemID, &line.Count, &line.UnitPrice); err != nil {
+			return err
+		}
+		return w.WritePurchaseListItem(&line)
+	}); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// streamRows calls handle for every row of the query as it is received.
+func streamRows(ctx context.Context, tx pgx.Tx, query string, args []any, handle func(rows pgx.Rows) error) error {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := handle(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const exportJobColumns = `
+            ej.id,
+            ej.user_id,
+            ej.format,
+            ej.from_date,
+            ej.to_date,
+            ej.status,
+            ej.media_id,
+            ej.error_message,
+            ej.completion_date,
+            ej.creation_date,
+            ej.update_date
+`
+
+func exportJobFields(job *models.ExportJob) []any {
+	return []any{
+		&job.ID,
+		&job.UserID,
+		&job.Format,
+		&job.FromDate,
+		&job.ToDate,
+		&job.Status,
+		&job.MediaID,
+		&job.ErrorMessage,
+		&job.CompletionDate,
+		&job.CreationDate,
+		&job.UpdateDate,
+	}
+}
+
+const exportJobJoinedSelect = `
+        SELECT` + exportJobColumns + `,
+            m.url
+        FROM export_jobs ej
+        LEFT JOIN media m
+            ON m.id = ej.media_id
+`
+
+func scanExportJobJoined(row pgx.Row, item *dto.ExportJobResponse) error {
+	fields := append(exportJobFields(&item.ExportJob), &item.URL)
+	return row.Scan(fields...)
+}
+
+func (r *exportRepository) CreateJob(ctx context.Context, job *models.ExportJob) error {
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, format, from_date, to_date, status, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING id
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	job.Status = enums.ExportJobPending
+	job.CreationDate = currentTime
+	job.UpdateDate = currentTime
+	err := r.db.QueryRow(
+		ctx,
+		fmt.Sprintf(queryFormat, r.tableName),
+		job.UserID,
+		job.Format,
+		job.FromDate,
+		job.ToDate,
+		job.Status,
+		currentTime,
+	).Scan(&job.ID)
+	return err
+}
+
+func (r *exportRepository) GetJob(ctx context.Context, id int, userID uuid.UUID) (*dto.ExportJobResponse, error) {
+	query := exportJobJoinedSelect + `
+        WHERE ej.id = $1 AND ej.user_id = $2
+    `
+	var item dto.ExportJobResponse
+	err := scanExportJobJoined(r.db.QueryRow(ctx, query, id, userID), &item)
+	return &item, err
+}
+
+func (r *exportRepository) ListJobs(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.ExportJobResponse, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := exportJobJoinedSelect + `
+        WHERE ej.user_id = $1
+        ORDER BY ej.id DESC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var jobs = make([]dto.ExportJobResponse, 0, limit)
+	for rows.Next() {
+		var item dto.ExportJobResponse
+		if err := scanExportJobJoined(rows, &item); err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &jobs, totalCount, nil
+}
+
+// ClaimJob marks the oldest pending job as running and returns it, or
+// pgx.ErrNoRows when there is none. Jobs left running for longer than
+// staleAfter belong to a worker that stopped and are claimed again.
+func (r *exportRepository) ClaimJob(ctx context.Context, staleAfter time.Duration) (*models.ExportJob, error) {
+	query := fmt.Sprintf(`
+        UPDATE %[1]s ej
+        SET status = 'running', update_date = $1
+        WHERE ej.id = (
+            SELECT id
+            FROM %[1]s
+            WHERE status = 'pending' OR (status = 'running' AND update_date < $2)
+            ORDER BY id
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING`+exportJobColumns, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	var job models.ExportJob
+	err := r.db.QueryRow(ctx, query, currentTime, currentTime.Add(-staleAfter)).Scan(exportJobFields(&job)...)
+	return &job, err
+}
+
+// CompleteJob registers the written file as temporary media owned by the user
+// and links it to the job, so it is served by the media file endpoint until
+// the media cleaner removes it.
+func (r *exportRepository) CompleteJob(ctx context.Context, job *models.ExportJob, fileName string) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	mediaQuery := `
+        INSERT INTO media (url, file_path, user_id, status, access, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING id
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	var mediaID int
+	if err = tx.QueryRow(
+		ctx,
+		mediaQuery,
+		fmt.Sprintf("/file/%s", fileName),
+		fileName,
+		job.UserID,
+		enums.MediaStatusTemp,
+		enums.Owner,
+		currentTime,
+	).Scan(&mediaID); err != nil {
+		return err
+	}
+
+	jobQuery := fmt.Sprintf("UPDATE %s SET status = $1, media_id = $2, completion_date = $3 WHERE id = $4", r.tableName)
+	if _, err = tx.Exec(ctx, jobQuery, enums.ExportJobCompleted, mediaID, currentTime, job.ID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+func (r *exportRepository) FailJob(ctx context.Context, id int, message string) error {
+	query := fmt.Sprintf("UPDATE %s SET status = $1, error_message = $2, completion_date = $3 WHERE id = $4", r.tableName)
+	_, err := r.db.Exec(ctx, query, enums.ExportJobFailed, message, time.Now().UTC().Truncate(time.Second), id)
+	return err
+}
@@ -0,0 +1,298 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type FinancialGroupInvitationRepository interface {
+	Create(ctx context.Context, invitation *models.FinancialGroupInvitation) (*dto.FinancialGroupInvitationResponse, error)
+	ListByGroup(ctx context.Context, financialGroupID int) (*[]dto.FinancialGroupInvitationResponse, error)
+	ListPending(ctx context.Context, userID uuid.UUID) (*[]dto.FinancialGroupInvitationResponse, error)
+	Accept(ctx context.Context, token string, userID uuid.UUID) (*dto.FinancialGroupInvitationResponse, error)
+	Decline(ctx context.Context, token string, userID uuid.UUID) error
+	Revoke(ctx context.Context, id, financialGroupID int) error
+}
+
+type financialGroupInvitationRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewFinancialGroupInvitationRepository(db *pgxpool.Pool) FinancialGroupInvitationRepository {
+	return &financialGroupInvitationRepository{db: db, tableName: "financial_group_invitations"}
+}
+
+const financialGroupInvitationJoinedSelect = `
+        SELECT
+            fgi.id,
+            fgi.financial_group_id,
+            fg.name,
+            fgi.invited_by,
+            fgi.email,
+            fgi.token,
+            fgi.status,
+            fgi.role,
+            fgi.expires_at,
+            fgi.creation_date,
+            fgi.update_date
+        FROM financial_group_invitations fgi
+        JOIN financial_groups fg
+            ON fg.id = fgi.financial_group_id
+`
+
+func scanFinancialGroupInvitation(row pgx.Row, item *dto.FinancialGroupInvitationResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.FinancialGroupID,
+		&item.FinancialGroupName,
+		&item.InvitedBy,
+		&item.Email,
+		&item.Token,
+		&item.Status,
+		&item.Role,
+		&item.ExpiresAt,
+		&item.CreationDate,
+		&item.UpdateDate,
+	)
+}
+
+// Create invites email to the group. Users that are already members can not be
+// invited again and only one invitation per email can be pending, an older one
+// that is past its expiry is marked as expired first so it does not block.
+func (r *financialGroupInvitationRepository) Create(ctx context.Context, invitation *models.FinancialGroupInvitation) (_ *dto.FinancialGroupInvitationResponse, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	memberQuery := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM user_financial_groups ufg
+            JOIN users u
+                ON u.id = ufg.user_id
+            WHERE ufg.financial_group_id = $1 AND LOWER(u.email) = LOWER($2)
+        )
+    `
+	var isMember bool
+	if err = tx.QueryRow(ctx, memberQuery, invitation.FinancialGroupID, invitation.Email).Scan(&isMember); err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, &server_errors.UserAlreadyInFinancialGroup
+	}
+
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	expireQueryFormat := `
+        UPDATE %s
+        SET status = 'expired'
+        WHERE financial_group_id = $1 AND LOWER(email) = LOWER($2) AND status = 'pending' AND expires_at <= $3
+    `
+	if _, err = tx.Exec(ctx, fmt.Sprintf(expireQueryFormat, r.tableName), invitation.FinancialGroupID, invitation.Email, currentTime); err != nil {
+		return nil, err
+	}
+
+	queryFormat := `
+        INSERT INTO %s (financial_group_id, invited_by, email, token, status, role, expires_at, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+        RETURNING id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	invitation.Status = enums.InvitationPending
+	invitation.CreationDate = currentTime
+	invitation.UpdateDate = currentTime
+	err = tx.QueryRow(
+		ctx,
+		query,
+		invitation.FinancialGroupID,
+		invitation.InvitedBy,
+		invitation.Email,
+		invitation.Token,
+		invitation.Status,
+		invitation.Role,
+		invitation.ExpiresAt,
+		currentTime,
+	).Scan(&invitation.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == server_errors.PGUniqueViolation {
+			err = &server_errors.InvitationAlreadyPending
+		}
+		return nil, err
+	}
+
+	result, err := r.getByID(ctx, tx, invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListByGroup returns the pending invitations of a group.
+func (r *financialGroupInvitationRepository) ListByGroup(ctx context.Context, financialGroupID int) (*[]dto.FinancialGroupInvitationResponse, error) {
+	query := financialGroupInvitationJoinedSelect + `
+        WHERE fgi.financial_group_id = $1 AND fgi.status = 'pending' AND fgi.expires_at > $2
+        ORDER BY fgi.id
+    `
+	return r.list(ctx, query, financialGroupID, time.Now().UTC())
+}
+
+// ListPending returns the invitations sent to the email of the user that can
+// still be accepted.
+func (r *financialGroupInvitationRepository) ListPending(ctx context.Context, userID uuid.UUID) (*[]dto.FinancialGroupInvitationResponse, error) {
+	query := financialGroupInvitationJoinedSelect + `
+        JOIN users u
+            ON LOWER(u.email) = LOWER(fgi.email)
+        WHERE u.id = $1 AND fgi.status = 'pending' AND fgi.expires_at > $2
+        ORDER BY fgi.id
+    `
+	return r.list(ctx, query, userID, time.Now().UTC())
+}
+
+// Accept adds the user to the group of the invitation with the role it was
+// sent with. The invitation is locked for the whole transaction so it can only
+// be used once, while the add_user_to_financial_group_check trigger still
+// rejects duplicate members.
+func (r *financialGroupInvitationRepository) Accept(ctx context.Context, token string, userID uuid.UUID) (_ *dto.FinancialGroupInvitationResponse, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	var invitationID int
+	var expired bool
+	if invitationID, expired, err = r.lockPending(ctx, tx, token, userID); err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, &server_errors.InvitationExpired
+	}
+
+	memberQuery := `
+        INSERT INTO user_financial_groups (financial_group_id, user_id, role)
+        SELECT financial_group_id, $2, role
+        FROM financial_group_invitations
+        WHERE id = $1
+    `
+	if _, err = tx.Exec(ctx, memberQuery, invitationID, userID); err != nil {
+		return nil, err
+	}
+
+	if err = r.setStatus(ctx, tx, invitationID, enums.InvitationAccepted); err != nil {
+		return nil, err
+	}
+
+	result, err := r.getByID(ctx, tx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *financialGroupInvitationRepository) Decline(ctx context.Context, token string, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	var invitationID int
+	var expired bool
+	if invitationID, expired, err = r.lockPending(ctx, tx, token, userID); err != nil {
+		return err
+	}
+	if expired {
+		return &server_errors.InvitationExpired
+	}
+
+	if err = r.setStatus(ctx, tx, invitationID, enums.InvitationDeclined); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// Revoke cancels a pending invitation of the group.
+func (r *financialGroupInvitationRepository) Revoke(ctx context.Context, id, financialGroupID int) error {
+	queryFormat := "UPDATE %s SET status = $1 WHERE id = $2 AND financial_group_id = $3 AND status = 'pending' RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var revokedID int
+	err := r.db.QueryRow(ctx, query, enums.InvitationRevoked, id, financialGroupID).Scan(&revokedID)
+	return err
+}
+
+// lockPending locks the pending invitation with token addressed to the email
+// of the user, it returns pgx.ErrNoRows when there is none.
+func (r *financialGroupInvitationRepository) lockPending(ctx context.Context, tx pgx.Tx, token string, userID uuid.UUID) (id int, expired bool, err error) {
+	queryFormat := `
+        SELECT fgi.id, fgi.expires_at <= $3
+        FROM %s fgi
+        JOIN users u
+            ON LOWER(u.email) = LOWER(fgi.email)
+        WHERE fgi.token = $1 AND u.id = $2 AND fgi.status = 'pending'
+        FOR UPDATE OF fgi
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	err = tx.QueryRow(ctx, query, token, userID, time.Now().UTC()).Scan(&id, &expired)
+	return id, expired, err
+}
+
+func (r *financialGroupInvitationRepository) setStatus(ctx context.Context, tx pgx.Tx, id int, status enums.InvitationStatus) error {
+	queryFormat := "UPDATE %s SET status = $1 WHERE id = $2"
+	_, err := tx.Exec(ctx, fmt.Sprintf(queryFormat, r.tableName), status, id)
+	return err
+}
+
+func (r *financialGroupInvitationRepository) list(ctx context.Context, query string, args ...any) (*[]dto.FinancialGroupInvitationResponse, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations = make([]dto.FinancialGroupInvitationResponse, 0)
+	for rows.Next() {
+		var item dto.FinancialGroupInvitationResponse
+		if err := scanFinancialGroupInvitation(rows, &item); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &invitations, nil
+}
+
+func (r *financialGroupInvitationRepository) getByID(ctx context.Context, q queryRower, id int) (*dto.FinancialGroupInvitationResponse, error) {
+	query := financialGroupInvitationJoinedSelect + `
+        WHERE fgi.id = $1
+    `
+	var item dto.FinancialGroupInvitationResponse
+	err := scanFinancialGroupInvitation(q.QueryRow(ctx, query, id), &item)
+	return &item, err
+}
@@ -6,20 +6,23 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
 	server_errors "shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/models"
 )
 
 type FinancialGroupRepository interface {
 	Create(ctx context.Context, financialGroup *models.FinancialGroups) error
-	AddUserToGroup(ctx context.Context, financialGroupID int, userID uuid.UUID) error
 	GetRelatedGroupByID(ctx context.Context, finacialGroupID int, userID uuid.UUID) (*dto.FinancialGroup, error)
 	GetOwnedGroupByID(ctx context.Context, financialGroupID int, userID uuid.UUID) (*models.FinancialGroups, error)
 	ListOwnedGroups(ctx context.Context, page, size int, userID uuid.UUID) ([]dto.FinancialGroupListItem, int, error)
 	ListMemberedGroups(ctx context.Context, page, size int, userID uuid.UUID) ([]dto.FinancialGroupListItem, int, error)
-	RemoveGroupMember(ctx context.Context, financialGroupID int, memberID, userID uuid.UUID) error
+	RemoveGroupMember(ctx context.Context, financialGroupID int, memberID uuid.UUID) error
+	UpdateMemberRole(ctx context.Context, financialGroupID int, memberID uuid.UUID, role enums.FinancialGroupRole) error
+	TransferOwnership(ctx context.Context, financialGroupID int, newOwnerID, userID uuid.UUID) error
 	Delete(ctx context.Context, financialGroupID int) error
 	GetByID(ctx context.Context, financialGroupID int) (*models.FinancialGroups, error)
+	GetMemberRole(ctx context.Context, financialGroupID int, userID uuid.UUID) (enums.FinancialGroupRole, error)
 }
 
 type financialGroupRepository struct {
@@ -42,27 +45,13 @@ func (r *financialGroupRepository) Create(ctx context.Context, financialGroup *m
 	return err
 }
 
-func (r *financialGroupRepository) AddUserToGroup(ctx context.Context, financialGroupID int, userID uuid.UUID) error {
-	query := `
-        INSERT INTO user_financial_groups 
-        (financial_group_id, user_id)
-        VALUES ($1, $2)
-        RETURNING id
-    `
-	var relID int
-	err := r.db.QueryRow(ctx, query, financialGroupID, userID).Scan(&relID)
-	return err
-}
-
 func (r *financialGroupRepository) GetRelatedGroupByID(ctx context.Context, financialGroupID int, userID uuid.UUID) (*dto.FinancialGroup, error) {
 	var financialGroup dto.FinancialGroup
-	var usersList []uuid.UUID
 	query := `
         SELECT
-            fg.name, m.url, fg.user_id, fg.creation_date, fg.update_date, ARRAY_AGG(ufg.user_id) AS members
+            fg.name, m.url, fg.user_id, fg.creation_date, fg.update_date
         FROM financial_groups fg
         LEFT JOIN media m ON m.id = fg.image_id
-        LEFT JOIN user_financial_groups ufg ON ufg.financial_group_id = fg.id
         WHERE
             fg.id = $1
             AND (
@@ -74,30 +63,31 @@ func (r *financialGroupRepository) GetRelatedGroupByID(ctx context.Context, fina
                     AND user_id = $2
                 )
             )
-        GROUP BY
-            fg.name, m.url, fg.user_id, fg.creation_date, fg.update_date;
     `
 	usersQuery := `
-        SELECT u.id, pm.url profie_picture, p.name, p.family_name
-        FROM users u
+        SELECT u.id, pm.url profie_picture, p.name, p.family_name, ufg.role
+        FROM user_financial_groups ufg
+        JOIN users u ON u.id = ufg.user_id
         JOIN profiles p ON p.id = u.profile_id
         LEFT JOIN media pm ON pm.id = p.picture_id
-        WHERE u.id = ANY($1)
+        WHERE ufg.financial_group_id = $1
+        ORDER BY ufg.id
     `
 
-	if err := r.db.QueryRow(ctx, query, financialGroupID, userID).Scan(&financialGroup.Name, &financialGroup.ImageURL, &financialGroup.OwnerID, &financialGroup.CreationDate, &financialGroup.UpdateDate, &usersList); err != nil {
+	if err := r.db.QueryRow(ctx, query, financialGroupID, userID).Scan(&financialGroup.Name, &financialGroup.ImageURL, &financialGroup.OwnerID, &financialGroup.CreationDate, &financialGroup.UpdateDate); err != nil {
 		return nil, err
 	}
 
-	rows, err := r.db.Query(ctx, usersQuery, usersList)
+	rows, err := r.db.Query(ctx, usersQuery, financialGroupID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	var users []*dto.UserGetResponse
+	var users []*dto.FinancialGroupMember
 	for rows.Next() {
-		var user dto.UserGetResponse
-		if err := rows.Scan(&user.ID, &user.ProfilePictureURL, &user.Name, &user.FamilyName); err != nil {
+		var user dto.FinancialGroupMember
+		if err := rows.Scan(&user.ID, &user.ProfilePictureURL, &user.Name, &user.FamilyName, &user.Role); err != nil {
 			return nil, err
 		}
 		users = append(users, &user)
@@ -205,20 +195,11 @@ func (r *financialGroupRepository) ListMemberedGroups(ctx context.Context, limit
 	return financialGroups, totalCount, nil
 }
 
-func (r *financialGroupRepository) RemoveGroupMember(ctx context.Context, financialGroupID int, memberID, userID uuid.UUID) error {
-	if userID != memberID {
-		getOwnerQuery := `
-            SELECT user_id
-            FROM financial_groups
-            WHERE id = $1
-        `
-		var ownerID uuid.UUID
-		if err := r.db.QueryRow(ctx, getOwnerQuery, financialGroupID).Scan(&ownerID); err != nil {
-			return err
-		}
-		if ownerID != userID {
-			return &server_errors.Unauthorized
-		}
+// RemoveGroupMember removes memberID from the group, the owner can not be
+// removed before handing the group over.
+func (r *financialGroupRepository) RemoveGroupMember(ctx context.Context, financialGroupID int, memberID uuid.UUID) error {
+	if err := r.requireNotOwner(ctx, financialGroupID, memberID); err != nil {
+		return err
 	}
 
 	query := `
@@ -235,6 +216,74 @@ func (r *financialGroupRepository) RemoveGroupMember(ctx context.Context, financ
 	return err
 }
 
+// UpdateMemberRole changes the role of a member, the owner keeps the admin
+// role for as long as they own the group.
+func (r *financialGroupRepository) UpdateMemberRole(ctx context.Context, financialGroupID int, memberID uuid.UUID, role enums.FinancialGroupRole) error {
+	if err := r.requireNotOwner(ctx, financialGroupID, memberID); err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE user_financial_groups
+        SET role = $3
+        WHERE financial_group_id = $1 AND user_id = $2
+        RETURNING id
+    `
+
+	var id int
+	err := r.db.QueryRow(ctx, query, financialGroupID, memberID, role).Scan(&id)
+	return err
+}
+
+// TransferOwnership hands the group over to one of its members, who becomes an
+// admin if they were not one already. The previous owner stays as an admin.
+func (r *financialGroupRepository) TransferOwnership(ctx context.Context, financialGroupID int, newOwnerID, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	var ownerID uuid.UUID
+	if err = tx.QueryRow(ctx, "SELECT user_id FROM financial_groups WHERE id = $1 FOR UPDATE", financialGroupID).Scan(&ownerID); err != nil {
+		return err
+	}
+	if ownerID != userID {
+		return &server_errors.Unauthorized
+	}
+
+	promoteQuery := `
+        UPDATE user_financial_groups
+        SET role = 'admin'
+        WHERE financial_group_id = $1 AND user_id = $2
+        RETURNING id
+    `
+	var memberID int
+	if err = tx.QueryRow(ctx, promoteQuery, financialGroupID, newOwnerID).Scan(&memberID); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE financial_groups SET user_id = $2 WHERE id = $1", financialGroupID, newOwnerID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// requireNotOwner fails with FinancialGroupOwnerChange when memberID owns the
+// group, and pgx.ErrNoRows when the group does not exist.
+func (r *financialGroupRepository) requireNotOwner(ctx context.Context, financialGroupID int, memberID uuid.UUID) error {
+	var ownerID uuid.UUID
+	if err := r.db.QueryRow(ctx, "SELECT user_id FROM financial_groups WHERE id = $1", financialGroupID).Scan(&ownerID); err != nil {
+		return err
+	}
+	if ownerID == memberID {
+		return &server_errors.FinancialGroupOwnerChange
+	}
+	return nil
+}
+
 func (r *financialGroupRepository) Delete(ctx context.Context, financialGroupID int) error {
 	query := `
         DELETE FROM financial_groups
@@ -257,3 +306,16 @@ func (r *financialGroupRepository) GetByID(ctx context.Context, financialGroupID
 	err := r.db.QueryRow(ctx, query, financialGroupID).Scan(&financialGroup.ID, &financialGroup.Name, &financialGroup.UserID, &financialGroup.ImageID, &financialGroup.CreationDate, &financialGroup.UpdateDate)
 	return &financialGroup, err
 }
+
+// GetMemberRole returns the role the user holds in the group, it returns
+// pgx.ErrNoRows when the user is not a member.
+func (r *financialGroupRepository) GetMemberRole(ctx context.Context, financialGroupID int, userID uuid.UUID) (enums.FinancialGroupRole, error) {
+	query := `
+        SELECT role
+        FROM user_financial_groups
+        WHERE financial_group_id = $1 AND user_id = $2
+    `
+	var role enums.FinancialGroupRole
+	err := r.db.QueryRow(ctx, query, financialGroupID, userID).Scan(&role)
+	return role, err
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"fmt"
+)
+
+// Accounts, categories and items are either personal, owned by user_id, or
+// belong to a financial group through financial_group_id. Group rows are shared
+// by every member according to their role: viewers can read them,
+// contributors can also create and update them while deleting needs an admin.
+
+// visibleScopeCondition matches rows the user can read, alias is the table
+// alias and argIndex the position of the user id argument.
+func visibleScopeCondition(alias string, argIndex int) string {
+	return fmt.Sprintf(
+		"((%[1]s.financial_group_id IS NULL AND %[1]s.user_id = $%[2]d) OR %[1]s.financial_group_id IN (SELECT financial_group_id FROM user_financial_groups WHERE user_id = $%[2]d))",
+		alias,
+		argIndex,
+	)
+}
+
+// editableScopeCondition matches rows the user can update, personal ones and
+// the ones of groups where the user is at least a contributor.
+func editableScopeCondition(alias string, argIndex int) string {
+	return fmt.Sprintf(
+		"((%[1]s.financial_group_id IS NULL AND %[1]s.user_id = $%[2]d) OR %[1]s.financial_group_id IN (SELECT financial_group_id FROM user_financial_groups WHERE user_id = $%[2]d AND role >= 'contributor'))",
+		alias,
+		argIndex,
+	)
+}
+
+// adminScopeCondition matches rows the user can delete, personal ones and the
+// ones of groups where the user is an admin.
+func adminScopeCondition(alias string, argIndex int) string {
+	return fmt.Sprintf(
+		"((%[1]s.financial_group_id IS NULL AND %[1]s.user_id = $%[2]d) OR %[1]s.financial_group_id IN (SELECT financial_group_id FROM user_financial_groups WHERE user_id = $%[2]d AND role = 'admin'))",
+		alias,
+		argIndex,
+	)
+}
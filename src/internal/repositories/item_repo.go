@@ -31,33 +31,35 @@ func NewItemRepository(db *pgxpool.Pool) ItemRepository {
 }
 
 func (r *itemRepository) Create(ctx context.Context, item *models.Item) error {
-	queryFormat := "INSERT INTO %s (user_id, name, image_id, category_id) VALUES ($1, $2, $3, $4) RETURNING id"
+	queryFormat := "INSERT INTO %s (user_id, name, image_id, category_id, financial_group_id) VALUES ($1, $2, $3, $4, $5) RETURNING id"
 	query := fmt.Sprintf(queryFormat, r.tableName)
 	// We do not rely on postgres default time because it works with nano seconds but we want second percision
 	currentTime := time.Now().UTC().Truncate(time.Second)
 	item.CreationDate = currentTime
 	item.UpdateDate = currentTime
-	err := r.db.QueryRow(ctx, query, item.UserID, item.Name, item.ImageID, item.CategoryID).Scan(&item.ID)
+	err := r.db.QueryRow(ctx, query, item.UserID, item.Name, item.ImageID, item.CategoryID, item.FinancialGroupID).Scan(&item.ID)
 	return err
 }
 
 func (r *itemRepository) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.ItemJoinedResponse, error) {
-	queryFormat := "SELECT i.id, i.user_id, i.name, i.image_id, m.url, m.metadata, c.id, c.name, cm.url as category_icon, c.entity_type, i.creation_date, i.update_date FROM %s i LEFT JOIN categories c ON i.category_id = c.id LEFT JOIN media m ON i.image_id = m.id LEFT JOIN media cm ON c.icon_id = m.id WHERE i.id = $1 AND i.user_id = $2"
-	query := fmt.Sprintf(queryFormat, r.tableName)
+	queryFormat := "SELECT i.id, i.user_id, i.name, i.image_id, m.url, m.metadata, c.id, c.name, cm.url as category_icon, c.entity_type, i.financial_group_id, i.creation_date, i.update_date FROM %s i LEFT JOIN categories c ON i.category_id = c.id LEFT JOIN media m ON i.image_id = m.id LEFT JOIN media cm ON c.icon_id = cm.id WHERE i.id = $1 AND %s"
+	query := fmt.Sprintf(queryFormat, r.tableName, visibleScopeCondition("i", 2))
 
 	var item dto.ItemJoinedResponse
-	err := r.db.QueryRow(ctx, query, id, userID).Scan(&item.ID, &item.UserID, &item.Name, &item.ImageID, &item.ImageURL, &item.ImageMetadata, &item.CategoryID, &item.CategoryName, &item.CategoryIconURL, &item.CategoryType, &item.CreationDate, &item.UpdateDate)
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&item.ID, &item.UserID, &item.Name, &item.ImageID, &item.ImageURL, &item.ImageMetadata, &item.CategoryID, &item.CategoryName, &item.CategoryIconURL, &item.CategoryType, &item.FinancialGroupID, &item.CreationDate, &item.UpdateDate)
 	return &item, err
 }
 
 func (r *itemRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.ItemJoinedResponse, int, error) {
-	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
-	if err != nil {
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s i WHERE %s", r.tableName, visibleScopeCondition("i", 1))
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&totalCount); err != nil {
 		return nil, 0, err
 	}
 
 	var items = make([]dto.ItemJoinedResponse, 0, limit)
-	query := "SELECT i.id, i.user_id, i.name, i.image_id, m.url, m.metadata, c.id, c.name, cm.url as category_icon, c.entity_type, i.creation_date, i.update_date FROM items i LEFT JOIN categories c ON i.category_id = c.id LEFT JOIN media m ON i.image_id = m.id LEFT JOIN media cm ON c.icon_id = m.id WHERE i.user_id = $1 LIMIT $2 OFFSET $3"
+	queryFormat := "SELECT i.id, i.user_id, i.name, i.image_id, m.url, m.metadata, c.id, c.name, cm.url as category_icon, c.entity_type, i.financial_group_id, i.creation_date, i.update_date FROM %s i LEFT JOIN categories c ON i.category_id = c.id LEFT JOIN media m ON i.image_id = m.id LEFT JOIN media cm ON c.icon_id = cm.id WHERE %s ORDER BY i.id LIMIT $2 OFFSET $3"
+	query := fmt.Sprintf(queryFormat, r.tableName, visibleScopeCondition("i", 1))
 
 	rows, err := r.db.Query(ctx, query, userID, limit, offset)
 	if err != nil {
@@ -67,7 +69,7 @@ func (r *itemRepository) List(ctx context.Context, limit, offset int, userID uui
 
 	for rows.Next() {
 		var item dto.ItemJoinedResponse
-		err = rows.Scan(&item.ID, &item.UserID, &item.Name, &item.ImageID, &item.ImageURL, &item.ImageMetadata, &item.CategoryID, &item.CategoryName, &item.CategoryIconURL, &item.CategoryType, &item.CreationDate, &item.UpdateDate)
+		err = rows.Scan(&item.ID, &item.UserID, &item.Name, &item.ImageID, &item.ImageURL, &item.ImageMetadata, &item.CategoryID, &item.CategoryName, &item.CategoryIconURL, &item.CategoryType, &item.FinancialGroupID, &item.CreationDate, &item.UpdateDate)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -77,8 +79,8 @@ func (r *itemRepository) List(ctx context.Context, limit, offset int, userID uui
 }
 
 func (r *itemRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
-    queryFormat := "DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id"
-    query := fmt.Sprintf(queryFormat, r.tableName)
+    queryFormat := "DELETE FROM %s i WHERE i.id = $1 AND %s RETURNING i.id"
+    query := fmt.Sprintf(queryFormat, r.tableName, adminScopeCondition("i", 2))
     var deletedID int
     err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
     return err
@@ -104,6 +106,7 @@ func (r *itemRepository) Update(ctx context.Context, item *models.Item) (*dto.It
 	if item.ImageID != nil {
 		setClauses = append(setClauses, fmt.Sprintf("image_id = $%d", argIndex))
 		args = append(args, item.ImageID)
+		argIndex++
 	}
 
     if len(setClauses) == 0 {
@@ -111,12 +114,13 @@ func (r *itemRepository) Update(ctx context.Context, item *models.Item) (*dto.It
     }
 
 	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE id = %d AND user_id = '%s' RETURNING id",
+		"UPDATE %s i SET %s WHERE i.id = %d AND %s RETURNING i.id",
         r.tableName,
 		strings.Join(setClauses, ", "),
 		item.ID,
-		item.UserID.String(),
+		editableScopeCondition("i", argIndex),
 	)
+	args = append(args, item.UserID)
 
 	err := r.db.QueryRow(ctx, query, args...).Scan(&item.ID)
     if err != nil {
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/models"
 )
 
@@ -15,6 +19,10 @@ type MediaRepository interface {
 	ListForCleanUp(ctx context.Context, threshold string) ([]string, error)
 	DeleteRemovedMedia(ctx context.Context) error
     GetByMediaName(ctx context.Context, url string, userID uuid.UUID) (*models.Media, error)
+	Replace(ctx context.Context, url string, userID uuid.UUID, filePath string, metadata *string) (*models.Media, string, error)
+	UpdateAccess(ctx context.Context, url string, userID uuid.UUID, access enums.MediaAccess) (*models.Media, error)
+	Move(ctx context.Context, url string, userID uuid.UUID, financialGroupID int) (*models.Media, error)
+	Delete(ctx context.Context, url string, userID uuid.UUID) (string, error)
 }
 
 type mediaRepository struct {
@@ -95,9 +103,159 @@ func (r *mediaRepository) GetByMediaName(ctx context.Context, url string, userID
             m.access = 'public'
             or
             (m.access = 'group' and ufg.id is not null)
+            or
+            exists (
+                select 1 from media_transaction mt
+                join transactions t
+                on t.id = mt.transaction_id
+                where mt.media_id = m.id and has_account_access(t.account_id, $1, 'view')
+            )
         )`
     var media models.Media
     err := r.db.QueryRow(ctx, query, userID, url).Scan(&media.ID, &media.Url, &media.FilePath, &media.Metadata, &media.Access, &media.FinancialGroupID, &media.CreationDate, &media.UpdateDate)
     return &media, err
 }
 
+// lockOwnedMedia locks the media for the changes only its uploader can make,
+// media of other users are reported as not found.
+func lockOwnedMedia(ctx context.Context, tx pgx.Tx, url string, userID uuid.UUID) (*models.Media, error) {
+	query := `
+        SELECT id, user_id, url, file_path, metadata, access, financial_group_id, creation_date, update_date
+        FROM media
+        WHERE url = $1 AND user_id = $2 AND status <> 'removed'
+        FOR UPDATE
+    `
+	var media models.Media
+	err := tx.QueryRow(ctx, query, url, userID).Scan(&media.ID, &media.UserID, &media.Url, &media.FilePath, &media.Metadata, &media.Access, &media.FinancialGroupID, &media.CreationDate, &media.UpdateDate)
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// Replace points the media to a newly stored file and returns the path of the
+// previous one, the URL and so every reference to the media stay the same.
+func (r *mediaRepository) Replace(ctx context.Context, url string, userID uuid.UUID, filePath string, metadata *string) (media *models.Media, oldFilePath string, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	media, err = lockOwnedMedia(ctx, tx, url, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	oldFilePath = media.FilePath
+
+	media.FilePath = filePath
+	media.Metadata = metadata
+	media.UpdateDate = time.Now().UTC().Truncate(time.Second)
+	query := fmt.Sprintf("UPDATE %s SET file_path = $2, metadata = $3, update_date = $4 WHERE id = $1", r.tableName)
+	if _, err = tx.Exec(ctx, query, media.ID, media.FilePath, media.Metadata, media.UpdateDate); err != nil {
+		return nil, "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, "", err
+	}
+	return media, oldFilePath, nil
+}
+
+func (r *mediaRepository) UpdateAccess(ctx context.Context, url string, userID uuid.UUID, access enums.MediaAccess) (media *models.Media, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	media, err = lockOwnedMedia(ctx, tx, url, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Exports are not saved in a group, there is nobody to share them with
+	if access == enums.Group && media.FinancialGroupID == nil {
+		return nil, &server_errors.MediaWithoutGroup
+	}
+
+	media.Access = &access
+	media.UpdateDate = time.Now().UTC().Truncate(time.Second)
+	query := fmt.Sprintf("UPDATE %s SET access = $2, update_date = $3 WHERE id = $1", r.tableName)
+	if _, err = tx.Exec(ctx, query, media.ID, access, media.UpdateDate); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// Move saves the media in another group.
+func (r *mediaRepository) Move(ctx context.Context, url string, userID uuid.UUID, financialGroupID int) (media *models.Media, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	media, err = lockOwnedMedia(ctx, tx, url, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	media.FinancialGroupID = &financialGroupID
+	media.UpdateDate = time.Now().UTC().Truncate(time.Second)
+	query := fmt.Sprintf("UPDATE %s SET financial_group_id = $2, update_date = $3 WHERE id = $1", r.tableName)
+	if _, err = tx.Exec(ctx, query, media.ID, financialGroupID, media.UpdateDate); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// Delete removes the media and its transaction attachments and returns the
+// path of its file. Media still shown as an icon, image or picture is kept,
+// removing it would leave those without one.
+func (r *mediaRepository) Delete(ctx context.Context, url string, userID uuid.UUID) (filePath string, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	media, err := lockOwnedMedia(ctx, tx, url, userID)
+	if err != nil {
+		return "", err
+	}
+
+	inUseQuery := `
+        SELECT EXISTS (SELECT 1 FROM items WHERE image_id = $1)
+            OR EXISTS (SELECT 1 FROM categories WHERE icon_id = $1)
+            OR EXISTS (SELECT 1 FROM financial_groups WHERE image_id = $1)
+            OR EXISTS (SELECT 1 FROM profiles WHERE picture_id = $1)
+    `
+	var inUse bool
+	if err = tx.QueryRow(ctx, inUseQuery, media.ID).Scan(&inUse); err != nil {
+		return "", err
+	}
+	if inUse {
+		return "", &server_errors.MediaInUse
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM media_transaction WHERE media_id = $1", media.ID); err != nil {
+		return "", err
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", r.tableName)
+	if _, err = tx.Exec(ctx, query, media.ID); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return media.FilePath, nil
+}
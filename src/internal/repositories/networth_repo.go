@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+)
+
+type NetWorthRepository interface {
+	Snapshot(ctx context.Context, day time.Time) (int, error)
+	BackfillMissing(ctx context.Context, day time.Time) (int, error)
+	Backfill(ctx context.Context, day time.Time, userID uuid.UUID) (int, error)
+	History(ctx context.Context, input *dto.NetWorthRequest, userID uuid.UUID) ([]dto.NetWorthBalance, error)
+}
+
+type netWorthRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewNetWorthRepository(db *pgxpool.Pool) NetWorthRepository {
+	return &netWorthRepository{db: db, tableName: "account_balance_snapshots"}
+}
+
+// balanceHistoryQuery rebuilds the snapshots of the accounts matching
+// accountCondition, from the day each account was created or got its first
+// transaction up to $1. The balance of a day is the current balance minus
+// every transaction recorded after it. $2 is the update time, the condition
+// arguments start at $3.
+func balanceHistoryQuery(accountCondition string) string {
+	return fmt.Sprintf(`
+        WITH selected AS (
+            SELECT a.id, a.balance, a.creation_date::DATE AS creation_day
+            FROM accounts a
+            WHERE %s
+        ),
+        daily AS (
+            SELECT t.account_id, t.transaction_date::DATE AS day, SUM(t.amount) AS amount
+            FROM transactions t
+            JOIN selected s
+                ON s.id = t.account_id
+            GROUP BY 1, 2
+        ),
+        bounds AS (
+            SELECT s.id, s.balance, LEAST(s.creation_day, MIN(d.day)) AS first_day, GREATEST($1::DATE, MAX(d.day)) AS last_day
+            FROM selected s
+            LEFT JOIN daily d
+                ON d.account_id = s.id
+            GROUP BY s.id, s.balance, s.creation_day
+        ),
+        history AS (
+            SELECT
+                b.id AS account_id,
+                p.day::DATE AS day,
+                b.balance - COALESCE(SUM(d.amount) OVER (
+                    PARTITION BY b.id
+                    ORDER BY p.day DESC
+                    ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+                ), 0) AS balance
+            FROM bounds b
+            CROSS JOIN LATERAL GENERATE_SERIES(b.first_day, b.last_day, INTERVAL '1 day') AS p(day)
+            LEFT JOIN daily d
+                ON d.account_id = b.id AND d.day = p.day::DATE
+        )
+        INSERT INTO account_balance_snapshots (account_id, snapshot_date, balance, creation_date, update_date)
+        SELECT account_id, day, balance, $2, $2
+        FROM history
+        WHERE day <= $1
+        ON CONFLICT (account_id, snapshot_date) DO UPDATE SET balance = EXCLUDED.balance
+    `, accountCondition)
+}
+
+// Snapshot records the balance every account has at the end of day, which is
+// the current balance unless transactions were recorded after it.
+func (r *netWorthRepository) Snapshot(ctx context.Context, day time.Time) (int, error) {
+	query := fmt.Sprintf(`
+        INSERT INTO %s (account_id, snapshot_date, balance, creation_date, update_date)
+        SELECT
+            a.id,
+            $1::DATE,
+            a.balance - COALESCE((
+                SELECT SUM(t.amount)
+                FROM transactions t
+                WHERE t.account_id = a.id AND t.transaction_date >= $1::DATE + 1
+            ), 0),
+            $2,
+            $2
+        FROM accounts a
+        ON CONFLICT (account_id, snapshot_date) DO UPDATE SET balance = EXCLUDED.balance
+    `, r.tableName)
+	result, err := r.db.Exec(ctx, query, day, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// BackfillMissing rebuilds the history of the accounts without any snapshot
+// before day, like the ones created since the last run.
+func (r *netWorthRepository) BackfillMissing(ctx context.Context, day time.Time) (int, error) {
+	query := balanceHistoryQuery(`NOT EXISTS (
+                SELECT 1
+                FROM account_balance_snapshots bs
+                WHERE bs.account_id = a.id AND bs.snapshot_date < $1
+            )`)
+	result, err := r.db.Exec(ctx, query, day, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// Backfill rebuilds the history of every account the user can see, it is
+// needed after past transactions are edited or deleted.
+func (r *netWorthRepository) Backfill(ctx context.Context, day time.Time, userID uuid.UUID) (int, error) {
+	query := balanceHistoryQuery(fmt.Sprintf("a.id IN (%s)", fmt.Sprintf(accessibleAccountsQuery, 3)))
+	result, err := r.db.Exec(ctx, query, day, time.Now().UTC().Truncate(time.Second), userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// History reads the last snapshot of each account the user can see on every
+// point of the range, grouped by currency, account type and category.
+func (r *netWorthRepository) History(ctx context.Context, input *dto.NetWorthRequest, userID uuid.UUID) ([]dto.NetWorthBalance, error) {
+	query := fmt.Sprintf(`
+        SELECT p.day, a.currency, COALESCE(a.type, 'self') AS account_type, a.category_id, c.name, SUM(s.balance)
+        FROM (
+            SELECT LEAST((DATE_TRUNC('%[1]s', g) + INTERVAL '1 %[1]s' - INTERVAL '1 day')::DATE, $3::DATE) AS day
+            FROM GENERATE_SERIES(DATE_TRUNC('%[1]s', $2::TIMESTAMP), $3::TIMESTAMP, INTERVAL '1 %[1]s') AS g
+        ) p
+        CROSS JOIN accounts a
+        JOIN categories c
+            ON c.id = a.category_id
+        CROSS JOIN LATERAL (
+            SELECT bs.balance
+            FROM account_balance_snapshots bs
+            WHERE bs.account_id = a.id AND bs.snapshot_date <= p.day
+            ORDER BY bs.snapshot_date DESC
+            LIMIT 1
+        ) s
+        WHERE a.id IN (%[2]s)
+        GROUP BY p.day, a.currency, account_type, a.category_id, c.name
+        ORDER BY p.day, a.currency, a.category_id
+    `, reportPeriodUnits[input.Interval], fmt.Sprintf(accessibleAccountsQuery, 1))
+	rows, _ := r.db.Query(ctx, query, userID, input.From, input.To)
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.NetWorthBalance, error) {
+		var item dto.NetWorthBalance
+		err := row.Scan(&item.Date, &item.Currency, &item.AccountType, &item.CategoryID, &item.CategoryName, &item.Balance)
+		return item, err
+	})
+}
@@ -0,0 +1,331 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type PurchaseListItemRepository interface {
+	List(ctx context.Context, transactionID int, userID uuid.UUID) (*dto.PurchaseListResponse, error)
+	GetByID(ctx context.Context, id, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error)
+	Create(ctx context.Context, purchaseListItem *models.PurchaseListItem) error
+	Update(ctx context.Context, purchaseListItem *models.PurchaseListItem) error
+	Delete(ctx context.Context, id, transactionID int, userID uuid.UUID) error
+}
+
+type purchaseListItemRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewPurchaseListItemRepository(db *pgxpool.Pool) PurchaseListItemRepository {
+	return &purchaseListItemRepository{db: db, tableName: "purchase_list_items"}
+}
+
+const purchaseListItemJoinedSelect = `
+        SELECT
+            p.id,
+            p.user_id,
+            p.transaction_id,
+            i.id,
+            i.name,
+            im.url,
+            c.id,
+            c.name,
+            cm.url,
+            p.count,
+            p.unit_price,
+            p.count * p.unit_price,
+            p.creation_date,
+            p.update_date
+        FROM purchase_list_items p
+        JOIN transactions t
+            ON t.id = p.transaction_id
+        JOIN items i
+            ON i.id = p.item_id
+        LEFT JOIN media im
+            ON im.id = i.image_id
+        JOIN categories c
+            ON c.id = i.category_id
+        LEFT JOIN media cm
+            ON cm.id = c.icon_id
+`
+
+func scanPurchaseListItemJoined(row pgx.Row, item *dto.PurchaseListItemJoinedResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.TransactionID,
+		&item.ItemID,
+		&item.ItemName,
+		&item.ItemImageURL,
+		&item.CategoryID,
+		&item.CategoryName,
+		&item.CategoryIconURL,
+		&item.Count,
+		&item.UnitPrice,
+		&item.Total,
+		&item.CreationDate,
+		&item.UpdateDate,
+	)
+}
+
+// lockExpense locks the parent expense for the rest of tx so concurrent line
+// changes are applied one after another and the amount sync never races.
+// Changing lines needs 'edit' on the expense account, the lines of voided
+// expenses are kept as they were.
+func lockExpense(ctx context.Context, tx pgx.Tx, transactionID int, userID uuid.UUID) error {
+	query := `
+        SELECT account_id, status
+        FROM transactions
+        WHERE id = $1 AND has_account_access(account_id, $2, 'view') AND transaction_type = 'expense'
+        FOR UPDATE
+    `
+	var accountID int
+	var status enums.TransactionStatus
+	if err := tx.QueryRow(ctx, query, transactionID, userID).Scan(&accountID, &status); err != nil {
+		return err
+	}
+	if status != enums.TransactionPosted {
+		return &server_errors.TransactionNotPosted
+	}
+	return requireAccountAccess(ctx, tx, accountID, userID, enums.AccessEdit)
+}
+
+// syncExpenseAmount sets the expense amount to the negated sum of its lines
+// and moves the difference into the account balance. An expense without lines
+// keeps its last amount, so removing every line does not zero it.
+func syncExpenseAmount(ctx context.Context, tx pgx.Tx, transactionID int, userID uuid.UUID) error {
+	sumQuery := `
+        SELECT t.account_id, t.amount, COALESCE(SUM(p.count * p.unit_price), 0), COUNT(p.id)
+        FROM transactions t
+        LEFT JOIN purchase_list_items p
+            ON p.transaction_id = t.id
+        WHERE t.id = $1
+        GROUP BY t.id
+    `
+	var accountID, lineCount int
+	var currentAmount, total models.Money
+	if err := tx.QueryRow(ctx, sumQuery, transactionID).Scan(&accountID, &currentAmount, &total, &lineCount); err != nil {
+		return err
+	}
+
+	newAmount := -total
+	if lineCount == 0 || newAmount == currentAmount {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE transactions SET amount = $1 WHERE id = $2", newAmount, transactionID); err != nil {
+		return err
+	}
+	return changeAccountBalance(ctx, tx, accountID, newAmount-currentAmount, userID)
+}
+
+// checkItemOwner makes sure the item exists and is visible to the user, either
+// personal or shared through one of their financial groups. Items of other
+// users must never be linked to an expense.
+func checkItemOwner(ctx context.Context, tx pgx.Tx, itemID int, userID uuid.UUID) error {
+	var id int
+	query := fmt.Sprintf("SELECT i.id FROM items i WHERE i.id = $1 AND %s", visibleScopeCondition("i", 2))
+	return tx.QueryRow(ctx, query, itemID, userID).Scan(&id)
+}
+
+func (r *purchaseListItemRepository) List(ctx context.Context, transactionID int, userID uuid.UUID) (*dto.PurchaseListResponse, error) {
+	var result dto.PurchaseListResponse
+	amountQuery := `
+        SELECT id, amount
+        FROM transactions
+        WHERE id = $1 AND has_account_access(account_id, $2, 'view') AND transaction_type = 'expense'
+    `
+	if err := r.db.QueryRow(ctx, amountQuery, transactionID, userID).Scan(&result.TransactionID, &result.Amount); err != nil {
+		return nil, err
+	}
+	// Expenses are stored negative, lines are listed with what was paid
+	result.Amount = -result.Amount
+
+	query := purchaseListItemJoinedSelect + `
+        WHERE p.transaction_id = $1
+        ORDER BY p.id
+    `
+	rows, err := r.db.Query(ctx, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items = make([]dto.PurchaseListItemJoinedResponse, 0)
+	for rows.Next() {
+		var item dto.PurchaseListItemJoinedResponse
+		if err := scanPurchaseListItemJoined(rows, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	result.Items = &items
+	return &result, nil
+}
+
+func (r *purchaseListItemRepository) GetByID(ctx context.Context, id, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error) {
+	query := purchaseListItemJoinedSelect + `
+        WHERE p.id = $1 AND p.transaction_id = $2 AND has_account_access(t.account_id, $3, 'view')
+    `
+
+	var item dto.PurchaseListItemJoinedResponse
+	err := scanPurchaseListItemJoined(r.db.QueryRow(ctx, query, id, transactionID, userID), &item)
+	return &item, err
+}
+
+func (r *purchaseListItemRepository) Create(ctx context.Context, purchaseListItem *models.PurchaseListItem) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = lockExpense(ctx, tx, purchaseListItem.TransactionID, purchaseListItem.UserID); err != nil {
+		return err
+	}
+	if err = checkItemOwner(ctx, tx, *purchaseListItem.ItemID, purchaseListItem.UserID); err != nil {
+		return err
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, item_id, count, unit_price, transaction_id, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	purchaseListItem.CreationDate = currentTime
+	purchaseListItem.UpdateDate = currentTime
+	if err = tx.QueryRow(
+		ctx,
+		query,
+		purchaseListItem.UserID,
+		purchaseListItem.ItemID,
+		purchaseListItem.Count,
+		purchaseListItem.UnitPrice,
+		purchaseListItem.TransactionID,
+		currentTime,
+	).Scan(&purchaseListItem.ID); err != nil {
+		return err
+	}
+
+	if err = syncExpenseAmount(ctx, tx, purchaseListItem.TransactionID, purchaseListItem.UserID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+func (r *purchaseListItemRepository) Update(ctx context.Context, purchaseListItem *models.PurchaseListItem) (err error) {
+	var setClauses []string
+	var args []interface{}
+	argIndex := 1
+
+	if purchaseListItem.ItemID != nil {
+		setClauses = append(setClauses, fmt.Sprintf("item_id = $%d", argIndex))
+		args = append(args, purchaseListItem.ItemID)
+		argIndex++
+	}
+
+	if purchaseListItem.Count != nil {
+		setClauses = append(setClauses, fmt.Sprintf("count = $%d", argIndex))
+		args = append(args, purchaseListItem.Count)
+		argIndex++
+	}
+
+	if purchaseListItem.UnitPrice != nil {
+		setClauses = append(setClauses, fmt.Sprintf("unit_price = $%d", argIndex))
+		args = append(args, purchaseListItem.UnitPrice)
+		argIndex++
+	}
+
+	if len(setClauses) == 0 {
+		return &server_errors.EmptyUpdate
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = lockExpense(ctx, tx, purchaseListItem.TransactionID, purchaseListItem.UserID); err != nil {
+		return err
+	}
+	if purchaseListItem.ItemID != nil {
+		if err = checkItemOwner(ctx, tx, *purchaseListItem.ItemID, purchaseListItem.UserID); err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE id = $%d AND transaction_id = $%d RETURNING id",
+		r.tableName,
+		strings.Join(setClauses, ", "),
+		argIndex,
+		argIndex+1,
+	)
+	args = append(args, purchaseListItem.ID, purchaseListItem.TransactionID)
+	if err = tx.QueryRow(ctx, query, args...).Scan(&purchaseListItem.ID); err != nil {
+		return err
+	}
+
+	if err = syncExpenseAmount(ctx, tx, purchaseListItem.TransactionID, purchaseListItem.UserID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+func (r *purchaseListItemRepository) Delete(ctx context.Context, id, transactionID int, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = lockExpense(ctx, tx, transactionID, userID); err != nil {
+		return err
+	}
+
+	queryFormat := "DELETE FROM %s WHERE id = $1 AND transaction_id = $2 RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var deletedID int
+	if err = tx.QueryRow(ctx, query, id, transactionID).Scan(&deletedID); err != nil {
+		return err
+	}
+
+	if err = syncExpenseAmount(ctx, tx, transactionID, userID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// hasPurchaseListItems reports whether the amount of a transaction is derived
+// from its lines and must not be edited directly.
+func hasPurchaseListItems(ctx context.Context, tx pgx.Tx, transactionID int) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM purchase_list_items WHERE transaction_id = $1)", transactionID).Scan(&exists)
+	return exists, err
+}
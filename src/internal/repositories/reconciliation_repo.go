@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+type ReconciliationRepository interface {
+	Create(ctx context.Context, reconciliation *models.AccountReconciliation, adjust bool) error
+	List(ctx context.Context, accountID, limit, offset int, userID uuid.UUID) (*[]models.AccountReconciliation, int, error)
+	RecordDiscrepancies(ctx context.Context, detectionDate time.Time) (int, error)
+	ListDiscrepancies(ctx context.Context, userID uuid.UUID) (*[]dto.BalanceDiscrepancyResponse, error)
+}
+
+type reconciliationRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewReconciliationRepository(db *pgxpool.Pool) ReconciliationRepository {
+	return &reconciliationRepository{db: db, tableName: "account_reconciliations"}
+}
+
+// Create computes the ledger balance of the account at the end of the
+// statement date and records the comparison. With adjust, a non zero
+// difference is recorded as an adjustment transaction at the end of that day.
+func (r *reconciliationRepository) Create(ctx context.Context, reconciliation *models.AccountReconciliation, adjust bool) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	required := enums.AccessView
+	if adjust {
+		required = enums.AccessEdit
+	}
+	if err = requireAccountAccess(ctx, tx, reconciliation.AccountID, reconciliation.UserID, required); err != nil {
+		return err
+	}
+
+	dayEnd := reconciliation.StatementDate.AddDate(0, 0, 1)
+	ledgerQuery := "SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE account_id = $1 AND transaction_date < $2"
+	if err = tx.QueryRow(ctx, ledgerQuery, reconciliation.AccountID, dayEnd).Scan(&reconciliation.LedgerBalance); err != nil {
+		return err
+	}
+	reconciliation.Difference = reconciliation.StatementBalance - reconciliation.LedgerBalance
+
+	if adjust && reconciliation.Difference != 0 {
+		var transactionID int
+		transactionID, err = insertLedgerTransaction(
+			ctx,
+			tx,
+			reconciliation.UserID,
+			reconciliation.AccountID,
+			reconciliation.Difference,
+			enums.TransactionAdjustment,
+			"Reconciliation adjustment",
+			dayEnd.Add(-time.Second),
+		)
+		if err != nil {
+			return err
+		}
+		if err = changeAccountBalance(ctx, tx, reconciliation.AccountID, reconciliation.Difference, reconciliation.UserID); err != nil {
+			return err
+		}
+		reconciliation.AdjustmentTransactionID = &transactionID
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (account_id, user_id, statement_date, statement_balance, ledger_balance, difference, adjustment_transaction_id, creation_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id
+    `
+	reconciliation.CreationDate = time.Now().UTC().Truncate(time.Second)
+	if err = tx.QueryRow(
+		ctx,
+		fmt.Sprintf(queryFormat, r.tableName),
+		reconciliation.AccountID,
+		reconciliation.UserID,
+		reconciliation.StatementDate,
+		reconciliation.StatementBalance,
+		reconciliation.LedgerBalance,
+		reconciliation.Difference,
+		reconciliation.AdjustmentTransactionID,
+		reconciliation.CreationDate,
+	).Scan(&reconciliation.ID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// List returns the reconciliations of the account, latest statement first.
+func (r *reconciliationRepository) List(ctx context.Context, accountID, limit, offset int, userID uuid.UUID) (*[]models.AccountReconciliation, int, error) {
+	if err := requireAccountAccess(ctx, r.db, accountID, userID, enums.AccessView); err != nil {
+		return nil, 0, err
+	}
+
+	var totalCount int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE account_id = $1", r.tableName)
+	if err := r.db.QueryRow(ctx, countQuery, accountID).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT id, account_id, user_id, statement_date, statement_balance, ledger_balance, difference, adjustment_transaction_id, creation_date
+        FROM %s
+        WHERE account_id = $1
+        ORDER BY statement_date DESC, id DESC
+        LIMIT $2 OFFSET $3
+    `, r.tableName)
+	rows, _ := r.db.Query(ctx, query, accountID, limit, offset)
+	reconciliations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.AccountReconciliation, error) {
+		var item models.AccountReconciliation
+		err := row.Scan(
+			&item.ID,
+			&item.AccountID,
+			&item.UserID,
+			&item.StatementDate,
+			&item.StatementBalance,
+			&item.LedgerBalance,
+			&item.Difference,
+			&item.AdjustmentTransactionID,
+			&item.CreationDate,
+		)
+		return item, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &reconciliations, totalCount, nil
+}
+
+// RecordDiscrepancies compares the stored balance of every account with the
+// sum of its transactions. Drifted accounts are recorded, keeping the date
+// they were first detected, and the ones that match again are cleared. It
+// returns how many accounts drifted.
+func (r *reconciliationRepository) RecordDiscrepancies(ctx context.Context, detectionDate time.Time) (int, error) {
+	query := `
+        WITH ledger AS (
+            SELECT a.id, a.balance AS stored_balance, COALESCE(SUM(t.amount), 0) AS ledger_balance
+            FROM accounts a
+            LEFT JOIN transactions t
+                ON t.account_id = a.id
+            GROUP BY a.id, a.balance
+        ),
+        resolved AS (
+            DELETE FROM account_balance_discrepancies d
+            USING ledger l
+            WHERE d.account_id = l.id AND l.stored_balance = l.ledger_balance
+        )
+        INSERT INTO account_balance_discrepancies (account_id, stored_balance, ledger_balance, detection_date, creation_date, update_date)
+        SELECT id, stored_balance, ledger_balance, $1, $1, $1
+        FROM ledger
+        WHERE stored_balance <> ledger_balance
+        ON CONFLICT (account_id) DO UPDATE
+        SET stored_balance = EXCLUDED.stored_balance, ledger_balance = EXCLUDED.ledger_balance
+    `
+	result, err := r.db.Exec(ctx, query, detectionDate)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *reconciliationRepository) ListDiscrepancies(ctx context.Context, userID uuid.UUID) (*[]dto.BalanceDiscrepancyResponse, error) {
+	query := fmt.Sprintf(`
+        SELECT d.account_id, a.name, a.currency, d.stored_balance, d.ledger_balance, d.stored_balance - d.ledger_balance, d.detection_date
+        FROM account_balance_discrepancies d
+        JOIN accounts a
+            ON a.id = d.account_id
+        WHERE d.account_id IN (%s)
+        ORDER BY d.detection_date, d.account_id
+    `, fmt.Sprintf(accessibleAccountsQuery, 1))
+	rows, _ := r.db.Query(ctx, query, userID)
+	discrepancies, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.BalanceDiscrepancyResponse, error) {
+		var item dto.BalanceDiscrepancyResponse
+		err := row.Scan(&item.AccountID, &item.AccountName, &item.Currency, &item.StoredBalance, &item.LedgerBalance, &item.Difference, &item.DetectionDate)
+		return item, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &discrepancies, nil
+}
@@ -0,0 +1,618 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type RecurringTransactionRepository interface {
+	Create(ctx context.Context, rule *models.RecurringTransaction) error
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.RecurringTransactionResponse, error)
+	List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.RecurringTransactionResponse, int, error)
+	Update(ctx context.Context, id int, userID uuid.UUID, input *dto.RecurringTransactionUpdateRequest, today time.Time) error
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	SetPaused(ctx context.Context, id int, userID uuid.UUID, paused bool, today time.Time) error
+	ListOverrides(ctx context.Context, id int, userID uuid.UUID) (*[]models.RecurringTransactionOverride, error)
+	SetOverride(ctx context.Context, override *models.RecurringTransactionOverride, userID uuid.UUID) error
+	DeleteOverride(ctx context.Context, id int, occurrenceDate time.Time, userID uuid.UUID) error
+	ListDue(ctx context.Context, today time.Time) ([]int, error)
+	Materialize(ctx context.Context, id int, today time.Time) (int, error)
+}
+
+type recurringTransactionRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewRecurringTransactionRepository(db *pgxpool.Pool) RecurringTransactionRepository {
+	return &recurringTransactionRepository{db: db, tableName: "recurring_transactions"}
+}
+
+const recurringTransactionColumns = `
+            rt.id,
+            rt.user_id,
+            rt.account_id,
+            rt.category_id,
+            rt.transaction_type,
+            rt.amount,
+            rt.description,
+            rt.frequency,
+            rt.repeat_interval,
+            rt.start_date,
+            rt.end_date,
+            rt.paused,
+            rt.occurrence_index,
+            rt.next_occurrence,
+            rt.creation_date,
+            rt.update_date
+`
+
+const recurringTransactionJoinedSelect = `
+        SELECT` + recurringTransactionColumns + `,
+            a.name,
+            c.name
+        FROM recurring_transactions rt
+        JOIN accounts a
+            ON a.id = rt.account_id
+        JOIN categories c
+            ON c.id = rt.category_id
+`
+
+func recurringTransactionFields(rule *models.RecurringTransaction) []any {
+	return []any{
+		&rule.ID,
+		&rule.UserID,
+		&rule.AccountID,
+		&rule.CategoryID,
+		&rule.TransactionType,
+		&rule.Amount,
+		&rule.Description,
+		&rule.Frequency,
+		&rule.Interval,
+		&rule.StartDate,
+		&rule.EndDate,
+		&rule.Paused,
+		&rule.OccurrenceIndex,
+		&rule.NextOccurrence,
+		&rule.CreationDate,
+		&rule.UpdateDate,
+	}
+}
+
+func scanRecurringTransactionJoined(row pgx.Row, item *dto.RecurringTransactionResponse) error {
+	fields := append(recurringTransactionFields(&item.RecurringTransaction), &item.AccountName, &item.CategoryName)
+	return row.Scan(fields...)
+}
+
+// checkRecurringTransactionReferences makes sure the user can record
+// transactions on the account and that the category is visible to them and of
+// the same type as the rule.
+func checkRecurringTransactionReferences(ctx context.Context, q queryRower, rule *models.RecurringTransaction) error {
+	if err := requireAccountAccess(ctx, q, rule.AccountID, rule.UserID, enums.AccessEdit); err != nil {
+		return err
+	}
+
+	categoryQuery := fmt.Sprintf(
+		"SELECT c.entity_type::TEXT = $3 FROM categories c WHERE c.id = $1 AND %s",
+		visibleScopeCondition("c", 2),
+	)
+	var matches bool
+	if err := q.QueryRow(ctx, categoryQuery, rule.CategoryID, rule.UserID, rule.TransactionType).Scan(&matches); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &server_errors.CategoryNotFound
+		}
+		return err
+	}
+	if !matches {
+		return &server_errors.RecurringCategoryMismatch
+	}
+	return nil
+}
+
+// scheduleFrom points the rule at its first occurrence on or after t, counting
+// from index from, or marks it as finished when it ends before.
+func scheduleFrom(rule *models.RecurringTransaction, from int, t time.Time) {
+	index, date, ok := rule.OccurrenceOnOrAfter(from, t)
+	rule.OccurrenceIndex = index
+	rule.NextOccurrence = nil
+	if ok {
+		rule.NextOccurrence = &date
+	}
+}
+
+// Create saves the rule, its first occurrence is the first one on or after
+// today so a start date in the past does not create transactions for it.
+func (r *recurringTransactionRepository) Create(ctx context.Context, rule *models.RecurringTransaction) error {
+	if err := checkRecurringTransactionReferences(ctx, r.db, rule); err != nil {
+		return err
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, account_id, category_id, transaction_type, amount, description, frequency, repeat_interval, start_date, end_date, occurrence_index, next_occurrence, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
+        RETURNING id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	rule.CreationDate = currentTime
+	rule.UpdateDate = currentTime
+	scheduleFrom(rule, 0, currentTime)
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		rule.UserID,
+		rule.AccountID,
+		rule.CategoryID,
+		rule.TransactionType,
+		rule.Amount,
+		rule.Description,
+		rule.Frequency,
+		rule.Interval,
+		rule.StartDate,
+		rule.EndDate,
+		rule.OccurrenceIndex,
+		rule.NextOccurrence,
+		currentTime,
+	).Scan(&rule.ID)
+	return err
+}
+
+func (r *recurringTransactionRepository) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.RecurringTransactionResponse, error) {
+	query := recurringTransactionJoinedSelect + `
+        WHERE rt.id = $1 AND rt.user_id = $2
+    `
+	var item dto.RecurringTransactionResponse
+	err := scanRecurringTransactionJoined(r.db.QueryRow(ctx, query, id, userID), &item)
+	return &item, err
+}
+
+func (r *recurringTransactionRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.RecurringTransactionResponse, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := recurringTransactionJoinedSelect + `
+        WHERE rt.user_id = $1
+        ORDER BY rt.id
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var rules = make([]dto.RecurringTransactionResponse, 0, limit)
+	for rows.Next() {
+		var item dto.RecurringTransactionResponse
+		if err := scanRecurringTransactionJoined(rows, &item); err != nil {
+			return nil, 0, err
+		}
+		rules = append(rules, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &rules, totalCount, nil
+}
+
+// Update changes the rule for the occurrences that were not materialized yet.
+// Changing the frequency, interval or start date restarts the schedule from
+// today, occurrences already created are never repeated thanks to the
+// transactions_recurring_occurrence_key index.
+func (r *recurringTransactionRepository) Update(ctx context.Context, id int, userID uuid.UUID, input *dto.RecurringTransactionUpdateRequest, today time.Time) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	rule, err := r.lock(ctx, tx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	if input.AccountID != nil {
+		rule.AccountID = *input.AccountID
+		updated = true
+	}
+	if input.CategoryID != nil {
+		rule.CategoryID = *input.CategoryID
+		updated = true
+	}
+	if input.Amount != nil {
+		rule.Amount = *input.Amount
+		updated = true
+	}
+	if input.Description != nil {
+		rule.Description = input.Description
+		updated = true
+	}
+	if input.EndDate != nil {
+		rule.EndDate = input.EndDate
+		updated = true
+	}
+
+	scheduleChanged := false
+	if input.Frequency != nil {
+		rule.Frequency = *input.Frequency
+		scheduleChanged = true
+	}
+	if input.Interval != nil {
+		rule.Interval = *input.Interval
+		scheduleChanged = true
+	}
+	if input.StartDate != nil {
+		rule.StartDate = *input.StartDate
+		scheduleChanged = true
+	}
+
+	if !updated && !scheduleChanged {
+		return &server_errors.EmptyUpdate
+	}
+
+	if rule.EndDate != nil && rule.EndDate.Before(rule.StartDate) {
+		return &server_errors.InvalidRecurrence
+	}
+
+	if input.AccountID != nil || input.CategoryID != nil {
+		if err = checkRecurringTransactionReferences(ctx, tx, rule); err != nil {
+			return err
+		}
+	}
+
+	if scheduleChanged {
+		scheduleFrom(rule, 0, today)
+	} else {
+		scheduleFrom(rule, rule.OccurrenceIndex, rule.Occurrence(rule.OccurrenceIndex))
+	}
+
+	queryFormat := `
+        UPDATE %s
+        SET
+            account_id = $1,
+            category_id = $2,
+            amount = $3,
+            description = $4,
+            frequency = $5,
+            repeat_interval = $6,
+            start_date = $7,
+            end_date = $8,
+            occurrence_index = $9,
+            next_occurrence = $10
+        WHERE id = $11
+    `
+	if _, err = tx.Exec(
+		ctx,
+		fmt.Sprintf(queryFormat, r.tableName),
+		rule.AccountID,
+		rule.CategoryID,
+		rule.Amount,
+		rule.Description,
+		rule.Frequency,
+		rule.Interval,
+		rule.StartDate,
+		rule.EndDate,
+		rule.OccurrenceIndex,
+		rule.NextOccurrence,
+		rule.ID,
+	); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// Delete removes the rule, the transactions it already created are kept.
+func (r *recurringTransactionRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	queryFormat := "DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
+	return err
+}
+
+// SetPaused pauses or resumes the rule. Occurrences that fell due while it was
+// paused are not created, a resumed rule continues from today.
+func (r *recurringTransactionRepository) SetPaused(ctx context.Context, id int, userID uuid.UUID, paused bool, today time.Time) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	rule, err := r.lock(ctx, tx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if rule.Paused != paused {
+		if !paused {
+			scheduleFrom(rule, rule.OccurrenceIndex, today)
+		}
+
+		queryFormat := "UPDATE %s SET paused = $1, occurrence_index = $2, next_occurrence = $3 WHERE id = $4"
+		if _, err = tx.Exec(ctx, fmt.Sprintf(queryFormat, r.tableName), paused, rule.OccurrenceIndex, rule.NextOccurrence, rule.ID); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// ListOverrides returns the overrides of the occurrences that were not
+// materialized yet.
+func (r *recurringTransactionRepository) ListOverrides(ctx context.Context, id int, userID uuid.UUID) (*[]models.RecurringTransactionOverride, error) {
+	query := `
+        SELECT o.id, o.recurring_transaction_id, o.occurrence_date, o.skip, o.amount, o.description, o.creation_date, o.update_date
+        FROM recurring_transaction_overrides o
+        JOIN recurring_transactions rt
+            ON rt.id = o.recurring_transaction_id
+        WHERE rt.id = $1 AND rt.user_id = $2 AND o.occurrence_date >= rt.next_occurrence
+        ORDER BY o.occurrence_date
+    `
+	return r.listOverrides(ctx, r.db, query, id, userID)
+}
+
+// SetOverride creates or replaces the override of an upcoming occurrence, it
+// fails with OccurrenceNotUpcoming when the date is not one.
+func (r *recurringTransactionRepository) SetOverride(ctx context.Context, override *models.RecurringTransactionOverride, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	rule, err := r.lock(ctx, tx, override.RecurringTransactionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if rule.NextOccurrence == nil {
+		return &server_errors.OccurrenceNotUpcoming
+	}
+	_, date, ok := rule.OccurrenceOnOrAfter(rule.OccurrenceIndex, override.OccurrenceDate)
+	if !ok || !date.Equal(override.OccurrenceDate) {
+		return &server_errors.OccurrenceNotUpcoming
+	}
+
+	query := `
+        INSERT INTO recurring_transaction_overrides
+        (recurring_transaction_id, occurrence_date, skip, amount, description, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        ON CONFLICT ON CONSTRAINT recurring_transaction_overrides_occurrence_key
+        DO UPDATE SET skip = EXCLUDED.skip, amount = EXCLUDED.amount, description = EXCLUDED.description
+        RETURNING id, creation_date, update_date
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	if err = tx.QueryRow(
+		ctx,
+		query,
+		override.RecurringTransactionID,
+		override.OccurrenceDate,
+		override.Skip,
+		override.Amount,
+		override.Description,
+		currentTime,
+	).Scan(&override.ID, &override.CreationDate, &override.UpdateDate); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+func (r *recurringTransactionRepository) DeleteOverride(ctx context.Context, id int, occurrenceDate time.Time, userID uuid.UUID) error {
+	query := `
+        DELETE FROM recurring_transaction_overrides o
+        USING recurring_transactions rt
+        WHERE rt.id = o.recurring_transaction_id AND rt.id = $1 AND rt.user_id = $2 AND o.occurrence_date = $3
+        RETURNING o.id
+    `
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, id, userID, occurrenceDate).Scan(&deletedID)
+	return err
+}
+
+// ListDue returns the ids of the running rules that have occurrences on or
+// before today waiting to be materialized.
+func (r *recurringTransactionRepository) ListDue(ctx context.Context, today time.Time) ([]int, error) {
+	queryFormat := "SELECT id FROM %s WHERE NOT paused AND next_occurrence <= $1 ORDER BY next_occurrence, id"
+	rows, err := r.db.Query(ctx, fmt.Sprintf(queryFormat, r.tableName), today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Materialize creates the transactions of every due occurrence of the rule up
+// to today and moves it to the next one, returning how many were created. The
+// rule row is locked with SKIP LOCKED so concurrent workers leave it to the one
+// that got it first, and an occurrence already in transactions is never
+// inserted or applied to the account balance again.
+func (r *recurringTransactionRepository) Materialize(ctx context.Context, id int, today time.Time) (created int, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	var rule models.RecurringTransaction
+	queryFormat := `
+        SELECT` + recurringTransactionColumns + `
+        FROM %s rt
+        WHERE rt.id = $1 AND NOT rt.paused AND rt.next_occurrence <= $2
+        FOR UPDATE SKIP LOCKED
+    `
+	if err = tx.QueryRow(ctx, fmt.Sprintf(queryFormat, r.tableName), id, today).Scan(recurringTransactionFields(&rule)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Handled by another worker or no longer due
+			err = tx.Rollback(ctx)
+			return 0, err
+		}
+		return 0, err
+	}
+
+	overridesQuery := `
+        SELECT id, recurring_transaction_id, occurrence_date, skip, amount, description, creation_date, update_date
+        FROM recurring_transaction_overrides
+        WHERE recurring_transaction_id = $1 AND occurrence_date BETWEEN $2 AND $3
+    `
+	overrideList, err := r.listOverrides(ctx, tx, overridesQuery, rule.ID, rule.NextOccurrence, today)
+	if err != nil {
+		return 0, err
+	}
+	overrides := make(map[string]models.RecurringTransactionOverride, len(*overrideList))
+	for _, override := range *overrideList {
+		overrides[override.OccurrenceDate.Format(time.DateOnly)] = override
+	}
+
+	insertQuery := `
+        INSERT INTO transactions
+        (user_id, account_id, category_id, amount, description, transaction_type, transaction_date, recurring_transaction_id, recurring_occurrence_date, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7, $9, $9)
+        ON CONFLICT (recurring_transaction_id, recurring_occurrence_date) DO NOTHING
+        RETURNING id
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+
+	index := rule.OccurrenceIndex
+	date := rule.Occurrence(index)
+	for !date.After(today) && !rule.EndsBefore(date) {
+		override, found := overrides[date.Format(time.DateOnly)]
+		if !found || !override.Skip {
+			amount := rule.Amount
+			description := rule.Description
+			if found && override.Amount != nil {
+				amount = *override.Amount
+			}
+			if found && override.Description != nil {
+				description = override.Description
+			}
+			// Expenses are stored as negative amounts, see signedAmount
+			if rule.TransactionType == enums.TransactionExpense {
+				amount = -amount
+			}
+
+			var transactionID int
+			err = tx.QueryRow(
+				ctx,
+				insertQuery,
+				rule.UserID,
+				rule.AccountID,
+				rule.CategoryID,
+				amount,
+				description,
+				rule.TransactionType,
+				date,
+				rule.ID,
+				currentTime,
+			).Scan(&transactionID)
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				err = nil
+			case err != nil:
+				return 0, err
+			default:
+				if err = changeAccountBalance(ctx, tx, rule.AccountID, amount, rule.UserID); err != nil {
+					return 0, err
+				}
+				created++
+			}
+		}
+
+		index++
+		date = rule.Occurrence(index)
+	}
+
+	var nextOccurrence *time.Time
+	if !rule.EndsBefore(date) {
+		nextOccurrence = &date
+	}
+	updateFormat := "UPDATE %s SET occurrence_index = $1, next_occurrence = $2 WHERE id = $3"
+	if _, err = tx.Exec(ctx, fmt.Sprintf(updateFormat, r.tableName), index, nextOccurrence, rule.ID); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+// lock returns the rule of the user and locks it until tx ends.
+func (r *recurringTransactionRepository) lock(ctx context.Context, tx pgx.Tx, id int, userID uuid.UUID) (*models.RecurringTransaction, error) {
+	queryFormat := `
+        SELECT` + recurringTransactionColumns + `
+        FROM %s rt
+        WHERE rt.id = $1 AND rt.user_id = $2
+        FOR UPDATE
+    `
+	var rule models.RecurringTransaction
+	if err := tx.QueryRow(ctx, fmt.Sprintf(queryFormat, r.tableName), id, userID).Scan(recurringTransactionFields(&rule)...); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *recurringTransactionRepository) listOverrides(ctx context.Context, q querier, query string, args ...any) (*[]models.RecurringTransactionOverride, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides = make([]models.RecurringTransactionOverride, 0)
+	for rows.Next() {
+		var override models.RecurringTransactionOverride
+		if err := rows.Scan(
+			&override.ID,
+			&override.RecurringTransactionID,
+			&override.OccurrenceDate,
+			&override.Skip,
+			&override.Amount,
+			&override.Description,
+			&override.CreationDate,
+			&override.UpdateDate,
+		); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &overrides, nil
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+)
+
+type ReportRepository interface {
+	Summary(ctx context.Context, input *dto.ReportRequest, userID uuid.UUID) ([]dto.ReportTotals, error)
+	Breakdown(ctx context.Context, input *dto.ReportBreakdownRequest, userID uuid.UUID) ([]dto.ReportBreakdownRow, error)
+	Top(ctx context.Context, input *dto.ReportTopRequest, userID uuid.UUID) ([]dto.ReportTopRow, error)
+}
+
+type reportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepository(db *pgxpool.Pool) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+// reportTotalsColumns aggregates the transactions of a report, they are only
+// incomes and expenses so their sum is the net.
+const reportTotalsColumns = `
+            COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'income'), 0) AS income,
+            COALESCE(-SUM(t.amount) FILTER (WHERE t.transaction_type = 'expense'), 0) AS expense,
+            COALESCE(SUM(t.amount), 0) AS net,
+            COUNT(t.id) AS count
+`
+
+// reportPeriodUnits are the DATE_TRUNC fields of the time groupings.
+var reportPeriodUnits = map[enums.ReportGroupBy]string{
+	enums.ReportByDay:   "day",
+	enums.ReportByWeek:  "week",
+	enums.ReportByMonth: "month",
+	enums.ReportByYear:  "year",
+}
+
+// reportScope returns the conditions selecting the income and expense
+// transactions of the report with their arguments, the user is $1, the start
+// of the range $2 and its exclusive end $3. Voided transactions and their
+// reversals cancel out and are left out.
+func reportScope(ctx context.Context, q queryRower, input *dto.ReportRequest, userID uuid.UUID) ([]string, []any, error) {
+	conditions := []string{
+		fmt.Sprintf("t.account_id IN (%s)", fmt.Sprintf(accessibleAccountsQuery, 1)),
+		"t.transaction_type IN ('income', 'expense')",
+		"t.status = 'posted'",
+		"t.transaction_date >= $2",
+		"t.transaction_date < $3",
+	}
+	args := []any{userID, input.From, input.To.AddDate(0, 0, 1)}
+
+	if input.AccountID != nil {
+		if err := requireAccountAccess(ctx, q, *input.AccountID, userID, enums.AccessView); err != nil {
+			return nil, nil, err
+		}
+		args = append(args, *input.AccountID)
+		conditions = append(conditions, fmt.Sprintf("t.account_id = $%d", len(args)))
+	}
+	return conditions, args, nil
+}
+
+func scanReportTotals(totals *dto.ReportTotals) []any {
+	return []any{&totals.Currency, &totals.Income, &totals.Expense, &totals.Net, &totals.Count}
+}
+
+func (r *reportRepository) Summary(ctx context.Context, input *dto.ReportRequest, userID uuid.UUID) ([]dto.ReportTotals, error) {
+	conditions, args, err := reportScope(ctx, r.db, input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT a.currency, %s
+        FROM transactions t
+        JOIN accounts a
+            ON a.id = t.account_id
+        WHERE %s
+        GROUP BY a.currency
+        ORDER BY a.currency
+    `, reportTotalsColumns, strings.Join(conditions, " AND "))
+	rows, _ := r.db.Query(ctx, query, args...)
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.ReportTotals, error) {
+		var totals dto.ReportTotals
+		err := row.Scan(scanReportTotals(&totals)...)
+		return totals, err
+	})
+}
+
+// Breakdown groups the report by category, account or period. Categories and
+// accounts are ordered by their total activity, periods chronologically for
+// each currency.
+func (r *reportRepository) Breakdown(ctx context.Context, input *dto.ReportBreakdownRequest, userID uuid.UUID) ([]dto.ReportBreakdownRow, error) {
+	conditions, args, err := reportScope(ctx, r.db, &input.ReportRequest, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Type != nil {
+		args = append(args, *input.Type)
+		conditions = append(conditions, fmt.Sprintf("t.transaction_type = $%d", len(args)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var query string
+	switch input.GroupBy {
+	case enums.ReportByCategory:
+		query = fmt.Sprintf(`
+        SELECT t.category_id, c.name, NULL::TIMESTAMP, a.currency, %s
+        FROM transactions t
+        JOIN accounts a
+            ON a.id = t.account_id
+        LEFT JOIN categories c
+            ON c.id = t.category_id
+        WHERE %s
+        GROUP BY t.category_id, c.name, a.currency
+        ORDER BY SUM(ABS(t.amount)) DESC, a.currency, t.category_id
+    `, reportTotalsColumns, where)
+	case enums.ReportByAccount:
+		query = fmt.Sprintf(`
+        SELECT a.id, a.name, NULL::TIMESTAMP, a.currency, %s
+        FROM transactions t
+        JOIN accounts a
+            ON a.id = t.account_id
+        WHERE %s
+        GROUP BY a.id, a.name, a.currency
+        ORDER BY SUM(ABS(t.amount)) DESC, a.id
+    `, reportTotalsColumns, where)
+	default:
+		unit := reportPeriodUnits[input.GroupBy]
+		query = fmt.Sprintf(`
+        WITH totals AS (
+            SELECT DATE_TRUNC('%[1]s', t.transaction_date) AS period_start, a.currency, %[2]s
+            FROM transactions t
+            JOIN accounts a
+                ON a.id = t.account_id
+            WHERE %[3]s
+            GROUP BY 1, 2
+        )
+        SELECT
+            NULL::INT,
+            NULL::TEXT,
+            p.period_start,
+            cur.currency,
+            COALESCE(tt.income, 0),
+            COALESCE(tt.expense, 0),
+            COALESCE(tt.net, 0),
+            COALESCE(tt.count, 0)
+        FROM GENERATE_SERIES(
+            DATE_TRUNC('%[1]s', $2::TIMESTAMP),
+            DATE_TRUNC('%[1]s', $3::TIMESTAMP - INTERVAL '1 day'),
+            INTERVAL '1 %[1]s'
+        ) AS p(period_start)
+        CROSS JOIN (SELECT DISTINCT currency FROM totals) cur
+        LEFT JOIN totals tt
+            ON tt.period_start = p.period_start AND tt.currency = cur.currency
+        ORDER BY cur.currency, p.period_start
+    `, unit, reportTotalsColumns, where)
+	}
+
+	rows, _ := r.db.Query(ctx, query, args...)
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.ReportBreakdownRow, error) {
+		var item dto.ReportBreakdownRow
+		fields := append([]any{&item.Key, &item.Label, &item.PeriodStart}, scanReportTotals(&item.ReportTotals)...)
+		err := row.Scan(fields...)
+		return item, err
+	})
+}
+
+// Top returns the descriptions the most money went to or came from.
+// Transactions without a description are left out.
+func (r *reportRepository) Top(ctx context.Context, input *dto.ReportTopRequest, userID uuid.UUID) ([]dto.ReportTopRow, error) {
+	conditions, args, err := reportScope(ctx, r.db, &input.ReportRequest, userID)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, input.Type)
+	conditions = append(conditions, fmt.Sprintf("t.transaction_type = $%d", len(args)), "NULLIF(TRIM(t.description), '') IS NOT NULL")
+	args = append(args, input.Limit)
+
+	query := fmt.Sprintf(`
+        SELECT MODE() WITHIN GROUP (ORDER BY TRIM(t.description)), a.currency, ABS(SUM(t.amount)) AS total, COUNT(t.id) AS count
+        FROM transactions t
+        JOIN accounts a
+            ON a.id = t.account_id
+        WHERE %s
+        GROUP BY LOWER(REGEXP_REPLACE(TRIM(t.description), '\s+', ' ', 'g')), a.currency
+        ORDER BY total DESC, count DESC
+        LIMIT $%d
+    `, strings.Join(conditions, " AND "), len(args))
+	rows, _ := r.db.Query(ctx, query, args...)
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.ReportTopRow, error) {
+		var item dto.ReportTopRow
+		err := row.Scan(&item.Description, &item.Currency, &item.Total, &item.Count)
+		return item, err
+	})
+}
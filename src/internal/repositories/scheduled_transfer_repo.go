@@ -0,0 +1,405 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type ScheduledTransferRepository interface {
+	Create(ctx context.Context, transfer *models.ScheduledTransfer) error
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.ScheduledTransferResponse, error)
+	List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.ScheduledTransferResponse, int, error)
+	Cancel(ctx context.Context, id int, userID uuid.UUID) error
+	ListRuns(ctx context.Context, id, limit, offset int, userID uuid.UUID) (*[]models.ScheduledTransferRun, int, error)
+	ListDue(ctx context.Context, today time.Time) ([]int, error)
+	ExecuteDue(ctx context.Context, id int, today time.Time) (int, error)
+}
+
+type scheduledTransferRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewScheduledTransferRepository(db *pgxpool.Pool) ScheduledTransferRepository {
+	return &scheduledTransferRepository{db: db, tableName: "scheduled_transfers"}
+}
+
+const scheduledTransferColumns = `
+            st.id,
+            st.user_id,
+            st.from_account_id,
+            st.dest_account_id,
+            st.amount,
+            TRIM_SCALE(st.exchange_rate)::TEXT,
+            st.frequency,
+            st.repeat_interval,
+            st.start_date,
+            st.end_date,
+            st.status,
+            st.occurrence_index,
+            st.next_run,
+            st.creation_date,
+            st.update_date
+`
+
+const scheduledTransferJoinedSelect = `
+        SELECT` + scheduledTransferColumns + `,
+            fa.name,
+            da.name
+        FROM scheduled_transfers st
+        LEFT JOIN accounts fa
+            ON fa.id = st.from_account_id
+        LEFT JOIN accounts da
+            ON da.id = st.dest_account_id
+`
+
+func scheduledTransferFields(transfer *models.ScheduledTransfer) []any {
+	return []any{
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.FromAccountID,
+		&transfer.DestAccountID,
+		&transfer.Amount,
+		&transfer.Rate,
+		&transfer.Frequency,
+		&transfer.Interval,
+		&transfer.StartDate,
+		&transfer.EndDate,
+		&transfer.Status,
+		&transfer.OccurrenceIndex,
+		&transfer.NextRun,
+		&transfer.CreationDate,
+		&transfer.UpdateDate,
+	}
+}
+
+func scanScheduledTransferJoined(row pgx.Row, item *dto.ScheduledTransferResponse) error {
+	fields := append(scheduledTransferFields(&item.ScheduledTransfer), &item.FromAccountName, &item.DestAccountName)
+	return row.Scan(fields...)
+}
+
+// Create saves the transfer after checking the user can currently move money
+// on both accounts, the access is checked again on every run.
+func (r *scheduledTransferRepository) Create(ctx context.Context, transfer *models.ScheduledTransfer) error {
+	for _, accountID := range []*int{transfer.FromAccountID, transfer.DestAccountID} {
+		if err := requireAccountAccess(ctx, r.db, *accountID, transfer.UserID, enums.AccessEdit); err != nil {
+			return err
+		}
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, from_account_id, dest_account_id, amount, exchange_rate, frequency, repeat_interval, start_date, end_date, status, next_run, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $8, $11, $11)
+        RETURNING id
+    `
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	transfer.Status = enums.ScheduledTransferActive
+	transfer.NextRun = &transfer.StartDate
+	transfer.CreationDate = currentTime
+	transfer.UpdateDate = currentTime
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		transfer.UserID,
+		transfer.FromAccountID,
+		transfer.DestAccountID,
+		transfer.Amount,
+		transfer.Rate,
+		transfer.Frequency,
+		transfer.Interval,
+		transfer.StartDate,
+		transfer.EndDate,
+		transfer.Status,
+		currentTime,
+	).Scan(&transfer.ID)
+	return err
+}
+
+func (r *scheduledTransferRepository) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.ScheduledTransferResponse, error) {
+	query := scheduledTransferJoinedSelect + `
+        WHERE st.id = $1 AND st.user_id = $2
+    `
+	var item dto.ScheduledTransferResponse
+	err := scanScheduledTransferJoined(r.db.QueryRow(ctx, query, id, userID), &item)
+	return &item, err
+}
+
+func (r *scheduledTransferRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.ScheduledTransferResponse, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := scheduledTransferJoinedSelect + `
+        WHERE st.user_id = $1
+        ORDER BY st.id DESC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var transfers = make([]dto.ScheduledTransferResponse, 0, limit)
+	for rows.Next() {
+		var item dto.ScheduledTransferResponse
+		if err := scanScheduledTransferJoined(rows, &item); err != nil {
+			return nil, 0, err
+		}
+		transfers = append(transfers, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &transfers, totalCount, nil
+}
+
+// Cancel stops an active transfer, its past runs are kept.
+func (r *scheduledTransferRepository) Cancel(ctx context.Context, id int, userID uuid.UUID) error {
+	queryFormat := "UPDATE %s SET status = $1, next_run = NULL WHERE id = $2 AND user_id = $3 AND status = 'active' RETURNING id"
+	query := fmt.Sprintf(queryFormat, r.tableName)
+	var cancelledID int
+	err := r.db.QueryRow(ctx, query, enums.ScheduledTransferCancelled, id, userID).Scan(&cancelledID)
+	return err
+}
+
+func (r *scheduledTransferRepository) ListRuns(ctx context.Context, id, limit, offset int, userID uuid.UUID) (*[]models.ScheduledTransferRun, int, error) {
+	countQuery := `
+        SELECT COUNT(*)
+        FROM scheduled_transfer_runs str
+        JOIN scheduled_transfers st
+            ON st.id = str.scheduled_transfer_id
+        WHERE st.id = $1 AND st.user_id = $2
+    `
+	var totalCount int
+	if err := r.db.QueryRow(ctx, countQuery, id, userID).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT str.id, str.scheduled_transfer_id, str.run_date, str.status, str.error_message, str.from_transaction_id, str.dest_transaction_id, str.creation_date
+        FROM scheduled_transfer_runs str
+        JOIN scheduled_transfers st
+            ON st.id = str.scheduled_transfer_id
+        WHERE st.id = $1 AND st.user_id = $2
+        ORDER BY str.run_date DESC
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := r.db.Query(ctx, query, id, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var runs = make([]models.ScheduledTransferRun, 0, limit)
+	for rows.Next() {
+		var run models.ScheduledTransferRun
+		if err := rows.Scan(
+			&run.ID,
+			&run.ScheduledTransferID,
+			&run.RunDate,
+			&run.Status,
+			&run.ErrorMessage,
+			&run.FromTransactionID,
+			&run.DestTransactionID,
+			&run.CreationDate,
+		); err != nil {
+			return nil, 0, err
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &runs, totalCount, nil
+}
+
+// ListDue returns the ids of the active transfers with a run on or before
+// today.
+func (r *scheduledTransferRepository) ListDue(ctx context.Context, today time.Time) ([]int, error) {
+	queryFormat := "SELECT id FROM %s WHERE status = 'active' AND next_run <= $1 ORDER BY next_run, id"
+	rows, err := r.db.Query(ctx, fmt.Sprintf(queryFormat, r.tableName), today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ExecuteDue runs every due occurrence of the transfer up to today and returns
+// how many runs were recorded. Each transfer happens in a savepoint of the
+// same database transaction that records its run and moves the schedule
+// forward, so an occurrence is executed exactly once. Runs that can not
+// succeed, like a deleted account or a revoked access, are recorded as failed
+// while unexpected errors roll everything back to be retried.
+func (r *scheduledTransferRepository) ExecuteDue(ctx context.Context, id int, today time.Time) (recorded int, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	var transfer models.ScheduledTransfer
+	queryFormat := `
+        SELECT` + scheduledTransferColumns + `
+        FROM %s st
+        WHERE st.id = $1 AND st.status = 'active' AND st.next_run <= $2
+        FOR UPDATE SKIP LOCKED
+    `
+	if err = tx.QueryRow(ctx, fmt.Sprintf(queryFormat, r.tableName), id, today).Scan(scheduledTransferFields(&transfer)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Handled by another worker or no longer due
+			err = tx.Rollback(ctx)
+			return 0, err
+		}
+		return 0, err
+	}
+
+	runQuery := `
+        INSERT INTO scheduled_transfer_runs
+        (scheduled_transfer_id, run_date, status, error_message, from_transaction_id, dest_transaction_id, creation_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+
+	schedule := transfer.Schedule()
+	index := transfer.OccurrenceIndex
+	date := schedule.Occurrence(index)
+	for !date.After(today) && !schedule.EndsBefore(date) {
+		run := models.ScheduledTransferRun{Status: enums.ScheduledTransferRunSucceeded}
+
+		var savepoint pgx.Tx
+		if savepoint, err = tx.Begin(ctx); err != nil {
+			return 0, err
+		}
+		result, runErr := executeScheduledTransfer(ctx, savepoint, &transfer, date)
+		if runErr != nil {
+			if err = savepoint.Rollback(ctx); err != nil {
+				return 0, err
+			}
+			message, permanent := scheduledTransferFailure(runErr)
+			if !permanent {
+				err = runErr
+				return 0, err
+			}
+			run.Status = enums.ScheduledTransferRunFailed
+			run.ErrorMessage = &message
+		} else {
+			if err = savepoint.Commit(ctx); err != nil {
+				return 0, err
+			}
+			run.FromTransactionID = &result.From.TransactionID
+			run.DestTransactionID = &result.Dest.TransactionID
+		}
+
+		if _, err = tx.Exec(
+			ctx,
+			runQuery,
+			transfer.ID,
+			date,
+			run.Status,
+			run.ErrorMessage,
+			run.FromTransactionID,
+			run.DestTransactionID,
+			currentTime,
+		); err != nil {
+			return 0, err
+		}
+		recorded++
+
+		index++
+		date = schedule.Occurrence(index)
+	}
+
+	status := enums.ScheduledTransferActive
+	var nextRun *time.Time
+	if schedule.EndsBefore(date) {
+		status = enums.ScheduledTransferFinished
+	} else {
+		nextRun = &date
+	}
+	updateFormat := "UPDATE %s SET occurrence_index = $1, next_run = $2, status = $3 WHERE id = $4"
+	if _, err = tx.Exec(ctx, fmt.Sprintf(updateFormat, r.tableName), index, nextRun, status, transfer.ID); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return recorded, nil
+}
+
+// executeScheduledTransfer resolves the rate of the run the same way
+// transferService does and moves the money through transfer.
+func executeScheduledTransfer(ctx context.Context, tx pgx.Tx, scheduled *models.ScheduledTransfer, date time.Time) (*dto.AccountTransferResult, error) {
+	if scheduled.FromAccountID == nil || scheduled.DestAccountID == nil {
+		return nil, pgx.ErrNoRows
+	}
+
+	currencyQuery := `
+        SELECT fa.currency, da.currency
+        FROM accounts fa, accounts da
+        WHERE fa.id = $1 AND da.id = $2
+    `
+	var fromCurrency, destCurrency string
+	if err := tx.QueryRow(ctx, currencyQuery, *scheduled.FromAccountID, *scheduled.DestAccountID).Scan(&fromCurrency, &destCurrency); err != nil {
+		return nil, err
+	}
+
+	rate := scheduled.Rate
+	if fromCurrency == destCurrency {
+		rate = nil
+	} else if rate == nil {
+		savedRate, err := exchangeRate(ctx, tx, fromCurrency, destCurrency, scheduled.UserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, &server_errors.ExchangeRateNotFound
+			}
+			return nil, err
+		}
+		rate = &savedRate
+	}
+
+	return transfer(ctx, tx, *scheduled.FromAccountID, *scheduled.DestAccountID, scheduled.Amount, rate, scheduled.UserID, date)
+}
+
+// scheduledTransferFailure returns the message recorded for a failed run and
+// whether err is a failure that retrying would not fix.
+func scheduledTransferFailure(err error) (string, bool) {
+	var sErr *server_errors.SError
+	if errors.As(err, &sErr) {
+		return sErr.Message, true
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "Account no longer exists or is not accessible", true
+	}
+	return "", false
+}
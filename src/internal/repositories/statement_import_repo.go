@@ -0,0 +1,450 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+)
+
+type StatementImportRepository interface {
+	Create(ctx context.Context, statementImport *models.StatementImport, rows []models.StatementImportRow) error
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.StatementImportResponse, error)
+	List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.StatementImportResponse, int, error)
+	Commit(ctx context.Context, id int, userID uuid.UUID, input *dto.StatementImportCommitRequest) error
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+}
+
+type statementImportRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewStatementImportRepository(db *pgxpool.Pool) StatementImportRepository {
+	return &statementImportRepository{db: db, tableName: "statement_imports"}
+}
+
+const statementImportJoinedSelect = `
+        SELECT
+            si.id,
+            si.user_id,
+            si.account_id,
+            si.format,
+            si.file_name,
+            si.status,
+            si.commit_date,
+            si.creation_date,
+            si.update_date,
+            a.name,
+            (SELECT COUNT(*) FROM statement_import_rows r WHERE r.import_id = si.id),
+            (SELECT COUNT(*) FROM statement_import_rows r WHERE r.import_id = si.id AND r.duplicate_of IS NOT NULL)
+        FROM statement_imports si
+        JOIN accounts a
+            ON a.id = si.account_id
+`
+
+func scanStatementImportJoined(row pgx.Row, item *dto.StatementImportResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.AccountID,
+		&item.Format,
+		&item.FileName,
+		&item.Status,
+		&item.CommitDate,
+		&item.CreationDate,
+		&item.UpdateDate,
+		&item.AccountName,
+		&item.RowCount,
+		&item.DuplicateCount,
+	)
+}
+
+// Create saves a pending import with its rows marked against the transactions
+// already on the account. The user needs 'edit' access since committing the
+// import records transactions.
+func (r *statementImportRepository) Create(ctx context.Context, statementImport *models.StatementImport, rows []models.StatementImportRow) (err error) {
+	if err = requireAccountAccess(ctx, r.db, statementImport.AccountID, statementImport.UserID, enums.AccessEdit); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = markDuplicateRows(ctx, tx, statementImport.AccountID, rows); err != nil {
+		return err
+	}
+
+	queryFormat := `
+        INSERT INTO %s
+        (user_id, account_id, format, file_name, status, creation_date, update_date)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING id
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	statementImport.Status = enums.StatementImportPending
+	statementImport.CreationDate = currentTime
+	statementImport.UpdateDate = currentTime
+	if err = tx.QueryRow(
+		ctx,
+		fmt.Sprintf(queryFormat, r.tableName),
+		statementImport.UserID,
+		statementImport.AccountID,
+		statementImport.Format,
+		statementImport.FileName,
+		statementImport.Status,
+		currentTime,
+	).Scan(&statementImport.ID); err != nil {
+		return err
+	}
+
+	rowQuery := `
+        INSERT INTO statement_import_rows
+        (import_id, line_number, transaction_date, amount, description, external_id, duplicate_of)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	batch := &pgx.Batch{}
+	for _, row := range rows {
+		batch.Queue(rowQuery, statementImport.ID, row.LineNumber, row.TransactionDate, row.Amount, row.Description, row.ExternalID, row.DuplicateOf)
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// GetByID returns the import with every row, it is the preview shown before
+// committing.
+func (r *statementImportRepository) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.StatementImportResponse, error) {
+	query := statementImportJoinedSelect + `
+        WHERE si.id = $1 AND si.user_id = $2
+    `
+	var item dto.StatementImportResponse
+	if err := scanStatementImportJoined(r.db.QueryRow(ctx, query, id, userID), &item); err != nil {
+		return nil, err
+	}
+
+	rows, err := listStatementImportRows(ctx, r.db, id)
+	if err != nil {
+		return nil, err
+	}
+	item.Rows = &rows
+	return &item, nil
+}
+
+func (r *statementImportRepository) List(ctx context.Context, limit, offset int, userID uuid.UUID) (*[]dto.StatementImportResponse, int, error) {
+	totalCount, err := CountByUserID(ctx, r.db, r.tableName, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := statementImportJoinedSelect + `
+        WHERE si.user_id = $1
+        ORDER BY si.id DESC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var imports = make([]dto.StatementImportResponse, 0, limit)
+	for rows.Next() {
+		var item dto.StatementImportResponse
+		if err := scanStatementImportJoined(rows, &item); err != nil {
+			return nil, 0, err
+		}
+		imports = append(imports, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return &imports, totalCount, nil
+}
+
+// Commit records the rows of a pending import as transactions categorized by
+// the user's rules, rows no rule matches stay uncategorized, and applies their total to the account balance in a single
+// database transaction. Duplicates are detected again since transactions may
+// have been added after the preview.
+func (r *statementImportRepository) Commit(ctx context.Context, id int, userID uuid.UUID, input *dto.StatementImportCommitRequest) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	var accountID int
+	var status enums.StatementImportStatus
+	lockQuery := fmt.Sprintf("SELECT account_id, status FROM %s WHERE id = $1 AND user_id = $2 FOR UPDATE", r.tableName)
+	if err = tx.QueryRow(ctx, lockQuery, id, userID).Scan(&accountID, &status); err != nil {
+		return err
+	}
+	if status != enums.StatementImportPending {
+		err = &server_errors.ImportNotPending
+		return err
+	}
+
+	rows, err := listStatementImportRows(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		rows[i].DuplicateOf = nil
+	}
+	if err = markDuplicateRows(ctx, tx, accountID, rows); err != nil {
+		return err
+	}
+
+	excluded := make(map[int]bool, len(input.ExcludedRows))
+	for _, rowID := range input.ExcludedRows {
+		excluded[rowID] = true
+	}
+
+	var committed []*models.StatementImportRow
+	var total models.Money
+	for i := range rows {
+		row := &rows[i]
+		if excluded[row.ID] || (row.DuplicateOf != nil && !input.IncludeDuplicates) {
+			continue
+		}
+		committed = append(committed, row)
+		total += row.Amount
+	}
+
+	if err = changeAccountBalance(ctx, tx, accountID, total, userID); err != nil {
+		return err
+	}
+
+	transactionQuery := `
+        INSERT INTO transactions
+        (user_id, account_id, category_id, amount, description, transaction_type, transaction_date, creation_date, update_date)
+        VALUES ($1, $2, ` + categorizationRuleMatch("$1", "$5", "$2", "$3", "$4") + `, $3, $4, $5, $6, $7, $7)
+        RETURNING id
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	batch := &pgx.Batch{}
+	for _, row := range committed {
+		transactionType := enums.TransactionExpense
+		if row.Amount > 0 {
+			transactionType = enums.TransactionIncome
+		}
+		batch.Queue(transactionQuery, userID, accountID, row.Amount, row.Description, transactionType, row.TransactionDate, currentTime).QueryRow(func(scanned pgx.Row) error {
+			var transactionID int
+			if err := scanned.Scan(&transactionID); err != nil {
+				return err
+			}
+			row.TransactionID = &transactionID
+			return nil
+		})
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	rowQuery := "UPDATE statement_import_rows SET duplicate_of = $1, transaction_id = $2 WHERE id = $3"
+	batch = &pgx.Batch{}
+	for _, row := range rows {
+		batch.Queue(rowQuery, row.DuplicateOf, row.TransactionID, row.ID)
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE %s SET status = $1, commit_date = $2 WHERE id = $3", r.tableName)
+	if _, err = tx.Exec(ctx, updateQuery, enums.StatementImportCommitted, currentTime, id); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// Delete discards a pending import, committed imports are kept as the record
+// of where their transactions came from.
+func (r *statementImportRepository) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2 AND status = 'pending' RETURNING id", r.tableName)
+	var deletedID int
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&deletedID)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	var exists bool
+	existsQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND user_id = $2)", r.tableName)
+	if existsErr := r.db.QueryRow(ctx, existsQuery, id, userID).Scan(&exists); existsErr != nil {
+		return existsErr
+	}
+	if exists {
+		return &server_errors.ImportNotPending
+	}
+	return err
+}
+
+func listStatementImportRows(ctx context.Context, q querier, importID int) ([]models.StatementImportRow, error) {
+	query := `
+        SELECT id, import_id, line_number, transaction_date, amount, description, external_id, duplicate_of, transaction_id
+        FROM statement_import_rows
+        WHERE import_id = $1
+        ORDER BY id
+    `
+	rows, err := q.Query(ctx, query, importID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var importRows []models.StatementImportRow
+	for rows.Next() {
+		var row models.StatementImportRow
+		if err := rows.Scan(
+			&row.ID,
+			&row.ImportID,
+			&row.LineNumber,
+			&row.TransactionDate,
+			&row.Amount,
+			&row.Description,
+			&row.ExternalID,
+			&row.DuplicateOf,
+			&row.TransactionID,
+		); err != nil {
+			return nil, err
+		}
+		importRows = append(importRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return importRows, nil
+}
+
+// markDuplicateRows sets DuplicateOf on the rows that are already on the
+// account. A row matches a transaction imported earlier with the same bank id,
+// or a transaction with the same date, amount and description ignoring case
+// and spacing. Each transaction matches a single row, so a statement with two
+// identical purchases against one recorded purchase keeps one of them.
+func markDuplicateRows(ctx context.Context, q querier, accountID int, rows []models.StatementImportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	from, to := rows[0].TransactionDate, rows[0].TransactionDate
+	var externalIDs []string
+	for _, row := range rows {
+		if row.TransactionDate.Before(from) {
+			from = row.TransactionDate
+		}
+		if row.TransactionDate.After(to) {
+			to = row.TransactionDate
+		}
+		if row.ExternalID != nil {
+			externalIDs = append(externalIDs, *row.ExternalID)
+		}
+	}
+
+	claimed := make(map[int]bool)
+
+	importedByExternalID := make(map[string]int)
+	if len(externalIDs) > 0 {
+		importedQuery := `
+            SELECT r.external_id, r.transaction_id
+            FROM statement_import_rows r
+            JOIN statement_imports si
+                ON si.id = r.import_id
+            WHERE si.account_id = $1 AND si.status = 'committed' AND r.transaction_id IS NOT NULL AND r.external_id = ANY($2)
+        `
+		imported, err := q.Query(ctx, importedQuery, accountID, externalIDs)
+		if err != nil {
+			return err
+		}
+		defer imported.Close()
+		for imported.Next() {
+			var externalID string
+			var transactionID int
+			if err := imported.Scan(&externalID, &transactionID); err != nil {
+				return err
+			}
+			importedByExternalID[externalID] = transactionID
+		}
+		if err := imported.Err(); err != nil {
+			return err
+		}
+	}
+
+	for i := range rows {
+		if rows[i].ExternalID == nil {
+			continue
+		}
+		if transactionID, found := importedByExternalID[*rows[i].ExternalID]; found && !claimed[transactionID] {
+			claimed[transactionID] = true
+			rows[i].DuplicateOf = &transactionID
+		}
+	}
+
+	existingQuery := `
+        SELECT id, transaction_date::DATE, amount, COALESCE(description, '')
+        FROM transactions
+        WHERE account_id = $1 AND transaction_date >= $2 AND transaction_date < $3
+        ORDER BY id
+    `
+	existing, err := q.Query(ctx, existingQuery, accountID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	defer existing.Close()
+
+	candidates := make(map[string][]int)
+	for existing.Next() {
+		var transactionID int
+		var date time.Time
+		var amount models.Money
+		var description string
+		if err := existing.Scan(&transactionID, &date, &amount, &description); err != nil {
+			return err
+		}
+		if claimed[transactionID] {
+			continue
+		}
+		key := duplicateKey(date, amount, &description)
+		candidates[key] = append(candidates[key], transactionID)
+	}
+	if err := existing.Err(); err != nil {
+		return err
+	}
+
+	for i := range rows {
+		if rows[i].DuplicateOf != nil {
+			continue
+		}
+		key := duplicateKey(rows[i].TransactionDate, rows[i].Amount, rows[i].Description)
+		if matches := candidates[key]; len(matches) > 0 {
+			rows[i].DuplicateOf = &matches[0]
+			candidates[key] = matches[1:]
+		}
+	}
+	return nil
+}
+
+func duplicateKey(date time.Time, amount models.Money, description *string) string {
+	normalized := ""
+	if description != nil {
+		normalized = strings.ToLower(strings.Join(strings.Fields(*description), " "))
+	}
+	return fmt.Sprintf("%s|%s|%s", date.Format(time.DateOnly), amount.String(), normalized)
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+)
+
+type TransactionAttachmentRepository interface {
+	Attach(ctx context.Context, transactionID, mediaID int, userID uuid.UUID) (*dto.TransactionAttachment, error)
+	List(ctx context.Context, transactionID int, userID uuid.UUID) (*[]dto.TransactionAttachment, error)
+	Detach(ctx context.Context, transactionID, mediaID int, userID uuid.UUID) error
+}
+
+type transactionAttachmentRepository struct {
+	db        *pgxpool.Pool
+	tableName string
+}
+
+func NewTransactionAttachmentRepository(db *pgxpool.Pool) TransactionAttachmentRepository {
+	return &transactionAttachmentRepository{db: db, tableName: "media_transaction"}
+}
+
+const transactionAttachmentSelect = `
+        SELECT mt.transaction_id, m.id, m.url, m.metadata, m.access, mt.creation_date
+        FROM media_transaction mt
+        JOIN media m
+            ON m.id = mt.media_id
+`
+
+func scanTransactionAttachment(row pgx.Row, transactionID *int, item *dto.TransactionAttachment) error {
+	return row.Scan(transactionID, &item.MediaID, &item.URL, &item.Metadata, &item.Access, &item.CreationDate)
+}
+
+// loadTransactionAttachments fills the attachments of the transactions with a
+// single query, transactions without any get an empty list.
+func loadTransactionAttachments(ctx context.Context, q querier, transactions []dto.TransactionJoinedResponse) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	positions := make(map[int]int, len(transactions))
+	ids := make([]int, len(transactions))
+	for i := range transactions {
+		transactions[i].Attachments = make([]dto.TransactionAttachment, 0)
+		positions[transactions[i].ID] = i
+		ids[i] = transactions[i].ID
+	}
+
+	rows, err := q.Query(ctx, transactionAttachmentSelect+" WHERE mt.transaction_id = ANY($1) ORDER BY mt.id", ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionID int
+		var item dto.TransactionAttachment
+		if err := scanTransactionAttachment(rows, &transactionID, &item); err != nil {
+			return err
+		}
+		transaction := &transactions[positions[transactionID]]
+		transaction.Attachments = append(transaction.Attachments, item)
+	}
+	return rows.Err()
+}
+
+// lockAttachedTransaction locks the transaction for the rest of tx, changing
+// its attachments needs 'edit' on its account.
+func lockAttachedTransaction(ctx context.Context, tx pgx.Tx, transactionID int, userID uuid.UUID) error {
+	query := `
+        SELECT account_id
+        FROM transactions
+        WHERE id = $1 AND has_account_access(account_id, $2, 'view')
+        FOR UPDATE
+    `
+	var accountID int
+	if err := tx.QueryRow(ctx, query, transactionID, userID).Scan(&accountID); err != nil {
+		return err
+	}
+	return requireAccountAccess(ctx, tx, accountID, userID, enums.AccessEdit)
+}
+
+// releaseMedia puts a media that nothing references anymore back to 'temp',
+// the media cleaner then removes it like any unused upload.
+func releaseMedia(ctx context.Context, tx pgx.Tx, mediaID int, date time.Time) error {
+	query := `
+        UPDATE media m
+        SET status = 'temp', update_date = $2
+        WHERE m.id = $1
+            AND m.status = 'attached'
+            AND NOT EXISTS (SELECT 1 FROM media_transaction WHERE media_id = m.id)
+            AND NOT EXISTS (SELECT 1 FROM items WHERE image_id = m.id)
+            AND NOT EXISTS (SELECT 1 FROM categories WHERE icon_id = m.id)
+            AND NOT EXISTS (SELECT 1 FROM financial_groups WHERE image_id = m.id)
+            AND NOT EXISTS (SELECT 1 FROM profiles WHERE picture_id = m.id)
+    `
+	_, err := tx.Exec(ctx, query, mediaID, date)
+	return err
+}
+
+// Attach only accepts media uploaded by the user, attaching the same media
+// again returns the existing attachment.
+func (r *transactionAttachmentRepository) Attach(ctx context.Context, transactionID, mediaID int, userID uuid.UUID) (result *dto.TransactionAttachment, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = lockAttachedTransaction(ctx, tx, transactionID, userID); err != nil {
+		return nil, err
+	}
+
+	// Locking the media keeps the cleaner from removing it while it is attached
+	mediaQuery := "SELECT id FROM media WHERE id = $1 AND user_id = $2 AND status <> 'removed' FOR UPDATE"
+	if err = tx.QueryRow(ctx, mediaQuery, mediaID, userID).Scan(&mediaID); err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	insertQuery := fmt.Sprintf(`
+        INSERT INTO %s (media_id, transaction_id, creation_date)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (media_id, transaction_id) DO NOTHING
+    `, r.tableName)
+	if _, err = tx.Exec(ctx, insertQuery, mediaID, transactionID, currentTime); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE media SET status = 'attached', update_date = $1 WHERE id = $2", currentTime, mediaID); err != nil {
+		return nil, err
+	}
+
+	var attachment dto.TransactionAttachment
+	query := transactionAttachmentSelect + " WHERE mt.transaction_id = $1 AND mt.media_id = $2"
+	if err = scanTransactionAttachment(tx.QueryRow(ctx, query, transactionID, mediaID), &transactionID, &attachment); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *transactionAttachmentRepository) List(ctx context.Context, transactionID int, userID uuid.UUID) (*[]dto.TransactionAttachment, error) {
+	var id int
+	accessQuery := "SELECT id FROM transactions WHERE id = $1 AND has_account_access(account_id, $2, 'view')"
+	if err := r.db.QueryRow(ctx, accessQuery, transactionID, userID).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	rows, _ := r.db.Query(ctx, transactionAttachmentSelect+" WHERE mt.transaction_id = $1 ORDER BY mt.id", transactionID)
+	attachments, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.TransactionAttachment, error) {
+		var item dto.TransactionAttachment
+		err := scanTransactionAttachment(row, &id, &item)
+		return item, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attachments, nil
+}
+
+// Detach removes the attachment, the media goes back to cleanup when it was
+// its last reference.
+func (r *transactionAttachmentRepository) Detach(ctx context.Context, transactionID, mediaID int, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = lockAttachedTransaction(ctx, tx, transactionID, userID); err != nil {
+		return err
+	}
+
+	var id int
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE transaction_id = $1 AND media_id = $2 RETURNING id", r.tableName)
+	if err = tx.QueryRow(ctx, deleteQuery, transactionID, mediaID).Scan(&id); err != nil {
+		return err
+	}
+
+	if err = releaseMedia(ctx, tx, mediaID, time.Now().UTC().Truncate(time.Second)); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
@@ -2,16 +2,29 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
 	"shirinec.com/src/internal/utils"
 )
 
 type TransactionRepository interface {
-	Transfer(ctx context.Context, from, dest int, amount float64, userID uuid.UUID) (*dto.AccountTransferResult, error)
+	Transfer(ctx context.Context, from, dest int, amount models.Money, rate *string, userID uuid.UUID) (*dto.AccountTransferResult, error)
+	Create(ctx context.Context, transaction *models.Transaction) error
+	GetByID(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error)
+	List(ctx context.Context, filter *dto.TransactionListFilter) (*[]dto.TransactionJoinedResponse, error)
+	Count(ctx context.Context, filter *dto.TransactionListFilter) (int, error)
+	Update(ctx context.Context, transaction *models.Transaction) (*dto.TransactionJoinedResponse, error)
+	Delete(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) error
+	Reverse(ctx context.Context, id int, userID uuid.UUID) (*dto.TransactionReversalResponse, error)
 }
 
 type transactionRepository struct {
@@ -24,48 +37,154 @@ func NewTransactionRepository(db *pgxpool.Pool) TransactionRepository {
 	}
 }
 
-func (r *transactionRepository) Transfer(ctx context.Context, from, dest int, amount float64, userID uuid.UUID) (*dto.AccountTransferResult, error) {
+const transactionJoinedSelect = `
+        SELECT
+            t.id,
+            t.user_id,
+            t.account_id,
+            a.name,
+            t.category_id,
+            c.name,
+            c.color,
+            cm.url,
+            t.amount,
+            t.description,
+            t.transaction_type,
+            t.status,
+            t.linked_transaction_id,
+            t.reversal_of_id,
+            t.transaction_date,
+            t.creation_date,
+            t.update_date
+        FROM transactions t
+        JOIN accounts a
+            ON a.id = t.account_id
+        LEFT JOIN categories c
+            ON c.id = t.category_id
+        LEFT JOIN media cm
+            ON cm.id = c.icon_id
+`
+
+func scanTransactionJoined(row pgx.Row, item *dto.TransactionJoinedResponse) error {
+	return row.Scan(
+		&item.ID,
+		&item.UserID,
+		&item.AccountID,
+		&item.AccountName,
+		&item.CategoryID,
+		&item.CategoryName,
+		&item.CategoryColor,
+		&item.CategoryIconURL,
+		&item.Amount,
+		&item.Description,
+		&item.Type,
+		&item.Status,
+		&item.LinkedTransactionID,
+		&item.ReversalOfID,
+		&item.Date,
+		&item.CreationDate,
+		&item.UpdateDate,
+	)
+}
+
+// rollbackOnError is meant to be deferred right after a transaction begins. It
+// rolls the transaction back when the function returns with a non nil error.
+func rollbackOnError(ctx context.Context, tx pgx.Tx, err *error) {
+	if *err == nil {
+		return
+	}
+	if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+		utils.Logger.Errorf("failed to rollback transaction: %s", rollbackErr.Error())
+	}
+}
+
+// changeAccountBalance requires the user to have at least 'edit' access on the
+// account, recording transactions is not allowed with 'view'.
+func changeAccountBalance(ctx context.Context, tx pgx.Tx, accountID int, amount models.Money, userID uuid.UUID) error {
+	if err := requireAccountAccess(ctx, tx, accountID, userID, enums.AccessEdit); err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE accounts
+        SET balance = balance + $1
+        WHERE id = $2
+        RETURNING id
+    `
+	var id int
+	return tx.QueryRow(ctx, query, amount, accountID).Scan(&id)
+}
+
+// Transfer moves amount out of the from account, when rate is set the dest
+// account receives amount converted with it and both legs keep the rate used.
+func (r *transactionRepository) Transfer(ctx context.Context, from, dest int, amount models.Money, rate *string, userID uuid.UUID) (result *dto.AccountTransferResult, err error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-    defer func() {
-        if err != nil {
-            if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
-                utils.Logger.Errorf("failed to rollback transaction: %s", rollbackErr.Error())
-            }
-        }
-    }()
+	defer rollbackOnError(ctx, tx, &err)
+
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	if result, err = transfer(ctx, tx, from, dest, amount, rate, userID, currentTime); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// transfer records both legs of a transfer dated date and updates the account
+// balances inside tx, it is shared by Transfer and scheduled transfers.
+func transfer(ctx context.Context, tx pgx.Tx, from, dest int, amount models.Money, rate *string, userID uuid.UUID, date time.Time) (*dto.AccountTransferResult, error) {
+	var err error
+	for _, accountID := range []int{from, dest} {
+		if err = requireAccountAccess(ctx, tx, accountID, userID, enums.AccessEdit); err != nil {
+			return nil, err
+		}
+	}
+
+	destAmount := amount
+	if rate != nil {
+		if err = tx.QueryRow(ctx, "SELECT $1::NUMERIC * $2::NUMERIC", amount, *rate).Scan(&destAmount); err != nil {
+			return nil, err
+		}
+	}
 
 	currentTime := time.Now().UTC().Truncate(time.Second)
 	createTransactionQuery := `
         INSERT INTO transactions
-        (user_id, account_id, amount, transaction_type, update_date, creation_date)
-        VALUES($1, $2, $3, $4, $5, $5)
+        (user_id, account_id, amount, transaction_type, exchange_rate, transaction_date, update_date, creation_date)
+        VALUES($1, $2, $3, $4, $5, $6, $7, $7)
         RETURNING id
     `
 
 	var firstTransID, secondTransID int
 
-	if err := tx.QueryRow(
+	if err = tx.QueryRow(
 		ctx,
 		createTransactionQuery,
 		userID,
 		from,
 		amount*-1,
 		"transfer",
+		rate,
+		date,
 		currentTime,
 	).Scan(&firstTransID); err != nil {
 		return nil, err
 	}
 
-	if err := tx.QueryRow(
+	if err = tx.QueryRow(
 		ctx,
 		createTransactionQuery,
 		userID,
 		dest,
-		amount,
+		destAmount,
 		"transfer",
+		rate,
+		date,
 		currentTime,
 	).Scan(&secondTransID); err != nil {
 		return nil, err
@@ -78,10 +197,10 @@ func (r *transactionRepository) Transfer(ctx context.Context, from, dest int, am
         RETURNING id
     `
 
-	if _, err := tx.Exec(ctx, updateTransQuery, firstTransID, secondTransID); err != nil {
+	if _, err = tx.Exec(ctx, updateTransQuery, firstTransID, secondTransID); err != nil {
 		return nil, err
 	}
-	if _, err := tx.Exec(ctx, updateTransQuery, secondTransID, firstTransID); err != nil {
+	if _, err = tx.Exec(ctx, updateTransQuery, secondTransID, firstTransID); err != nil {
 		return nil, err
 	}
 
@@ -89,39 +208,551 @@ func (r *transactionRepository) Transfer(ctx context.Context, from, dest int, am
         UPDATE accounts
         SET balance = balance + $1
         WHERE id = $2
-        RETURNING id, name, type, balance
+        RETURNING id, name, type, balance, currency
     `
 
 	var firstAccount, secondAccount dto.AccountTransferResultItem
 
-	if err := tx.QueryRow(ctx, changeBalanceQuery, amount*-1, from).Scan(
+	if err = tx.QueryRow(ctx, changeBalanceQuery, amount*-1, from).Scan(
 		&firstAccount.ID,
 		&firstAccount.Name,
 		&firstAccount.Type,
 		&firstAccount.Balance,
+		&firstAccount.Currency,
 	); err != nil {
 		return nil, err
 	}
 	firstAccount.Change = amount * -1
+	firstAccount.TransactionID = firstTransID
 
-	if err := tx.QueryRow(ctx, changeBalanceQuery, amount, dest).Scan(
+	if err = tx.QueryRow(ctx, changeBalanceQuery, destAmount, dest).Scan(
 		&secondAccount.ID,
 		&secondAccount.Name,
 		&secondAccount.Type,
 		&secondAccount.Balance,
+		&secondAccount.Currency,
 	); err != nil {
 		return nil, err
 	}
-	secondAccount.Change = amount
+	secondAccount.Change = destAmount
+	secondAccount.TransactionID = secondTransID
+
+	result := &dto.AccountTransferResult{
+		From: firstAccount,
+		Dest: secondAccount,
+		Rate: rate,
+		Date: date,
+	}
+
+	return result, nil
+}
+
+func (r *transactionRepository) Create(ctx context.Context, transaction *models.Transaction) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	if err = changeAccountBalance(ctx, tx, *transaction.AccountID, *transaction.Amount, transaction.UserID); err != nil {
+		return err
+	}
+
+	// Without a category the user's categorization rules pick one
+	query := `
+        INSERT INTO transactions
+        (user_id, account_id, category_id, amount, description, transaction_type, transaction_date, creation_date, update_date)
+        VALUES ($1, $2, COALESCE($3, ` + categorizationRuleMatch("$1", "$6", "$2", "$4", "$5") + `), $4, $5, $6, $7, $8, $8)
+        RETURNING id, category_id
+    `
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	transaction.CreationDate = currentTime
+	transaction.UpdateDate = currentTime
+	if transaction.TransactionDate == nil {
+		transaction.TransactionDate = &currentTime
+	}
+
+	if err = tx.QueryRow(
+		ctx,
+		query,
+		transaction.UserID,
+		transaction.AccountID,
+		transaction.CategoryID,
+		transaction.Amount,
+		transaction.Description,
+		transaction.TransactionType,
+		transaction.TransactionDate,
+		currentTime,
+	).Scan(&transaction.ID, &transaction.CategoryID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+func (r *transactionRepository) GetByID(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error) {
+	query := transactionJoinedSelect + `
+        WHERE
+            t.id = $1
+            AND
+            has_account_access(t.account_id, $2, 'view')
+            AND
+            t.transaction_type = $3
+    `
+
+	var item dto.TransactionJoinedResponse
+	if err := scanTransactionJoined(r.db.QueryRow(ctx, query, id, userID, transactionType), &item); err != nil {
+		return nil, err
+	}
+
+	items := []dto.TransactionJoinedResponse{item}
+	if err := loadTransactionAttachments(ctx, r.db, items); err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// transactionAmountColumn is what amount filters and sorting compare, expenses
+// are stored negative but a user asking for amounts over 10 means the size of
+// the transaction whatever its direction.
+const transactionAmountColumn = "ABS(t.amount)"
+
+// transactionFilterClauses builds the WHERE clauses shared by List and Count,
+// args are appended in order so the returned argIndex is the next free one.
+func transactionFilterClauses(filter *dto.TransactionListFilter) ([]string, []interface{}, int) {
+	// Transactions on shared accounts are part of the ledger of every user with access
+	whereClauses := []string{fmt.Sprintf("t.account_id IN (%s)", fmt.Sprintf(accessibleAccountsQuery, 1))}
+	args := []interface{}{filter.UserID}
+	argIndex := 2
+
+	if filter.AccountID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.account_id = $%d", argIndex))
+		args = append(args, *filter.AccountID)
+		argIndex++
+	}
+
+	if filter.CategoryID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.category_id = $%d", argIndex))
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+
+	if filter.Type != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.transaction_type = $%d", argIndex))
+		args = append(args, *filter.Type)
+		argIndex++
+	}
+
+	if filter.From != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.transaction_date >= $%d", argIndex))
+		args = append(args, *filter.From)
+		argIndex++
+	}
+
+	if filter.To != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("t.transaction_date < $%d", argIndex))
+		args = append(args, *filter.To)
+		argIndex++
+	}
+
+	if filter.MinAmount != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s >= $%d", transactionAmountColumn, argIndex))
+		args = append(args, *filter.MinAmount)
+		argIndex++
+	}
+
+	if filter.MaxAmount != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s <= $%d", transactionAmountColumn, argIndex))
+		args = append(args, *filter.MaxAmount)
+		argIndex++
+	}
+
+	if filter.Description != nil {
+		escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+		whereClauses = append(whereClauses, fmt.Sprintf("t.description ILIKE $%d", argIndex))
+		args = append(args, "%"+escaper.Replace(*filter.Description)+"%")
+		argIndex++
+	}
+
+	return whereClauses, args, argIndex
+}
+
+func (r *transactionRepository) List(ctx context.Context, filter *dto.TransactionListFilter) (*[]dto.TransactionJoinedResponse, error) {
+	whereClauses, args, argIndex := transactionFilterClauses(filter)
+
+	sortColumn := "t.transaction_date"
+	if filter.SortBy == "amount" {
+		sortColumn = transactionAmountColumn
+	}
+	direction, comparison := "DESC", "<"
+	if filter.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	if filter.After != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("(%s, t.id) %s ($%d, $%d)", sortColumn, comparison, argIndex, argIndex+1))
+		if filter.SortBy == "amount" {
+			args = append(args, filter.After.Amount)
+		} else {
+			args = append(args, filter.After.Date)
+		}
+		args = append(args, filter.After.ID)
+		argIndex += 2
+	}
+
+	query := fmt.Sprintf(
+		"%s WHERE %s ORDER BY %s %s, t.id %s LIMIT $%d OFFSET $%d",
+		transactionJoinedSelect,
+		strings.Join(whereClauses, " AND "),
+		sortColumn,
+		direction,
+		direction,
+		argIndex,
+		argIndex+1,
+	)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions = make([]dto.TransactionJoinedResponse, 0, filter.Limit)
+	for rows.Next() {
+		var item dto.TransactionJoinedResponse
+		if err := scanTransactionJoined(rows, &item); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := loadTransactionAttachments(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+	return &transactions, nil
+}
+
+func (r *transactionRepository) Count(ctx context.Context, filter *dto.TransactionListFilter) (int, error) {
+	whereClauses, args, _ := transactionFilterClauses(filter)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM transactions t WHERE %s", strings.Join(whereClauses, " AND "))
+
+	var totalCount int
+	err := r.db.QueryRow(ctx, query, args...).Scan(&totalCount)
+	return totalCount, err
+}
+
+func (r *transactionRepository) Update(ctx context.Context, transaction *models.Transaction) (result *dto.TransactionJoinedResponse, err error) {
+	var setClauses []string
+	var args []interface{}
+	argIndex := 1
+
+	if transaction.AccountID != nil {
+		setClauses = append(setClauses, fmt.Sprintf("account_id = $%d", argIndex))
+		args = append(args, transaction.AccountID)
+		argIndex++
+	}
+
+	if transaction.CategoryID != nil {
+		setClauses = append(setClauses, fmt.Sprintf("category_id = $%d", argIndex))
+		args = append(args, transaction.CategoryID)
+		argIndex++
+	}
+
+	if transaction.Amount != nil {
+		setClauses = append(setClauses, fmt.Sprintf("amount = $%d", argIndex))
+		args = append(args, transaction.Amount)
+		argIndex++
+	}
+
+	if transaction.Description != nil {
+		setClauses = append(setClauses, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, transaction.Description)
+		argIndex++
+	}
+
+	if transaction.TransactionDate != nil {
+		setClauses = append(setClauses, fmt.Sprintf("transaction_date = $%d", argIndex))
+		args = append(args, transaction.TransactionDate)
+		argIndex++
+	}
+
+	if len(setClauses) == 0 {
+		return nil, &server_errors.EmptyUpdate
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	// Locking the row so concurrent updates can not apply the balance change twice
+	currentQuery := `
+        SELECT account_id, amount, status
+        FROM transactions
+        WHERE id = $1 AND has_account_access(account_id, $2, 'view') AND transaction_type = $3
+        FOR UPDATE
+    `
+	var currentAccountID int
+	var currentAmount models.Money
+	var status enums.TransactionStatus
+	if err = tx.QueryRow(ctx, currentQuery, transaction.ID, transaction.UserID, transaction.TransactionType).Scan(&currentAccountID, &currentAmount, &status); err != nil {
+		return nil, err
+	}
+	if status != enums.TransactionPosted {
+		err = &server_errors.TransactionNotPosted
+		return nil, err
+	}
+	if err = requireAccountAccess(ctx, tx, currentAccountID, transaction.UserID, enums.AccessEdit); err != nil {
+		return nil, err
+	}
 
-	if err := tx.Commit(ctx); err != nil {
+	if transaction.Amount != nil && *transaction.Amount != currentAmount {
+		var itemized bool
+		if itemized, err = hasPurchaseListItems(ctx, tx, transaction.ID); err != nil {
+			return nil, err
+		}
+		if itemized {
+			err = &server_errors.AmountManagedByItems
+			return nil, err
+		}
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE transactions SET %s WHERE id = $%d RETURNING id",
+		strings.Join(setClauses, ", "),
+		argIndex,
+	)
+	args = append(args, transaction.ID)
+	if err = tx.QueryRow(ctx, query, args...).Scan(&transaction.ID); err != nil {
 		return nil, err
 	}
 
-	var result dto.AccountTransferResult
-	result.From = firstAccount
-	result.Dest = secondAccount
-	result.Date = currentTime
+	newAccountID := currentAccountID
+	if transaction.AccountID != nil {
+		newAccountID = *transaction.AccountID
+	}
+	newAmount := currentAmount
+	if transaction.Amount != nil {
+		newAmount = *transaction.Amount
+	}
 
-	return &result, nil
+	if newAccountID != currentAccountID || newAmount != currentAmount {
+		if err = changeAccountBalance(ctx, tx, currentAccountID, -currentAmount, transaction.UserID); err != nil {
+			return nil, err
+		}
+		if err = changeAccountBalance(ctx, tx, newAccountID, newAmount, transaction.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, transaction.ID, transaction.TransactionType, transaction.UserID)
+}
+
+func (r *transactionRepository) Delete(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	currentQuery := `
+        SELECT account_id, status
+        FROM transactions
+        WHERE id = $1 AND has_account_access(account_id, $2, 'view') AND transaction_type = $3
+        FOR UPDATE
+    `
+	var accountID int
+	var status enums.TransactionStatus
+	if err = tx.QueryRow(ctx, currentQuery, id, userID, transactionType).Scan(&accountID, &status); err != nil {
+		return err
+	}
+	// Deleting one side of a reversal would leave the other unbalanced
+	if status != enums.TransactionPosted {
+		err = &server_errors.TransactionNotPosted
+		return err
+	}
+	if err = requireAccountAccess(ctx, tx, accountID, userID, enums.AccessEdit); err != nil {
+		return err
+	}
+
+	// Attached receipts go back to cleanup once nothing else uses them
+	rows, _ := tx.Query(ctx, "DELETE FROM media_transaction WHERE transaction_id = $1 RETURNING media_id", id)
+	mediaIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return err
+	}
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	for _, mediaID := range mediaIDs {
+		if err = releaseMedia(ctx, tx, mediaID, currentTime); err != nil {
+			return err
+		}
+	}
+
+	var amount models.Money
+	if err = tx.QueryRow(ctx, "DELETE FROM transactions WHERE id = $1 RETURNING amount", id).Scan(&amount); err != nil {
+		return err
+	}
+
+	if err = changeAccountBalance(ctx, tx, accountID, -amount, userID); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	return err
+}
+
+// Reverse voids the transaction and records a reversal with the negated
+// amount on the same account, dated now so the history up to the reversal is
+// kept. Both legs of a transfer are reversed together and the two reversals
+// are linked to each other like the original legs.
+func (r *transactionRepository) Reverse(ctx context.Context, id int, userID uuid.UUID) (result *dto.TransactionReversalResponse, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rollbackOnError(ctx, tx, &err)
+
+	lockQuery := `
+        SELECT id, user_id, account_id, category_id, amount, transaction_type, status, TRIM_SCALE(exchange_rate)::TEXT, linked_transaction_id
+        FROM transactions
+        WHERE id = $1 AND has_account_access(account_id, $2, 'view')
+        FOR UPDATE
+    `
+	type reversedLeg struct {
+		id, accountID      int
+		ownerID            uuid.UUID
+		categoryID, linked *int
+		amount             models.Money
+		rate               *string
+		transactionType    enums.TransactionType
+		status             enums.TransactionStatus
+	}
+	lockLeg := func(legID int) (leg reversedLeg, err error) {
+		err = tx.QueryRow(ctx, lockQuery, legID, userID).Scan(
+			&leg.id,
+			&leg.ownerID,
+			&leg.accountID,
+			&leg.categoryID,
+			&leg.amount,
+			&leg.transactionType,
+			&leg.status,
+			&leg.rate,
+			&leg.linked,
+		)
+		return leg, err
+	}
+
+	first, err := lockLeg(id)
+	if err != nil {
+		return nil, err
+	}
+	legs := []reversedLeg{first}
+	if first.linked != nil {
+		var second reversedLeg
+		if second, err = lockLeg(*first.linked); err != nil {
+			return nil, err
+		}
+		legs = append(legs, second)
+	}
+
+	for _, leg := range legs {
+		if leg.transactionType == enums.TransactionOpening {
+			err = &server_errors.OpeningNotReversible
+			return nil, err
+		}
+		if leg.status != enums.TransactionPosted {
+			err = &server_errors.TransactionNotPosted
+			return nil, err
+		}
+	}
+
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	insertQuery := `
+        INSERT INTO transactions
+        (user_id, account_id, category_id, amount, description, transaction_type, exchange_rate, status, reversal_of_id, transaction_date, update_date, creation_date)
+        VALUES($1, $2, $3, $4, $5, $6, $7, 'reversal', $8, $9, $9, $9)
+        RETURNING id
+    `
+	voidedIDs := make([]int, 0, len(legs))
+	reversalIDs := make([]int, 0, len(legs))
+	for _, leg := range legs {
+		description := fmt.Sprintf("Reversal of transaction %d", leg.id)
+		var reversalID int
+		if err = tx.QueryRow(
+			ctx,
+			insertQuery,
+			leg.ownerID,
+			leg.accountID,
+			leg.categoryID,
+			-leg.amount,
+			description,
+			leg.transactionType,
+			leg.rate,
+			leg.id,
+			currentTime,
+		).Scan(&reversalID); err != nil {
+			return nil, err
+		}
+
+		if _, err = tx.Exec(ctx, "UPDATE transactions SET status = 'voided', update_date = $1 WHERE id = $2", currentTime, leg.id); err != nil {
+			return nil, err
+		}
+		if err = changeAccountBalance(ctx, tx, leg.accountID, -leg.amount, userID); err != nil {
+			return nil, err
+		}
+
+		voidedIDs = append(voidedIDs, leg.id)
+		reversalIDs = append(reversalIDs, reversalID)
+	}
+
+	if len(reversalIDs) == 2 {
+		linkQuery := "UPDATE transactions SET linked_transaction_id = $1 WHERE id = $2"
+		if _, err = tx.Exec(ctx, linkQuery, reversalIDs[1], reversalIDs[0]); err != nil {
+			return nil, err
+		}
+		if _, err = tx.Exec(ctx, linkQuery, reversalIDs[0], reversalIDs[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	result = &dto.TransactionReversalResponse{}
+	if result.Voided, err = listTransactionsByID(ctx, tx, voidedIDs); err != nil {
+		return nil, err
+	}
+	if result.Reversals, err = listTransactionsByID(ctx, tx, reversalIDs); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func listTransactionsByID(ctx context.Context, tx pgx.Tx, ids []int) ([]dto.TransactionJoinedResponse, error) {
+	rows, _ := tx.Query(ctx, transactionJoinedSelect+" WHERE t.id = ANY($1) ORDER BY t.id", ids)
+	transactions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.TransactionJoinedResponse, error) {
+		var item dto.TransactionJoinedResponse
+		err := scanTransactionJoined(row, &item)
+		return item, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = loadTransactionAttachments(ctx, tx, transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
 }
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	"shirinec.com/src/internal/models"
+)
+
+func TestTransactionFilterClausesAmount(t *testing.T) {
+	expense := enums.TransactionExpense
+	minAmount, maxAmount := models.Money(1000), models.Money(5000)
+
+	tests := []struct {
+		name        string
+		filter      dto.TransactionListFilter
+		wantClauses []string
+		wantArgs    []interface{}
+	}{
+		{
+			name:        "expense within a positive range",
+			filter:      dto.TransactionListFilter{Type: &expense, MinAmount: &minAmount, MaxAmount: &maxAmount},
+			wantClauses: []string{"t.transaction_type = $2", "ABS(t.amount) >= $3", "ABS(t.amount) <= $4"},
+			wantArgs:    []interface{}{expense, minAmount, maxAmount},
+		},
+		{
+			name:        "only a minimum",
+			filter:      dto.TransactionListFilter{MinAmount: &minAmount},
+			wantClauses: []string{"ABS(t.amount) >= $2"},
+			wantArgs:    []interface{}{minAmount},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.filter.UserID = uuid.New()
+			clauses, args, argIndex := transactionFilterClauses(&test.filter)
+
+			// The first clause and argument always limit the ledger to the user's accounts
+			if !reflect.DeepEqual(clauses[1:], test.wantClauses) {
+				t.Errorf("clauses = %q, want %q", clauses[1:], test.wantClauses)
+			}
+			if !reflect.DeepEqual(args[1:], test.wantArgs) {
+				t.Errorf("args = %v, want %v", args[1:], test.wantArgs)
+			}
+			if argIndex != len(args)+1 {
+				t.Errorf("argIndex = %d, want %d", argIndex, len(args)+1)
+			}
+		})
+	}
+}
@@ -7,8 +7,12 @@ import (
 )
 
 func (r *router) setupAccountRouter() {
-    accountService := services.NewAccountService(&r.Deps.AccountRepo)
+    accountService := services.NewAccountService(&r.Deps.AccountRepo, r.Deps.FinancialGroupRepo)
     accountHandler := handler.NewAccountHandler(&accountService)
+    accountAccessService := services.NewAccountAccessService(r.Deps.AccountAccessRepo)
+    accountAccessHandler := handler.NewAccountAccessHandler(accountAccessService)
+	reconciliationService := services.NewReconciliationService(r.Deps.ReconciliationRepo)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
 
     flags := middlewares.AuthMiddleWareFlags{
         ShouldBeActive: true,
@@ -18,8 +22,16 @@ func (r *router) setupAccountRouter() {
 
     r.GinEngine.POST("/account", authMiddleware, accountHandler.Create)
 	r.GinEngine.GET("/account", authMiddleware, accountHandler.List)
+	r.GinEngine.GET("/account/discrepancy", authMiddleware, reconciliationHandler.ListDiscrepancies)
 	r.GinEngine.GET("/account/:id", authMiddleware, accountHandler.GetByID)
     r.GinEngine.PUT("/account/:id", authMiddleware, accountHandler.Update)
     r.GinEngine.DELETE("/account/:id", authMiddleware, accountHandler.Delete)
+	r.GinEngine.PUT("/account/:id/opening_balance", authMiddleware, accountHandler.SetOpeningBalance)
+	r.GinEngine.POST("/account/:id/reconciliation", authMiddleware, reconciliationHandler.Create)
+	r.GinEngine.GET("/account/:id/reconciliation", authMiddleware, reconciliationHandler.List)
 
+    r.GinEngine.GET("/account/:id/access", authMiddleware, accountAccessHandler.List)
+    r.GinEngine.POST("/account/:id/access", authMiddleware, accountAccessHandler.Create)
+    r.GinEngine.PUT("/account/:id/access/:userID", authMiddleware, accountAccessHandler.Update)
+    r.GinEngine.DELETE("/account/:id/access/:userID", authMiddleware, accountAccessHandler.Delete)
 }
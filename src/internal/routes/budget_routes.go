@@ -0,0 +1,25 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupBudgetRouter() {
+	budgetService := services.NewBudgetService(r.Deps.BudgetRepo)
+	budgetHandler := handler.NewBudgetHandler(budgetService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/budget", authMiddleware, budgetHandler.Create)
+	r.GinEngine.GET("/budget", authMiddleware, budgetHandler.List)
+	r.GinEngine.GET("/budget/alert", authMiddleware, budgetHandler.ListAlerts)
+	r.GinEngine.GET("/budget/:id", authMiddleware, budgetHandler.GetByID)
+	r.GinEngine.PUT("/budget/:id", authMiddleware, budgetHandler.Update)
+	r.GinEngine.DELETE("/budget/:id", authMiddleware, budgetHandler.Delete)
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupCategorizationRuleRouter() {
+	categorizationRuleService := services.NewCategorizationRuleService(r.Deps.CategorizationRuleRepo)
+	categorizationRuleHandler := handler.NewCategorizationRuleHandler(categorizationRuleService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/categorization_rule", authMiddleware, categorizationRuleHandler.Create)
+	r.GinEngine.GET("/categorization_rule", authMiddleware, categorizationRuleHandler.List)
+	r.GinEngine.POST("/categorization_rule/apply", authMiddleware, categorizationRuleHandler.Apply)
+	r.GinEngine.GET("/categorization_rule/:id", authMiddleware, categorizationRuleHandler.GetByID)
+	r.GinEngine.PUT("/categorization_rule/:id", authMiddleware, categorizationRuleHandler.Update)
+	r.GinEngine.DELETE("/categorization_rule/:id", authMiddleware, categorizationRuleHandler.Delete)
+}
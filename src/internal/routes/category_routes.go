@@ -9,6 +9,7 @@ import (
 func (r *router) setupCategoryRouter() {
 	categoryService := services.NewCategoryService(
 		r.Deps.CategoryRepo,
+		r.Deps.FinancialGroupRepo,
 	)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
 
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupExchangeRateRouter() {
+	exchangeRateService := services.NewExchangeRateService(r.Deps.ExchangeRateRepo)
+	exchangeRateHandler := handler.NewExchangeRateHandler(exchangeRateService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/exchange_rate", authMiddleware, exchangeRateHandler.Create)
+	r.GinEngine.GET("/exchange_rate", authMiddleware, exchangeRateHandler.List)
+	r.GinEngine.GET("/exchange_rate/:id", authMiddleware, exchangeRateHandler.GetByID)
+	r.GinEngine.PUT("/exchange_rate/:id", authMiddleware, exchangeRateHandler.Update)
+	r.GinEngine.DELETE("/exchange_rate/:id", authMiddleware, exchangeRateHandler.Delete)
+}
@@ -1 +1,32 @@
 package routes
+
+import (
+	"shirinec.com/src/internal/enums"
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupExpenseRouter() {
+	transactionService := services.NewTransactionService(r.Deps.TransactionRepo)
+	expenseHandler := handler.NewTransactionHandler(transactionService, enums.TransactionExpense)
+	purchaseListItemService := services.NewPurchaseListItemService(r.Deps.PurchaseListItemRepo)
+	purchaseListItemHandler := handler.NewPurchaseListItemHandler(purchaseListItemService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/expense", authMiddleware, expenseHandler.Create)
+	r.GinEngine.GET("/expense", authMiddleware, expenseHandler.List)
+	r.GinEngine.GET("/expense/:id", authMiddleware, expenseHandler.GetByID)
+	r.GinEngine.PUT("/expense/:id", authMiddleware, expenseHandler.Update)
+	r.GinEngine.DELETE("/expense/:id", authMiddleware, expenseHandler.Delete)
+
+	r.GinEngine.GET("/expense/:id/item", authMiddleware, purchaseListItemHandler.List)
+	r.GinEngine.POST("/expense/:id/item", authMiddleware, purchaseListItemHandler.Create)
+	r.GinEngine.PUT("/expense/:id/item/:lineID", authMiddleware, purchaseListItemHandler.Update)
+	r.GinEngine.DELETE("/expense/:id/item/:lineID", authMiddleware, purchaseListItemHandler.Delete)
+}
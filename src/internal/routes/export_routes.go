@@ -0,0 +1,22 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupExportRouter() {
+	exportService := services.NewExportService(r.Deps.ExportRepo)
+	exportHandler := handler.NewExportHandler(exportService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.GET("/export", authMiddleware, exportHandler.Export)
+	r.GinEngine.GET("/export/job", authMiddleware, exportHandler.ListJobs)
+	r.GinEngine.GET("/export/job/:id", authMiddleware, exportHandler.GetJob)
+}
@@ -9,6 +9,8 @@ import (
 func (r *router) setupFinancialGroupRouter() {
     financialGroupService := services.NewFinancialGroupService(&r.Deps.FinancialGroupRepo)
     financialGroupHandler := handler.NewFinancialGroupHandler(&financialGroupService)
+    invitationService := services.NewFinancialGroupInvitationService(r.Deps.FinancialGroupInvitationRepo, r.Deps.FinancialGroupRepo)
+    invitationHandler := handler.NewFinancialGroupInvitationHandler(invitationService)
 
     flags := middlewares.AuthMiddleWareFlags{
         ShouldBeActive: true,
@@ -17,9 +19,18 @@ func (r *router) setupFinancialGroupRouter() {
     authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
 
     r.GinEngine.POST("/financial_group", authMiddleware, financialGroupHandler.Create)
-    r.GinEngine.POST("/financial_group/:id/:userID", authMiddleware, financialGroupHandler.AddUser)
     r.GinEngine.GET("/financial_group/:id", authMiddleware, financialGroupHandler.GetByID)
     r.GinEngine.GET("/financial_group", authMiddleware, financialGroupHandler.List)
     r.GinEngine.DELETE("/financial_group/:id", authMiddleware, financialGroupHandler.Delete)
     r.GinEngine.DELETE("/financial_group/:id/:userID", authMiddleware, financialGroupHandler.RemoveGroupMember)
+    r.GinEngine.PUT("/financial_group/:id/:userID", authMiddleware, financialGroupHandler.UpdateMemberRole)
+    r.GinEngine.POST("/financial_group/:id/transfer", authMiddleware, financialGroupHandler.TransferOwnership)
+
+    r.GinEngine.POST("/financial_group/:id/invitation", authMiddleware, invitationHandler.Create)
+    r.GinEngine.GET("/financial_group/:id/invitation", authMiddleware, invitationHandler.ListByGroup)
+    r.GinEngine.DELETE("/financial_group/:id/invitation/:invitationID", authMiddleware, invitationHandler.Revoke)
+
+    r.GinEngine.GET("/invitation", authMiddleware, invitationHandler.ListPending)
+    r.GinEngine.POST("/invitation/:token/accept", authMiddleware, invitationHandler.Accept)
+    r.GinEngine.POST("/invitation/:token/decline", authMiddleware, invitationHandler.Decline)
 }
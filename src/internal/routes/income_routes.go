@@ -1 +1,25 @@
 package routes
+
+import (
+	"shirinec.com/src/internal/enums"
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupIncomeRouter() {
+	transactionService := services.NewTransactionService(r.Deps.TransactionRepo)
+	incomeHandler := handler.NewTransactionHandler(transactionService, enums.TransactionIncome)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/income", authMiddleware, incomeHandler.Create)
+	r.GinEngine.GET("/income", authMiddleware, incomeHandler.List)
+	r.GinEngine.GET("/income/:id", authMiddleware, incomeHandler.GetByID)
+	r.GinEngine.PUT("/income/:id", authMiddleware, incomeHandler.Update)
+	r.GinEngine.DELETE("/income/:id", authMiddleware, incomeHandler.Delete)
+}
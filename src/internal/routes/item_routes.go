@@ -7,7 +7,7 @@ import (
 )
 
 func (r *router) setupItemRouter() {
-	itemService := services.NewItemService(&r.Deps.ItemRepo)
+	itemService := services.NewItemService(&r.Deps.ItemRepo, r.Deps.FinancialGroupRepo)
 	itemHandler := handler.NewItemHandler(&itemService)
 
 	flags := middlewares.AuthMiddleWareFlags{
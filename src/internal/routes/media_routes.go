@@ -7,8 +7,8 @@ import (
 )
 
 func (r *router) setupMediaRouter() {
-	mediaService := services.NewMediaService(r.Deps.MediaRepo, r.Deps.ItemRepo, r.Deps.CategoryRepo)
-	mediaHandler := handler.NewMediaHandler(mediaService)
+	mediaService := services.NewMediaService(r.Deps.MediaRepo, r.Deps.ItemRepo, r.Deps.CategoryRepo, r.Deps.FinancialGroupRepo, r.Deps.Storage)
+	mediaHandler := handler.NewMediaHandler(mediaService, r.Deps.Storage)
 
 	flags := middlewares.AuthMiddleWareFlags{
 		ShouldBeActive: true,
@@ -19,4 +19,7 @@ func (r *router) setupMediaRouter() {
 	r.GinEngine.POST("/media/upload", authMiddleware, mediaHandler.Upload)
     r.GinEngine.GET("/file/:fileName", authMiddleware, mediaHandler.GetMedia)
     r.GinEngine.POST("/file/:fileName", authMiddleware, mediaHandler.UpdateMedia)
+    r.GinEngine.PUT("/file/:fileName/access", authMiddleware, mediaHandler.UpdateAccess)
+    r.GinEngine.PUT("/file/:fileName/group", authMiddleware, mediaHandler.Move)
+    r.GinEngine.DELETE("/file/:fileName", authMiddleware, mediaHandler.Delete)
 }
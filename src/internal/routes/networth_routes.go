@@ -0,0 +1,21 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupNetWorthRouter() {
+	netWorthService := services.NewNetWorthService(r.Deps.NetWorthRepo)
+	netWorthHandler := handler.NewNetWorthHandler(netWorthService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.GET("/networth", authMiddleware, netWorthHandler.History)
+	r.GinEngine.POST("/networth/backfill", authMiddleware, netWorthHandler.Backfill)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupRecurringTransactionRouter() {
+	recurringTransactionService := services.NewRecurringTransactionService(r.Deps.RecurringTransactionRepo)
+	recurringTransactionHandler := handler.NewRecurringTransactionHandler(recurringTransactionService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/recurring_transaction", authMiddleware, recurringTransactionHandler.Create)
+	r.GinEngine.GET("/recurring_transaction", authMiddleware, recurringTransactionHandler.List)
+	r.GinEngine.GET("/recurring_transaction/:id", authMiddleware, recurringTransactionHandler.GetByID)
+	r.GinEngine.PUT("/recurring_transaction/:id", authMiddleware, recurringTransactionHandler.Update)
+	r.GinEngine.DELETE("/recurring_transaction/:id", authMiddleware, recurringTransactionHandler.Delete)
+	r.GinEngine.POST("/recurring_transaction/:id/pause", authMiddleware, recurringTransactionHandler.Pause)
+	r.GinEngine.POST("/recurring_transaction/:id/resume", authMiddleware, recurringTransactionHandler.Resume)
+	r.GinEngine.GET("/recurring_transaction/:id/occurrence", authMiddleware, recurringTransactionHandler.ListOccurrences)
+	r.GinEngine.PUT("/recurring_transaction/:id/occurrence/:date", authMiddleware, recurringTransactionHandler.SetOverride)
+	r.GinEngine.DELETE("/recurring_transaction/:id/occurrence/:date", authMiddleware, recurringTransactionHandler.DeleteOverride)
+}
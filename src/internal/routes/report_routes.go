@@ -0,0 +1,23 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupReportRouter() {
+	reportService := services.NewReportService(r.Deps.ReportRepo)
+	reportHandler := handler.NewReportHandler(reportService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.GET("/report/summary", authMiddleware, reportHandler.Summary)
+	r.GinEngine.GET("/report/breakdown", authMiddleware, reportHandler.Breakdown)
+	r.GinEngine.GET("/report/comparison", authMiddleware, reportHandler.Comparison)
+	r.GinEngine.GET("/report/top", authMiddleware, reportHandler.Top)
+}
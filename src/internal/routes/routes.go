@@ -16,6 +16,17 @@ type Router interface {
 	setupMediaRouter()
 	setupFinancialGroupRouter()
     setupTransactionRouter()
+	setupIncomeRouter()
+	setupExpenseRouter()
+	setupExchangeRateRouter()
+	setupBudgetRouter()
+	setupRecurringTransactionRouter()
+	setupScheduledTransferRouter()
+	setupStatementImportRouter()
+	setupCategorizationRuleRouter()
+	setupExportRouter()
+	setupReportRouter()
+	setupNetWorthRouter()
 }
 
 type router struct {
@@ -41,4 +52,15 @@ func (r *router) SetupRouter() {
 	r.setupMediaRouter()
 	r.setupFinancialGroupRouter()
     r.setupTransactionRouter()
+	r.setupIncomeRouter()
+	r.setupExpenseRouter()
+	r.setupExchangeRateRouter()
+	r.setupBudgetRouter()
+	r.setupRecurringTransactionRouter()
+	r.setupScheduledTransferRouter()
+	r.setupStatementImportRouter()
+	r.setupCategorizationRuleRouter()
+	r.setupExportRouter()
+	r.setupReportRouter()
+	r.setupNetWorthRouter()
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupScheduledTransferRouter() {
+	scheduledTransferService := services.NewScheduledTransferService(r.Deps.ScheduledTransferRepo)
+	scheduledTransferHandler := handler.NewScheduledTransferHandler(scheduledTransferService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/scheduled_transfer", authMiddleware, scheduledTransferHandler.Create)
+	r.GinEngine.GET("/scheduled_transfer", authMiddleware, scheduledTransferHandler.List)
+	r.GinEngine.GET("/scheduled_transfer/:id", authMiddleware, scheduledTransferHandler.GetByID)
+	r.GinEngine.DELETE("/scheduled_transfer/:id", authMiddleware, scheduledTransferHandler.Cancel)
+	r.GinEngine.GET("/scheduled_transfer/:id/run", authMiddleware, scheduledTransferHandler.ListRuns)
+}
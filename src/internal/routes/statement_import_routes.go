@@ -0,0 +1,24 @@
+package routes
+
+import (
+	handler "shirinec.com/src/internal/handlers"
+	"shirinec.com/src/internal/middlewares"
+	"shirinec.com/src/internal/services"
+)
+
+func (r *router) setupStatementImportRouter() {
+	statementImportService := services.NewStatementImportService(r.Deps.StatementImportRepo)
+	statementImportHandler := handler.NewStatementImportHandler(statementImportService)
+
+	flags := middlewares.AuthMiddleWareFlags{
+		ShouldBeActive: true,
+	}
+
+	authMiddleware := middlewares.AuthMiddleWare(flags, r.db)
+
+	r.GinEngine.POST("/import", authMiddleware, statementImportHandler.Create)
+	r.GinEngine.GET("/import", authMiddleware, statementImportHandler.List)
+	r.GinEngine.GET("/import/:id", authMiddleware, statementImportHandler.GetByID)
+	r.GinEngine.DELETE("/import/:id", authMiddleware, statementImportHandler.Delete)
+	r.GinEngine.POST("/import/:id/commit", authMiddleware, statementImportHandler.Commit)
+}
@@ -7,8 +7,12 @@ import (
 )
 
 func (r *router) setupTransactionRouter() {
-    transferService := services.NewTransferService(r.Deps.TransactionRepo)
+    transferService := services.NewTransferService(r.Deps.TransactionRepo, r.Deps.AccountRepo, r.Deps.ExchangeRateRepo)
     transferHandler := handler.NewTransferHandler(transferService)
+    transactionService := services.NewTransactionService(r.Deps.TransactionRepo)
+    ledgerHandler := handler.NewTransactionHandler(transactionService, "")
+    transactionAttachmentService := services.NewTransactionAttachmentService(r.Deps.TransactionAttachmentRepo)
+    transactionAttachmentHandler := handler.NewTransactionAttachmentHandler(transactionAttachmentService)
 
     flags := middlewares.AuthMiddleWareFlags{
         ShouldBeActive: true,
@@ -17,4 +21,10 @@ func (r *router) setupTransactionRouter() {
     authMiddleWare := middlewares.AuthMiddleWare(flags, r.db)
 
     r.GinEngine.POST("/transfer", authMiddleWare, transferHandler.Transfer)
+    r.GinEngine.GET("/transaction", authMiddleWare, ledgerHandler.List)
+    r.GinEngine.POST("/transaction/:id/reverse", authMiddleWare, ledgerHandler.Reverse)
+
+    r.GinEngine.GET("/transaction/:id/attachment", authMiddleWare, transactionAttachmentHandler.List)
+    r.GinEngine.POST("/transaction/:id/attachment", authMiddleWare, transactionAttachmentHandler.Attach)
+    r.GinEngine.DELETE("/transaction/:id/attachment/:mediaID", authMiddleWare, transactionAttachmentHandler.Detach)
 }
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type AccountAccessService interface {
+	List(ctx context.Context, accountID int, userID uuid.UUID) (*dto.AccountAccessListResponse, error)
+	Create(ctx context.Context, input *dto.AccountAccessCreateRequest, accountID int, userID uuid.UUID) (*dto.AccountAccessResponse, error)
+	Update(ctx context.Context, input *dto.AccountAccessUpdateRequest, accountID int, targetUserID, userID uuid.UUID) (*dto.AccountAccessResponse, error)
+	Delete(ctx context.Context, accountID int, targetUserID, userID uuid.UUID) error
+}
+
+type accountAccessService struct {
+	accountAccessRepo repositories.AccountAccessRepository
+}
+
+func NewAccountAccessService(accountAccessRepo repositories.AccountAccessRepository) AccountAccessService {
+	return &accountAccessService{accountAccessRepo: accountAccessRepo}
+}
+
+func (s *accountAccessService) List(ctx context.Context, accountID int, userID uuid.UUID) (*dto.AccountAccessListResponse, error) {
+	accountAccessList, err := s.accountAccessRepo.List(ctx, accountID, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("accountAccessService.List - Calling accountAccessRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &dto.AccountAccessListResponse{AccountAccess: accountAccessList}, nil
+}
+
+func (s *accountAccessService) Create(ctx context.Context, input *dto.AccountAccessCreateRequest, accountID int, userID uuid.UUID) (*dto.AccountAccessResponse, error) {
+	var accountAccess models.AccountAccess
+	accountAccess.AccountID = accountID
+	accountAccess.Access = input.Access
+
+	result, err := s.accountAccessRepo.Create(ctx, &accountAccess, input.Email, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("accountAccessService.Create - Calling accountAccessRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return result, nil
+}
+
+func (s *accountAccessService) Update(ctx context.Context, input *dto.AccountAccessUpdateRequest, accountID int, targetUserID, userID uuid.UUID) (*dto.AccountAccessResponse, error) {
+	var accountAccess models.AccountAccess
+	accountAccess.AccountID = accountID
+	accountAccess.UserID = targetUserID
+	accountAccess.Access = input.Access
+
+	result, err := s.accountAccessRepo.Update(ctx, &accountAccess, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("accountAccessService.Update - Calling accountAccessRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return result, nil
+}
+
+func (s *accountAccessService) Delete(ctx context.Context, accountID int, targetUserID, userID uuid.UUID) error {
+	if err := s.accountAccessRepo.Delete(ctx, accountID, targetUserID, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("accountAccessService.Delete - Calling accountAccessRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
 	"shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/models"
 	"shirinec.com/src/internal/repositories"
@@ -20,26 +21,43 @@ type AccountService interface {
 	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.AccountJoinedResponse, error)
 	Update(ctx context.Context, input *dto.AccountUpdateRequest, id int, userID uuid.UUID) (*dto.AccountJoinedResponse, error)
 	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	SetOpeningBalance(ctx context.Context, input *dto.AccountOpeningBalanceRequest, id int, userID uuid.UUID) (*dto.AccountJoinedResponse, error)
 }
 
 type accountService struct {
-	accountRepo repositories.AccountRepository
+	accountRepo        repositories.AccountRepository
+	financialGroupRepo repositories.FinancialGroupRepository
 }
 
-func NewAccountService(accountRepo *repositories.AccountRepository) AccountService {
-	return &accountService{accountRepo: *accountRepo}
+func NewAccountService(accountRepo *repositories.AccountRepository, financialGroupRepo repositories.FinancialGroupRepository) AccountService {
+	return &accountService{accountRepo: *accountRepo, financialGroupRepo: financialGroupRepo}
 }
 
+// Create adds a personal account, or a group account when the user is at least
+// a contributor of the group.
 func (s *accountService) Create(ctx context.Context, input *dto.AccountCreateRequest, userID uuid.UUID) (*models.Account, error) {
+	if input.FinancialGroupID != nil {
+		if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, *input.FinancialGroupID, userID, enums.FinancialGroupContributor, "accountService.Create"); err != nil {
+			return nil, err
+		}
+	}
+
 	var account models.Account
 	account.UserID = userID
 	account.CategoryID = &input.CategoryID
 	account.Name = &input.Name
 	account.Balance = &input.Balance
 	account.Type = &input.Type
+	account.Currency = &input.Currency
+	account.FinancialGroupID = input.FinancialGroupID
 
 	err := s.accountRepo.Create(ctx, &account)
 	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
 		pgErr := server_errors.AsPgError(err)
 		if pgErr != nil {
 			return nil, pgErr
@@ -98,6 +116,11 @@ func (s *accountService) GetByID(ctx context.Context, id int, userID uuid.UUID)
 func (s *accountService) Delete(ctx context.Context, id int, userID uuid.UUID) error {
 	err := s.accountRepo.Delete(ctx, id, userID)
 	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return &server_errors.ItemNotFound
 		}
@@ -118,6 +141,11 @@ func (s *accountService) Update(ctx context.Context, input *dto.AccountUpdateReq
 
 	accountJoined, err := s.accountRepo.Update(ctx, &account)
 	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, &server_errors.ItemNotFound
 		}
@@ -132,3 +160,26 @@ func (s *accountService) Update(ctx context.Context, input *dto.AccountUpdateReq
 
 	return accountJoined, nil
 }
+
+func (s *accountService) SetOpeningBalance(ctx context.Context, input *dto.AccountOpeningBalanceRequest, id int, userID uuid.UUID) (*dto.AccountJoinedResponse, error) {
+	accountJoined, err := s.accountRepo.SetOpeningBalance(ctx, id, input, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("accountService.SetOpeningBalance - Calling accountRepo.SetOpeningBalance: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return accountJoined, nil
+}
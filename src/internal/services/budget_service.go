@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+// defaultBudgetAlertThreshold is the percent of the limit a budget is flagged
+// at when the user does not choose one.
+const defaultBudgetAlertThreshold = 80
+
+type BudgetService interface {
+	Create(ctx context.Context, input *dto.BudgetCreateRequest, userID uuid.UUID) (*dto.BudgetResponse, error)
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.BudgetResponse, error)
+	List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.BudgetListResponse, error)
+	Update(ctx context.Context, input *dto.BudgetUpdateRequest, id int, userID uuid.UUID) (*dto.BudgetResponse, error)
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	ListAlerts(ctx context.Context, page, size int, userID uuid.UUID) (*dto.BudgetAlertListResponse, error)
+}
+
+type budgetService struct {
+	budgetRepo repositories.BudgetRepository
+}
+
+func NewBudgetService(budgetRepo repositories.BudgetRepository) BudgetService {
+	return &budgetService{budgetRepo: budgetRepo}
+}
+
+func (s *budgetService) Create(ctx context.Context, input *dto.BudgetCreateRequest, userID uuid.UUID) (*dto.BudgetResponse, error) {
+	var budget models.Budget
+	budget.UserID = userID
+	budget.CategoryID = input.CategoryID
+	budget.Amount = input.Amount
+	budget.Period = input.Period
+	budget.Rollover = input.Rollover
+	budget.AlertThreshold = defaultBudgetAlertThreshold
+	if input.AlertThreshold != nil {
+		budget.AlertThreshold = *input.AlertThreshold
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	budget.StartDate = today
+	if input.StartDate != nil {
+		budget.StartDate = input.StartDate.UTC().Truncate(24 * time.Hour)
+	}
+
+	if input.EndDate != nil {
+		endDate := input.EndDate.UTC().Truncate(24 * time.Hour)
+		budget.EndDate = &endDate
+	}
+
+	// Only custom budgets end, their range must not be empty
+	if (budget.Period == enums.BudgetCustom) != (budget.EndDate != nil) {
+		return nil, &server_errors.InvalidBudgetPeriod
+	}
+	if budget.EndDate != nil && budget.EndDate.Before(budget.StartDate) {
+		return nil, &server_errors.InvalidBudgetPeriod
+	}
+
+	if err := s.budgetRepo.Create(ctx, &budget); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("budgetService.Create - Calling budgetRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, budget.ID, userID)
+}
+
+func (s *budgetService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.BudgetResponse, error) {
+	budget, err := s.budgetRepo.GetByID(ctx, id, userID, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("budgetService.GetByID - Calling budgetRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return budget, nil
+}
+
+func (s *budgetService) List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.BudgetListResponse, error) {
+	limit := size
+	offset := page * size
+	budgets, totalCount, err := s.budgetRepo.List(ctx, limit, offset, userID, time.Now().UTC())
+	if err != nil {
+		utils.Logger.Errorf("budgetService.List - Calling budgetRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.BudgetListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Budgets = budgets
+
+	return &response, nil
+}
+
+func (s *budgetService) Update(ctx context.Context, input *dto.BudgetUpdateRequest, id int, userID uuid.UUID) (*dto.BudgetResponse, error) {
+	if err := s.budgetRepo.Update(ctx, id, userID, input); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("budgetService.Update - Calling budgetRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+func (s *budgetService) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	if err := s.budgetRepo.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("budgetService.Delete - Calling budgetRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
+func (s *budgetService) ListAlerts(ctx context.Context, page, size int, userID uuid.UUID) (*dto.BudgetAlertListResponse, error) {
+	limit := size
+	offset := page * size
+	alerts, totalCount, err := s.budgetRepo.ListAlerts(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("budgetService.ListAlerts - Calling budgetRepo.ListAlerts: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.BudgetAlertListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Alerts = alerts
+
+	return &response, nil
+}
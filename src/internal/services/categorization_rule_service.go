@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type CategorizationRuleService interface {
+	Create(ctx context.Context, input *dto.CategorizationRuleRequest, userID uuid.UUID) (*dto.CategorizationRuleResponse, error)
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.CategorizationRuleResponse, error)
+	List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.CategorizationRuleListResponse, error)
+	Update(ctx context.Context, input *dto.CategorizationRuleRequest, id int, userID uuid.UUID) (*dto.CategorizationRuleResponse, error)
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	Apply(ctx context.Context, input *dto.CategorizationRuleApplyRequest, userID uuid.UUID) (*dto.CategorizationRuleApplyResponse, error)
+}
+
+type categorizationRuleService struct {
+	categorizationRuleRepo repositories.CategorizationRuleRepository
+}
+
+func NewCategorizationRuleService(categorizationRuleRepo repositories.CategorizationRuleRepository) CategorizationRuleService {
+	return &categorizationRuleService{categorizationRuleRepo: categorizationRuleRepo}
+}
+
+// validateCategorizationRule trims the description condition and makes sure
+// the rule does not match every transaction of its type.
+func validateCategorizationRule(input *dto.CategorizationRuleRequest) error {
+	if input.DescriptionContains != nil {
+		description := strings.TrimSpace(*input.DescriptionContains)
+		input.DescriptionContains = &description
+		if description == "" {
+			input.DescriptionContains = nil
+		}
+	}
+
+	if input.AccountID == nil && input.DescriptionContains == nil && input.MinAmount == nil && input.MaxAmount == nil {
+		return &server_errors.EmptyCategorizationRule
+	}
+	if input.MinAmount != nil && input.MaxAmount != nil && *input.MinAmount > *input.MaxAmount {
+		return &server_errors.InvalidAmountRange
+	}
+	return nil
+}
+
+func (s *categorizationRuleService) Create(ctx context.Context, input *dto.CategorizationRuleRequest, userID uuid.UUID) (*dto.CategorizationRuleResponse, error) {
+	if err := validateCategorizationRule(input); err != nil {
+		return nil, err
+	}
+
+	var rule models.CategorizationRule
+	rule.UserID = userID
+	rule.CategoryID = input.CategoryID
+	rule.TransactionType = input.Type
+	rule.AccountID = input.AccountID
+	rule.DescriptionContains = input.DescriptionContains
+	rule.MinAmount = input.MinAmount
+	rule.MaxAmount = input.MaxAmount
+
+	if input.Priority != nil {
+		rule.Priority = *input.Priority
+	} else {
+		priority, err := s.categorizationRuleRepo.NextPriority(ctx, userID)
+		if err != nil {
+			utils.Logger.Errorf("categorizationRuleService.Create - Calling categorizationRuleRepo.NextPriority: %s", err.Error())
+			return nil, &server_errors.InternalError
+		}
+		rule.Priority = priority
+	}
+
+	if err := s.categorizationRuleRepo.Create(ctx, &rule); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("categorizationRuleService.Create - Calling categorizationRuleRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, rule.ID, userID)
+}
+
+func (s *categorizationRuleService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.CategorizationRuleResponse, error) {
+	rule, err := s.categorizationRuleRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("categorizationRuleService.GetByID - Calling categorizationRuleRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return rule, nil
+}
+
+func (s *categorizationRuleService) List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.CategorizationRuleListResponse, error) {
+	limit := size
+	offset := page * size
+	rules, totalCount, err := s.categorizationRuleRepo.List(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleService.List - Calling categorizationRuleRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.CategorizationRuleListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Rules = rules
+
+	return &response, nil
+}
+
+func (s *categorizationRuleService) Update(ctx context.Context, input *dto.CategorizationRuleRequest, id int, userID uuid.UUID) (*dto.CategorizationRuleResponse, error) {
+	if err := validateCategorizationRule(input); err != nil {
+		return nil, err
+	}
+
+	if err := s.categorizationRuleRepo.Update(ctx, id, userID, input); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("categorizationRuleService.Update - Calling categorizationRuleRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+func (s *categorizationRuleService) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	if err := s.categorizationRuleRepo.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("categorizationRuleService.Delete - Calling categorizationRuleRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
+func (s *categorizationRuleService) Apply(ctx context.Context, input *dto.CategorizationRuleApplyRequest, userID uuid.UUID) (*dto.CategorizationRuleApplyResponse, error) {
+	if input.To != nil {
+		// "to" is a day, including all of it means comparing against the next midnight
+		to := input.To.AddDate(0, 0, 1)
+		input.To = &to
+	}
+
+	updated, err := s.categorizationRuleRepo.Apply(ctx, input, userID)
+	if err != nil {
+		utils.Logger.Errorf("categorizationRuleService.Apply - Calling categorizationRuleRepo.Apply: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &dto.CategorizationRuleApplyResponse{Updated: updated}, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
 	"shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/models"
 	"shirinec.com/src/internal/repositories"
@@ -23,15 +24,38 @@ type CategoryService interface {
 }
 
 type categoryService struct {
-	categoryRepo repositories.CategoryRepository
+	categoryRepo       repositories.CategoryRepository
+	financialGroupRepo repositories.FinancialGroupRepository
 }
 
-func NewCategoryService(categoryRepo repositories.CategoryRepository) CategoryService {
-	return &categoryService{categoryRepo: categoryRepo}
+func NewCategoryService(categoryRepo repositories.CategoryRepository, financialGroupRepo repositories.FinancialGroupRepository) CategoryService {
+	return &categoryService{categoryRepo: categoryRepo, financialGroupRepo: financialGroupRepo}
 }
 
+// Create adds a personal category, or a group category when the user is at
+// least a contributor of the group.
 func (s *categoryService) Create(category *models.Category) error {
-	return s.categoryRepo.Create(context.Background(), category)
+	if category.FinancialGroupID != nil {
+		if err := requireFinancialGroupRole(context.Background(), s.financialGroupRepo, *category.FinancialGroupID, category.UserID, enums.FinancialGroupContributor, "CategoryService.Create"); err != nil {
+			return err
+		}
+	}
+
+	err := s.categoryRepo.Create(context.Background(), category)
+	if err != nil {
+		var sError *server_errors.SError
+		if errors.As(err, &sError) {
+			return sError
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("CategoryService.Create - Calling categoryRepo.Create: %s", err.Error())
+		return &server_errors.InternalError
+	}
+	return nil
 }
 
 func (s *categoryService) ListCategories(userID uuid.UUID, page int, size int) (*dto.CategoriesListResponse, error) {
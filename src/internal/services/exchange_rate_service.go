@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type ExchangeRateService interface {
+	Create(ctx context.Context, input *dto.ExchangeRateCreateRequest, userID uuid.UUID) (*models.ExchangeRate, error)
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*models.ExchangeRate, error)
+	List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.ExchangeRateListResponse, error)
+	Update(ctx context.Context, input *dto.ExchangeRateUpdateRequest, id int, userID uuid.UUID) (*models.ExchangeRate, error)
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+}
+
+type exchangeRateService struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateService(exchangeRateRepo repositories.ExchangeRateRepository) ExchangeRateService {
+	return &exchangeRateService{exchangeRateRepo: exchangeRateRepo}
+}
+
+func (s *exchangeRateService) Create(ctx context.Context, input *dto.ExchangeRateCreateRequest, userID uuid.UUID) (*models.ExchangeRate, error) {
+	var exchangeRate models.ExchangeRate
+	exchangeRate.UserID = userID
+	exchangeRate.BaseCurrency = input.BaseCurrency
+	exchangeRate.QuoteCurrency = input.QuoteCurrency
+	exchangeRate.Rate = input.Rate
+
+	if err := s.exchangeRateRepo.Upsert(ctx, &exchangeRate); err != nil {
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+		utils.Logger.Errorf("exchangeRateService.Create - Calling exchangeRateRepo.Upsert: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &exchangeRate, nil
+}
+
+func (s *exchangeRateService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*models.ExchangeRate, error) {
+	exchangeRate, err := s.exchangeRateRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("exchangeRateService.GetByID - Calling exchangeRateRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return exchangeRate, nil
+}
+
+func (s *exchangeRateService) List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.ExchangeRateListResponse, error) {
+	limit := size
+	offset := page * size
+	exchangeRates, totalCount, err := s.exchangeRateRepo.List(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("exchangeRateService.List - Calling exchangeRateRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.ExchangeRateListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.ExchangeRates = exchangeRates
+
+	return &response, nil
+}
+
+func (s *exchangeRateService) Update(ctx context.Context, input *dto.ExchangeRateUpdateRequest, id int, userID uuid.UUID) (*models.ExchangeRate, error) {
+	var exchangeRate models.ExchangeRate
+	exchangeRate.ID = id
+	exchangeRate.UserID = userID
+	exchangeRate.Rate = input.Rate
+
+	if err := s.exchangeRateRepo.Update(ctx, &exchangeRate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+		utils.Logger.Errorf("exchangeRateService.Update - Calling exchangeRateRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &exchangeRate, nil
+}
+
+func (s *exchangeRateService) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	if err := s.exchangeRateRepo.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("exchangeRateService.Delete - Calling exchangeRateRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/config"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/export"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type ExportService interface {
+	Export(ctx context.Context, input *dto.ExportRequest, userID uuid.UUID) (*dto.ExportJobResponse, error)
+	Write(ctx context.Context, input *dto.ExportRequest, userID uuid.UUID, exportDate time.Time, w io.Writer) error
+	GetJob(ctx context.Context, id int, userID uuid.UUID) (*dto.ExportJobResponse, error)
+	ListJobs(ctx context.Context, page, size int, userID uuid.UUID) (*dto.ExportJobListResponse, error)
+}
+
+type exportService struct {
+	exportRepo repositories.ExportRepository
+}
+
+func NewExportService(exportRepo repositories.ExportRepository) ExportService {
+	return &exportService{exportRepo: exportRepo}
+}
+
+// Export queues the export as a job for the export worker when it has more
+// than ExportSyncLimit transactions and returns the job, smaller exports
+// return no job and are written right away with Write.
+func (s *exportService) Export(ctx context.Context, input *dto.ExportRequest, userID uuid.UUID) (*dto.ExportJobResponse, error) {
+	if input.From != nil && input.To != nil && input.From.After(*input.To) {
+		return nil, &server_errors.InvalidDateRange
+	}
+
+	count, err := s.exportRepo.CountTransactions(ctx, userID, input.From, input.To)
+	if err != nil {
+		utils.Logger.Errorf("exportService.Export - Calling exportRepo.CountTransactions: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	if count <= config.AppConfig.ExportSyncLimit {
+		return nil, nil
+	}
+
+	job := models.ExportJob{
+		UserID:   userID,
+		Format:   input.Format,
+		FromDate: input.From,
+		ToDate:   input.To,
+	}
+	if err := s.exportRepo.CreateJob(ctx, &job); err != nil {
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("exportService.Export - Calling exportRepo.CreateJob: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	return &dto.ExportJobResponse{ExportJob: job}, nil
+}
+
+// Write streams the export to w record by record as the rows are read.
+func (s *exportService) Write(ctx context.Context, input *dto.ExportRequest, userID uuid.UUID, exportDate time.Time, w io.Writer) error {
+	header := dto.ExportHeader{ExportDate: exportDate, From: input.From, To: input.To}
+	writer, err := export.NewWriter(input.Format, w, &header)
+	if err != nil {
+		utils.Logger.Errorf("exportService.Write - Calling export.NewWriter: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	if err := s.exportRepo.Stream(ctx, userID, input.From, input.To, writer); err != nil {
+		utils.Logger.Errorf("exportService.Write - Calling exportRepo.Stream: %s", err.Error())
+		return &server_errors.InternalError
+	}
+	if err := writer.Close(); err != nil {
+		utils.Logger.Errorf("exportService.Write - Closing export writer: %s", err.Error())
+		return &server_errors.InternalError
+	}
+	return nil
+}
+
+func (s *exportService) GetJob(ctx context.Context, id int, userID uuid.UUID) (*dto.ExportJobResponse, error) {
+	job, err := s.exportRepo.GetJob(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("exportService.GetJob - Calling exportRepo.GetJob: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return job, nil
+}
+
+func (s *exportService) ListJobs(ctx context.Context, page, size int, userID uuid.UUID) (*dto.ExportJobListResponse, error) {
+	limit := size
+	offset := page * size
+	jobs, totalCount, err := s.exportRepo.ListJobs(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("exportService.ListJobs - Calling exportRepo.ListJobs: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.ExportJobListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Jobs = jobs
+
+	return &response, nil
+}
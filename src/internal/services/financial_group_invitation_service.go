@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/config"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+// invitationTokenSize is the number of random bytes of an invitation token,
+// it is hex encoded to 64 characters.
+const invitationTokenSize = 32
+
+type FinancialGroupInvitationService interface {
+	Create(ctx context.Context, input *dto.FinancialGroupInvitationCreateRequest, financialGroupID int, userID uuid.UUID) (*dto.FinancialGroupInvitationResponse, error)
+	ListByGroup(ctx context.Context, financialGroupID int, userID uuid.UUID) (*dto.FinancialGroupInvitationListResponse, error)
+	ListPending(ctx context.Context, userID uuid.UUID) (*dto.FinancialGroupInvitationListResponse, error)
+	Accept(ctx context.Context, token string, userID uuid.UUID) (*dto.FinancialGroupInvitationResponse, error)
+	Decline(ctx context.Context, token string, userID uuid.UUID) error
+	Revoke(ctx context.Context, id, financialGroupID int, userID uuid.UUID) error
+}
+
+type financialGroupInvitationService struct {
+	invitationRepo     repositories.FinancialGroupInvitationRepository
+	financialGroupRepo repositories.FinancialGroupRepository
+}
+
+func NewFinancialGroupInvitationService(invitationRepo repositories.FinancialGroupInvitationRepository, financialGroupRepo repositories.FinancialGroupRepository) FinancialGroupInvitationService {
+	return &financialGroupInvitationService{invitationRepo: invitationRepo, financialGroupRepo: financialGroupRepo}
+}
+
+// Create invites the email to the group, only admins can invite.
+func (s *financialGroupInvitationService) Create(ctx context.Context, input *dto.FinancialGroupInvitationCreateRequest, financialGroupID int, userID uuid.UUID) (*dto.FinancialGroupInvitationResponse, error) {
+	if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, financialGroupID, userID, enums.FinancialGroupAdmin, "financialGroupInvitationService.Create"); err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateToken(invitationTokenSize)
+	if err != nil {
+		utils.Logger.Errorf("financialGroupInvitationService.Create - Generating invitation token: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	var invitation models.FinancialGroupInvitation
+	invitation.FinancialGroupID = financialGroupID
+	invitation.InvitedBy = userID
+	invitation.Email = input.Email
+	invitation.Token = token
+	invitation.Role = input.Role
+	if invitation.Role == "" {
+		invitation.Role = enums.FinancialGroupContributor
+	}
+	invitation.ExpiresAt = time.Now().UTC().Add(config.AppConfig.InvitationDuration).Truncate(time.Second)
+
+	result, err := s.invitationRepo.Create(ctx, &invitation)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupInvitationService.Create - Calling invitationRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	return result, nil
+}
+
+// ListByGroup returns the pending invitations of a group to its admins.
+func (s *financialGroupInvitationService) ListByGroup(ctx context.Context, financialGroupID int, userID uuid.UUID) (*dto.FinancialGroupInvitationListResponse, error) {
+	if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, financialGroupID, userID, enums.FinancialGroupAdmin, "financialGroupInvitationService.ListByGroup"); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.ListByGroup(ctx, financialGroupID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupInvitationService.ListByGroup - Calling invitationRepo.ListByGroup: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	return &dto.FinancialGroupInvitationListResponse{Invitations: invitations}, nil
+}
+
+func (s *financialGroupInvitationService) ListPending(ctx context.Context, userID uuid.UUID) (*dto.FinancialGroupInvitationListResponse, error) {
+	invitations, err := s.invitationRepo.ListPending(ctx, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupInvitationService.ListPending - Calling invitationRepo.ListPending: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	return &dto.FinancialGroupInvitationListResponse{Invitations: invitations}, nil
+}
+
+func (s *financialGroupInvitationService) Accept(ctx context.Context, token string, userID uuid.UUID) (*dto.FinancialGroupInvitationResponse, error) {
+	result, err := s.invitationRepo.Accept(ctx, token, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupInvitationService.Accept - Calling invitationRepo.Accept: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	return result, nil
+}
+
+func (s *financialGroupInvitationService) Decline(ctx context.Context, token string, userID uuid.UUID) error {
+	if err := s.invitationRepo.Decline(ctx, token, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupInvitationService.Decline - Calling invitationRepo.Decline: %s", err.Error())
+		return &server_errors.InternalError
+	}
+	return nil
+}
+
+// Revoke cancels a pending invitation of a group the user administers.
+func (s *financialGroupInvitationService) Revoke(ctx context.Context, id, financialGroupID int, userID uuid.UUID) error {
+	if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, financialGroupID, userID, enums.FinancialGroupAdmin, "financialGroupInvitationService.Revoke"); err != nil {
+		return err
+	}
+
+	if err := s.invitationRepo.Revoke(ctx, id, financialGroupID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupInvitationService.Revoke - Calling invitationRepo.Revoke: %s", err.Error())
+		return &server_errors.InternalError
+	}
+	return nil
+}
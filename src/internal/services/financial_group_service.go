@@ -18,10 +18,11 @@ import (
 
 type FinancialGroupService interface {
 	Create(ctx context.Context, input *dto.FinancialGroupCreateRequest, userID uuid.UUID) (*models.FinancialGroups, error)
-	AddUserToGroup(ctx context.Context, financialGroupID int, newUserID uuid.UUID, userID uuid.UUID) error
 	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.FinancialGroup, error)
 	List(ctx context.Context, input dto.FinancialGroupListRequest, userID uuid.UUID) (*dto.FinancialGroupListResponse, error)
 	RemoveGroupMember(ctx context.Context, financialGroupID int, memberID, userID uuid.UUID) error
+	UpdateMemberRole(ctx context.Context, input *dto.FinancialGroupMemberRoleRequest, financialGroupID int, memberID, userID uuid.UUID) error
+	TransferOwnership(ctx context.Context, input *dto.FinancialGroupTransferRequest, financialGroupID int, userID uuid.UUID) error
 	Delete(ctx context.Context, financialGroupID int, userID uuid.UUID) error
 }
 
@@ -56,32 +57,6 @@ func (s *financialGroupService) Create(ctx context.Context, input *dto.Financial
 	return &financialGroup, nil
 }
 
-func (s *financialGroupService) AddUserToGroup(ctx context.Context, financialGroupID int, newUserID uuid.UUID, userID uuid.UUID) error {
-	// First checking to see if user is owner of selected group by getting group by id
-	_, err := s.financialGroupRepo.GetOwnedGroupByID(ctx, financialGroupID, userID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return &server_errors.ItemNotFound
-		}
-		if pgErr := server_errors.AsPgError(err); pgErr != nil {
-			return pgErr
-		}
-		utils.Logger.Errorf("financialGroupService.AddUserToGroup - Calling financialGroupRep.GetByID(%d): %s", financialGroupID, err)
-		return &server_errors.InternalError
-	}
-
-	if err = s.financialGroupRepo.AddUserToGroup(ctx, financialGroupID, newUserID); err != nil {
-		if pgErr := server_errors.AsPgError(err); pgErr != nil {
-			return pgErr
-		}
-
-		utils.Logger.Errorf("financialGroupService.AddUserToGroup - Calling financialGroupRepo.AddUserToGroup: (id: %d) %s", financialGroupID, err.Error())
-		return &server_errors.InternalError
-	}
-
-	return nil
-}
-
 func (s *financialGroupService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.FinancialGroup, error) {
 	financialGroup, err := s.financialGroupRepo.GetRelatedGroupByID(ctx, id, userID)
 	if err != nil {
@@ -100,7 +75,11 @@ func (s *financialGroupService) GetByID(ctx context.Context, id int, userID uuid
 	if userID == financialGroup.OwnerID {
 		financialGroup.UserRole = enums.FinancialGroupOwner
 	} else {
-		financialGroup.UserRole = enums.FinancialGroupMember
+		for _, member := range financialGroup.Users {
+			if member.ID == userID {
+				financialGroup.UserRole = member.Role
+			}
+		}
 	}
 
 	return financialGroup, nil
@@ -145,8 +124,16 @@ func (s *financialGroupService) List(ctx context.Context, input dto.FinancialGro
 	return &response, nil
 }
 
+// RemoveGroupMember lets members leave the group, removing someone else needs
+// an admin.
 func (s *financialGroupService) RemoveGroupMember(ctx context.Context, financialGroupID int, memberID, userID uuid.UUID) error {
-	if err := s.financialGroupRepo.RemoveGroupMember(ctx, financialGroupID, memberID, userID); err != nil {
+	if userID != memberID {
+		if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, financialGroupID, userID, enums.FinancialGroupAdmin, "financialGroupService.RemoveGroupMember"); err != nil {
+			return err
+		}
+	}
+
+	if err := s.financialGroupRepo.RemoveGroupMember(ctx, financialGroupID, memberID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return &server_errors.ItemNotFound
 		}
@@ -167,6 +154,55 @@ func (s *financialGroupService) RemoveGroupMember(ctx context.Context, financial
 	return nil
 }
 
+// UpdateMemberRole changes the role of a member, it needs an admin.
+func (s *financialGroupService) UpdateMemberRole(ctx context.Context, input *dto.FinancialGroupMemberRoleRequest, financialGroupID int, memberID, userID uuid.UUID) error {
+	if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, financialGroupID, userID, enums.FinancialGroupAdmin, "financialGroupService.UpdateMemberRole"); err != nil {
+		return err
+	}
+
+	if err := s.financialGroupRepo.UpdateMemberRole(ctx, financialGroupID, memberID, input.Role); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupService.UpdateMemberRole - Calling financialGroupRepo.UpdateMemberRole: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
+func (s *financialGroupService) TransferOwnership(ctx context.Context, input *dto.FinancialGroupTransferRequest, financialGroupID int, userID uuid.UUID) error {
+	if err := s.financialGroupRepo.TransferOwnership(ctx, financialGroupID, input.UserID, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("financialGroupService.TransferOwnership - Calling financialGroupRepo.TransferOwnership: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
 func (s *financialGroupService) Delete(ctx context.Context, financialGroupID int, userID uuid.UUID) error {
 	financialGroup, err := s.financialGroupRepo.GetByID(ctx, financialGroupID)
 	if err != nil {
@@ -201,3 +237,30 @@ func (s *financialGroupService) Delete(ctx context.Context, financialGroupID int
 
 	return nil
 }
+
+// financialGroupRoleRanks orders the membership roles from the weakest to the
+// strongest, like the FinancialGroupMemberRole type of the database.
+var financialGroupRoleRanks = map[enums.FinancialGroupRole]int{
+	enums.FinancialGroupViewer:      1,
+	enums.FinancialGroupContributor: 2,
+	enums.FinancialGroupAdmin:       3,
+}
+
+// requireFinancialGroupRole fails with NotFinancialGroupMember when the user is
+// not part of the group and FinancialGroupRoleDenied when their role is weaker
+// than required. The owner passes every check through its admin membership.
+// Other errors are logged for caller and reported as InternalError.
+func requireFinancialGroupRole(ctx context.Context, financialGroupRepo repositories.FinancialGroupRepository, financialGroupID int, userID uuid.UUID, required enums.FinancialGroupRole, caller string) error {
+	role, err := financialGroupRepo.GetMemberRole(ctx, financialGroupID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.NotFinancialGroupMember
+		}
+		utils.Logger.Errorf("%s - Calling financialGroupRepo.GetMemberRole: %s", caller, err.Error())
+		return &server_errors.InternalError
+	}
+	if financialGroupRoleRanks[role] < financialGroupRoleRanks[required] {
+		return &server_errors.FinancialGroupRoleDenied
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
 	"shirinec.com/src/internal/errors"
 	"shirinec.com/src/internal/models"
 	"shirinec.com/src/internal/repositories"
@@ -23,22 +24,36 @@ type ItemService interface {
 }
 
 type itemService struct {
-	itemRepo repositories.ItemRepository
+	itemRepo           repositories.ItemRepository
+	financialGroupRepo repositories.FinancialGroupRepository
 }
 
-func NewItemService(itemRepo *repositories.ItemRepository) ItemService {
-	return &itemService{itemRepo: *itemRepo}
+func NewItemService(itemRepo *repositories.ItemRepository, financialGroupRepo repositories.FinancialGroupRepository) ItemService {
+	return &itemService{itemRepo: *itemRepo, financialGroupRepo: financialGroupRepo}
 }
 
+// Create adds a personal item, or a group item when the user is at least a
+// contributor of the group.
 func (s *itemService) Create(ctx context.Context, input *dto.ItemCreateRequest, userID uuid.UUID) (*models.Item, error) {
+	if input.FinancialGroupID != nil {
+		if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, *input.FinancialGroupID, userID, enums.FinancialGroupContributor, "itemService.Create"); err != nil {
+			return nil, err
+		}
+	}
+
 	var item models.Item
 	item.UserID = userID
 	item.CategoryID = &input.CategoryID
 	item.Name = &input.Name
 	item.ImageID = input.ImageID
+	item.FinancialGroupID = input.FinancialGroupID
 
 	err := s.itemRepo.Create(ctx, &item)
 	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
 		if pgErr := server_errors.AsPgError(err); pgErr != nil {
 			return nil, pgErr
 		}
@@ -117,6 +132,10 @@ func (s *itemService) Update(ctx context.Context, input *dto.ItemUpdateRequest,
 
 	itemJoined, err := s.itemRepo.Update(ctx, &item)
 	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, &server_errors.ItemNotFound
 		}
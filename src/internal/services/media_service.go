@@ -1,43 +1,69 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"path"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 
 	"shirinec.com/config"
 	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
 	"shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/mediafile"
 	"shirinec.com/src/internal/models"
 	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/storage"
 	"shirinec.com/src/internal/utils"
 )
 
 type MediaService interface {
-	Create(ctx context.Context, savePath string, userID uuid.UUID, input *dto.MediaUploadQuery) (*dto.MediaUploadResponse, error)
-	GetMedia(ctx context.Context, mediaName string, userID uuid.UUID) (string, error)
+	Create(ctx context.Context, file io.Reader, userID uuid.UUID, input *dto.MediaUploadQuery) (*dto.MediaUploadResponse, error)
+	GetMedia(ctx context.Context, mediaName, size string, userID uuid.UUID) (string, error)
+	Replace(ctx context.Context, mediaName string, file io.Reader, userID uuid.UUID) (*dto.MediaUploadResponse, error)
+	UpdateAccess(ctx context.Context, mediaName string, input *dto.MediaAccessRequest, userID uuid.UUID) (*dto.MediaUploadResponse, error)
+	Move(ctx context.Context, mediaName string, input *dto.MediaMoveRequest, userID uuid.UUID) (*dto.MediaUploadResponse, error)
+	Delete(ctx context.Context, mediaName string, userID uuid.UUID) error
 }
 
 type mediaService struct {
-	mediaRepo    repositories.MediaRepository
-	itemRepo     repositories.ItemRepository
-	categoryRepo repositories.CategoryRepository
+	mediaRepo          repositories.MediaRepository
+	itemRepo           repositories.ItemRepository
+	categoryRepo       repositories.CategoryRepository
+	financialGroupRepo repositories.FinancialGroupRepository
+	storage            storage.Storage
 }
 
-func NewMediaService(mediaRepo repositories.MediaRepository, itemRepo repositories.ItemRepository, categoryRepo repositories.CategoryRepository) MediaService {
+func NewMediaService(mediaRepo repositories.MediaRepository, itemRepo repositories.ItemRepository, categoryRepo repositories.CategoryRepository, financialGroupRepo repositories.FinancialGroupRepository, mediaStorage storage.Storage) MediaService {
 	return &mediaService{
-		mediaRepo:    mediaRepo,
-		categoryRepo: categoryRepo,
-		itemRepo:     itemRepo,
+		mediaRepo:          mediaRepo,
+		categoryRepo:       categoryRepo,
+		itemRepo:           itemRepo,
+		financialGroupRepo: financialGroupRepo,
+		storage:            mediaStorage,
 	}
 }
 
-func (s *mediaService) Create(ctx context.Context, fileName string, userID uuid.UUID, input *dto.MediaUploadQuery) (*dto.MediaUploadResponse, error) {
+// Create validates the upload by its content and stores the re-encoded file,
+// or the PDF as it is, with its thumbnails. Stored files are removed again
+// when the media row can not be created, nothing would reference them.
+func (s *mediaService) Create(ctx context.Context, file io.Reader, userID uuid.UUID, input *dto.MediaUploadQuery) (*dto.MediaUploadResponse, error) {
+	// Viewers can see the group media but not upload to it
+	if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, input.FinancialGroupID, userID, enums.FinancialGroupContributor, "mediaService.Create"); err != nil {
+		return nil, err
+	}
+
+	fileName, metadata, err := s.store(ctx, file, "mediaService.Create")
+	if err != nil {
+		return nil, err
+	}
+
 	var media models.Media
 	media.UserID = userID
 	media.FilePath = fileName
@@ -45,11 +71,19 @@ func (s *mediaService) Create(ctx context.Context, fileName string, userID uuid.
 	media.CreationDate = currentTime
 	media.UpdateDate = currentTime
 	media.Access = &input.Access
-	media.FinancialGroupID = input.FinancialGroupID
+	media.FinancialGroupID = &input.FinancialGroupID
+	media.Metadata = metadata
 	url := fmt.Sprintf("/file/media-%s", uuid.New().String())
 	media.Url = url
 
 	if err := s.mediaRepo.Create(ctx, &media); err != nil {
+		s.removeFiles(ctx, fileName, "mediaService.Create")
+
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, &server_errors.ItemNotFound
 		}
@@ -58,18 +92,200 @@ func (s *mediaService) Create(ctx context.Context, fileName string, userID uuid.
 		return nil, &server_errors.InternalError
 	}
 
-	var mediaResponse *dto.MediaUploadResponse = &dto.MediaUploadResponse{
-		ID:           media.ID,
-		URL:          media.Url,
-		Metadata:     media.Metadata,
-		UpdateDate:   media.UpdateDate,
-		CreationDate: media.CreationDate,
+	return mediaResponse(&media), nil
+}
+
+// store processes the upload and saves it with its variants under a new key,
+// the files saved before a failure are removed again.
+func (s *mediaService) store(ctx context.Context, file io.Reader, caller string) (string, *string, error) {
+	processed, err := mediafile.Process(ctx, file, mediafile.Options{
+		MaxSize:       config.AppConfig.MediaMaxUploadSize,
+		PDFRenderer:   config.AppConfig.MediaPDFRenderer,
+		HEICConverter: config.AppConfig.MediaHEICConverter,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, mediafile.ErrTooLarge):
+			return "", nil, &server_errors.MediaFileTooLarge
+		case errors.Is(err, mediafile.ErrUnsupportedFormat):
+			return "", nil, &server_errors.InvalidFileFormat
+		}
+		utils.Logger.Errorf("%s - Calling mediafile.Process: %s", caller, err.Error())
+		return "", nil, &server_errors.InternalError
 	}
 
-	return mediaResponse, nil
+	metadata, err := json.Marshal(processed.Metadata)
+	if err != nil {
+		utils.Logger.Errorf("%s - Marshaling metadata: %s", caller, err.Error())
+		return "", nil, &server_errors.InternalError
+	}
+
+	fileName := fmt.Sprintf("%s%s", uuid.New().String(), processed.Extension)
+	files := map[string]mediafile.File{fileName: processed.Original}
+	for size, variant := range processed.Variants {
+		files[mediafile.VariantKey(fileName, size)] = variant
+	}
+
+	for key, stored := range files {
+		if err := s.storage.Save(ctx, key, bytes.NewReader(stored.Data), int64(len(stored.Data)), stored.ContentType); err != nil {
+			s.removeFiles(ctx, fileName, caller)
+			utils.Logger.Errorf("%s - Saving %s: %s", caller, key, err.Error())
+			return "", nil, &server_errors.InternalError
+		}
+	}
+
+	metadataText := string(metadata)
+	return fileName, &metadataText, nil
+}
+
+// removeFiles removes the file and every variant it may have, removing files
+// that were never saved does not fail.
+func (s *mediaService) removeFiles(ctx context.Context, fileName, caller string) {
+	keys := []string{fileName}
+	for _, size := range mediafile.Sizes {
+		keys = append(keys, mediafile.VariantKey(fileName, size.Name))
+	}
+	for _, key := range keys {
+		if err := s.storage.Remove(ctx, key); err != nil {
+			utils.Logger.Errorf("%s - Removing file %s: %s", caller, key, err.Error())
+		}
+	}
 }
 
-func (s *mediaService) GetMedia(ctx context.Context, mediaName string, userID uuid.UUID) (string, error) {
+func mediaResponse(media *models.Media) *dto.MediaUploadResponse {
+	response := dto.MediaUploadResponse{
+		ID:               media.ID,
+		URL:              media.Url,
+		Metadata:         media.Metadata,
+		FinancialGroupID: media.FinancialGroupID,
+		UpdateDate:       media.UpdateDate,
+		CreationDate:     media.CreationDate,
+	}
+	if media.Access != nil {
+		response.Access = *media.Access
+	}
+	return &response
+}
+
+// Replace stores the upload as the new content of the media, the files of
+// the previous content are removed once the media points to the new ones.
+func (s *mediaService) Replace(ctx context.Context, mediaName string, file io.Reader, userID uuid.UUID) (*dto.MediaUploadResponse, error) {
+	fileName, metadata, err := s.store(ctx, file, "mediaService.Replace")
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/file/%s", mediaName)
+	media, oldFileName, err := s.mediaRepo.Replace(ctx, url, userID, fileName, metadata)
+	if err != nil {
+		s.removeFiles(ctx, fileName, "mediaService.Replace")
+
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("mediaService.Replace - Calling mediaRepo.Replace: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	s.removeFiles(ctx, oldFileName, "mediaService.Replace")
+	return mediaResponse(media), nil
+}
+
+func (s *mediaService) UpdateAccess(ctx context.Context, mediaName string, input *dto.MediaAccessRequest, userID uuid.UUID) (*dto.MediaUploadResponse, error) {
+	url := fmt.Sprintf("/file/%s", mediaName)
+	media, err := s.mediaRepo.UpdateAccess(ctx, url, userID, input.Access)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("mediaService.UpdateAccess - Calling mediaRepo.UpdateAccess: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return mediaResponse(media), nil
+}
+
+// Move saves the media in another group, the user needs the same role in it
+// as for uploading there.
+func (s *mediaService) Move(ctx context.Context, mediaName string, input *dto.MediaMoveRequest, userID uuid.UUID) (*dto.MediaUploadResponse, error) {
+	if err := requireFinancialGroupRole(ctx, s.financialGroupRepo, input.FinancialGroupID, userID, enums.FinancialGroupContributor, "mediaService.Move"); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/file/%s", mediaName)
+	media, err := s.mediaRepo.Move(ctx, url, userID, input.FinancialGroupID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("mediaService.Move - Calling mediaRepo.Move: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return mediaResponse(media), nil
+}
+
+// Delete removes the media row first, its files are only removed when nothing
+// can reference them anymore.
+func (s *mediaService) Delete(ctx context.Context, mediaName string, userID uuid.UUID) error {
+	url := fmt.Sprintf("/file/%s", mediaName)
+	fileName, err := s.mediaRepo.Delete(ctx, url, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("mediaService.Delete - Calling mediaRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	s.removeFiles(ctx, fileName, "mediaService.Delete")
+	return nil
+}
+
+// GetMedia returns the storage key of the media when the user can read it.
+// With a size, the key of that variant is returned when the media has one and
+// the original otherwise.
+func (s *mediaService) GetMedia(ctx context.Context, mediaName, size string, userID uuid.UUID) (string, error) {
 	url := fmt.Sprintf("/file/%s", mediaName)
 	media, err := s.mediaRepo.GetByMediaName(ctx, url, userID)
 	if err != nil {
@@ -85,6 +301,17 @@ func (s *mediaService) GetMedia(ctx context.Context, mediaName string, userID uu
 		return "", &server_errors.InternalError
 	}
 
-	mediaPath := path.Join(config.AppConfig.UploadFolder, media.FilePath)
-	return mediaPath, nil
+	if size == "" || media.Metadata == nil {
+		return media.FilePath, nil
+	}
+
+	var metadata mediafile.Metadata
+	if err := json.Unmarshal([]byte(*media.Metadata), &metadata); err != nil {
+		utils.Logger.Warnf("mediaService.GetMedia - Reading metadata of media %d: %s", media.ID, err.Error())
+		return media.FilePath, nil
+	}
+	if _, ok := metadata.Variants[size]; ok {
+		return mediafile.VariantKey(media.FilePath, size), nil
+	}
+	return media.FilePath, nil
 }
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type NetWorthService interface {
+	History(ctx context.Context, input *dto.NetWorthRequest, userID uuid.UUID) (*dto.NetWorthResponse, error)
+	Backfill(ctx context.Context, userID uuid.UUID) (*dto.NetWorthBackfillResponse, error)
+}
+
+type netWorthService struct {
+	netWorthRepo repositories.NetWorthRepository
+}
+
+func NewNetWorthService(netWorthRepo repositories.NetWorthRepository) NetWorthService {
+	return &netWorthService{netWorthRepo: netWorthRepo}
+}
+
+func (s *netWorthService) History(ctx context.Context, input *dto.NetWorthRequest, userID uuid.UUID) (*dto.NetWorthResponse, error) {
+	if input.From.After(input.To) {
+		return nil, &server_errors.InvalidDateRange
+	}
+	if input.Interval == "" {
+		input.Interval = enums.ReportByDay
+	}
+	if input.Interval == enums.ReportByDay && input.To.Sub(input.From) >= maxDailyReportDays*24*time.Hour {
+		return nil, &server_errors.ReportRangeTooLong
+	}
+
+	balances, err := s.netWorthRepo.History(ctx, input, userID)
+	if err != nil {
+		utils.Logger.Errorf("netWorthService.History - Calling netWorthRepo.History: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	// Balances come ordered by day, then currency
+	points := make([]dto.NetWorthPoint, 0)
+	for _, balance := range balances {
+		last := len(points) - 1
+		if last < 0 || !points[last].Date.Equal(balance.Date) || points[last].Currency != balance.Currency {
+			points = append(points, dto.NetWorthPoint{
+				Date:       balance.Date,
+				Currency:   balance.Currency,
+				Categories: make([]dto.NetWorthCategory, 0),
+			})
+			last++
+		}
+
+		point := &points[last]
+		point.Total += balance.Balance
+		if balance.AccountType == enums.AccoutTypeExternal {
+			point.External += balance.Balance
+		} else {
+			point.Self += balance.Balance
+		}
+
+		categories := point.Categories
+		if n := len(categories); n > 0 && categories[n-1].CategoryID == balance.CategoryID {
+			categories[n-1].Balance += balance.Balance
+			continue
+		}
+		point.Categories = append(categories, dto.NetWorthCategory{
+			CategoryID:   balance.CategoryID,
+			CategoryName: balance.CategoryName,
+			Balance:      balance.Balance,
+		})
+	}
+
+	return &dto.NetWorthResponse{From: input.From, To: input.To, Interval: input.Interval, Points: points}, nil
+}
+
+func (s *netWorthService) Backfill(ctx context.Context, userID uuid.UUID) (*dto.NetWorthBackfillResponse, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	count, err := s.netWorthRepo.Backfill(ctx, today, userID)
+	if err != nil {
+		utils.Logger.Errorf("netWorthService.Backfill - Calling netWorthRepo.Backfill: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &dto.NetWorthBackfillResponse{Snapshots: count}, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type PurchaseListItemService interface {
+	List(ctx context.Context, transactionID int, userID uuid.UUID) (*dto.PurchaseListResponse, error)
+	Create(ctx context.Context, input *dto.PurchaseListItemCreateRequest, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error)
+	Update(ctx context.Context, input *dto.PurchaseListItemUpdateRequest, id, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error)
+	Delete(ctx context.Context, id, transactionID int, userID uuid.UUID) error
+}
+
+type purchaseListItemService struct {
+	purchaseListItemRepo repositories.PurchaseListItemRepository
+}
+
+func NewPurchaseListItemService(purchaseListItemRepo repositories.PurchaseListItemRepository) PurchaseListItemService {
+	return &purchaseListItemService{purchaseListItemRepo: purchaseListItemRepo}
+}
+
+func (s *purchaseListItemService) List(ctx context.Context, transactionID int, userID uuid.UUID) (*dto.PurchaseListResponse, error) {
+	purchaseList, err := s.purchaseListItemRepo.List(ctx, transactionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("purchaseListItemService.List - Calling purchaseListItemRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return purchaseList, nil
+}
+
+func (s *purchaseListItemService) Create(ctx context.Context, input *dto.PurchaseListItemCreateRequest, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error) {
+	var purchaseListItem models.PurchaseListItem
+	purchaseListItem.UserID = userID
+	purchaseListItem.TransactionID = transactionID
+	purchaseListItem.ItemID = &input.ItemID
+	purchaseListItem.Count = &input.Count
+	purchaseListItem.UnitPrice = &input.UnitPrice
+
+	if err := s.purchaseListItemRepo.Create(ctx, &purchaseListItem); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("purchaseListItemService.Create - Calling purchaseListItemRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.getByID(ctx, purchaseListItem.ID, transactionID, userID)
+}
+
+func (s *purchaseListItemService) Update(ctx context.Context, input *dto.PurchaseListItemUpdateRequest, id, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error) {
+	var purchaseListItem models.PurchaseListItem
+	purchaseListItem.ID = id
+	purchaseListItem.UserID = userID
+	purchaseListItem.TransactionID = transactionID
+	purchaseListItem.ItemID = input.ItemID
+	purchaseListItem.Count = input.Count
+	purchaseListItem.UnitPrice = input.UnitPrice
+
+	if err := s.purchaseListItemRepo.Update(ctx, &purchaseListItem); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("purchaseListItemService.Update - Calling purchaseListItemRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.getByID(ctx, id, transactionID, userID)
+}
+
+func (s *purchaseListItemService) Delete(ctx context.Context, id, transactionID int, userID uuid.UUID) error {
+	if err := s.purchaseListItemRepo.Delete(ctx, id, transactionID, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("purchaseListItemService.Delete - Calling purchaseListItemRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
+func (s *purchaseListItemService) getByID(ctx context.Context, id, transactionID int, userID uuid.UUID) (*dto.PurchaseListItemJoinedResponse, error) {
+	purchaseListItem, err := s.purchaseListItemRepo.GetByID(ctx, id, transactionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("purchaseListItemService.getByID - Calling purchaseListItemRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return purchaseListItem, nil
+}
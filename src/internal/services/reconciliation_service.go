@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type ReconciliationService interface {
+	Create(ctx context.Context, input *dto.AccountReconcileRequest, accountID int, userID uuid.UUID) (*models.AccountReconciliation, error)
+	List(ctx context.Context, accountID, page, size int, userID uuid.UUID) (*dto.AccountReconciliationListResponse, error)
+	ListDiscrepancies(ctx context.Context, userID uuid.UUID) (*[]dto.BalanceDiscrepancyResponse, error)
+}
+
+type reconciliationService struct {
+	reconciliationRepo repositories.ReconciliationRepository
+}
+
+func NewReconciliationService(reconciliationRepo repositories.ReconciliationRepository) ReconciliationService {
+	return &reconciliationService{reconciliationRepo: reconciliationRepo}
+}
+
+func (s *reconciliationService) Create(ctx context.Context, input *dto.AccountReconcileRequest, accountID int, userID uuid.UUID) (*models.AccountReconciliation, error) {
+	reconciliation := models.AccountReconciliation{
+		AccountID:        accountID,
+		UserID:           userID,
+		StatementDate:    input.Date.UTC().Truncate(24 * time.Hour),
+		StatementBalance: input.Balance,
+	}
+
+	if err := s.reconciliationRepo.Create(ctx, &reconciliation, input.Adjust); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("reconciliationService.Create - Calling reconciliationRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &reconciliation, nil
+}
+
+func (s *reconciliationService) List(ctx context.Context, accountID, page, size int, userID uuid.UUID) (*dto.AccountReconciliationListResponse, error) {
+	limit := size
+	offset := page * size
+	reconciliations, totalCount, err := s.reconciliationRepo.List(ctx, accountID, limit, offset, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("reconciliationService.List - Calling reconciliationRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.AccountReconciliationListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Reconciliations = reconciliations
+
+	return &response, nil
+}
+
+func (s *reconciliationService) ListDiscrepancies(ctx context.Context, userID uuid.UUID) (*[]dto.BalanceDiscrepancyResponse, error) {
+	discrepancies, err := s.reconciliationRepo.ListDiscrepancies(ctx, userID)
+	if err != nil {
+		utils.Logger.Errorf("reconciliationService.ListDiscrepancies - Calling reconciliationRepo.ListDiscrepancies: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return discrepancies, nil
+}
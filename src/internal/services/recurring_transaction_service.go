@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type RecurringTransactionService interface {
+	Create(ctx context.Context, input *dto.RecurringTransactionCreateRequest, userID uuid.UUID) (*dto.RecurringTransactionResponse, error)
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.RecurringTransactionResponse, error)
+	List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.RecurringTransactionListResponse, error)
+	Update(ctx context.Context, input *dto.RecurringTransactionUpdateRequest, id int, userID uuid.UUID) (*dto.RecurringTransactionResponse, error)
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+	SetPaused(ctx context.Context, id int, paused bool, userID uuid.UUID) (*dto.RecurringTransactionResponse, error)
+	ListOccurrences(ctx context.Context, id, count int, userID uuid.UUID) (*[]dto.RecurringOccurrenceResponse, error)
+	SetOverride(ctx context.Context, input *dto.RecurringOccurrenceOverrideRequest, id int, occurrenceDate time.Time, userID uuid.UUID) (*models.RecurringTransactionOverride, error)
+	DeleteOverride(ctx context.Context, id int, occurrenceDate time.Time, userID uuid.UUID) error
+}
+
+type recurringTransactionService struct {
+	recurringTransactionRepo repositories.RecurringTransactionRepository
+}
+
+func NewRecurringTransactionService(recurringTransactionRepo repositories.RecurringTransactionRepository) RecurringTransactionService {
+	return &recurringTransactionService{recurringTransactionRepo: recurringTransactionRepo}
+}
+
+// currentDate is today at midnight UTC, the dates of recurring rules have no
+// time part.
+func currentDate() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func (s *recurringTransactionService) Create(ctx context.Context, input *dto.RecurringTransactionCreateRequest, userID uuid.UUID) (*dto.RecurringTransactionResponse, error) {
+	var rule models.RecurringTransaction
+	rule.UserID = userID
+	rule.AccountID = input.AccountID
+	rule.CategoryID = input.CategoryID
+	rule.TransactionType = input.Type
+	rule.Amount = input.Amount
+	rule.Description = input.Description
+	rule.Frequency = input.Frequency
+	rule.Interval = 1
+	if input.Interval != nil {
+		rule.Interval = *input.Interval
+	}
+
+	rule.StartDate = currentDate()
+	if input.StartDate != nil {
+		rule.StartDate = input.StartDate.UTC().Truncate(24 * time.Hour)
+	}
+
+	if input.EndDate != nil {
+		endDate := input.EndDate.UTC().Truncate(24 * time.Hour)
+		if endDate.Before(rule.StartDate) {
+			return nil, &server_errors.InvalidRecurrence
+		}
+		rule.EndDate = &endDate
+	}
+
+	if err := s.recurringTransactionRepo.Create(ctx, &rule); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("recurringTransactionService.Create - Calling recurringTransactionRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, rule.ID, userID)
+}
+
+func (s *recurringTransactionService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.RecurringTransactionResponse, error) {
+	rule, err := s.recurringTransactionRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("recurringTransactionService.GetByID - Calling recurringTransactionRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return rule, nil
+}
+
+func (s *recurringTransactionService) List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.RecurringTransactionListResponse, error) {
+	limit := size
+	offset := page * size
+	rules, totalCount, err := s.recurringTransactionRepo.List(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("recurringTransactionService.List - Calling recurringTransactionRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.RecurringTransactionListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.RecurringTransactions = rules
+
+	return &response, nil
+}
+
+func (s *recurringTransactionService) Update(ctx context.Context, input *dto.RecurringTransactionUpdateRequest, id int, userID uuid.UUID) (*dto.RecurringTransactionResponse, error) {
+	if input.StartDate != nil {
+		startDate := input.StartDate.UTC().Truncate(24 * time.Hour)
+		input.StartDate = &startDate
+	}
+	if input.EndDate != nil {
+		endDate := input.EndDate.UTC().Truncate(24 * time.Hour)
+		input.EndDate = &endDate
+	}
+
+	if err := s.recurringTransactionRepo.Update(ctx, id, userID, input, currentDate()); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("recurringTransactionService.Update - Calling recurringTransactionRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+func (s *recurringTransactionService) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	if err := s.recurringTransactionRepo.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("recurringTransactionService.Delete - Calling recurringTransactionRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
+func (s *recurringTransactionService) SetPaused(ctx context.Context, id int, paused bool, userID uuid.UUID) (*dto.RecurringTransactionResponse, error) {
+	if err := s.recurringTransactionRepo.SetPaused(ctx, id, userID, paused, currentDate()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("recurringTransactionService.SetPaused - Calling recurringTransactionRepo.SetPaused: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+// ListOccurrences returns the next count occurrences of the rule that are not
+// materialized yet, with their overrides applied.
+func (s *recurringTransactionService) ListOccurrences(ctx context.Context, id, count int, userID uuid.UUID) (*[]dto.RecurringOccurrenceResponse, error) {
+	rule, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.recurringTransactionRepo.ListOverrides(ctx, id, userID)
+	if err != nil {
+		utils.Logger.Errorf("recurringTransactionService.ListOccurrences - Calling recurringTransactionRepo.ListOverrides: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	overridesByDate := make(map[string]models.RecurringTransactionOverride, len(*overrides))
+	for _, override := range *overrides {
+		overridesByDate[override.OccurrenceDate.Format(time.DateOnly)] = override
+	}
+
+	occurrences := make([]dto.RecurringOccurrenceResponse, 0, count)
+	if rule.NextOccurrence == nil {
+		return &occurrences, nil
+	}
+
+	for index := rule.OccurrenceIndex; len(occurrences) < count; index++ {
+		date := rule.Occurrence(index)
+		if rule.EndsBefore(date) {
+			break
+		}
+
+		occurrence := dto.RecurringOccurrenceResponse{
+			Date:        date,
+			Amount:      rule.Amount,
+			Description: rule.Description,
+		}
+		if override, found := overridesByDate[date.Format(time.DateOnly)]; found {
+			occurrence.Overridden = true
+			occurrence.Skipped = override.Skip
+			if override.Amount != nil {
+				occurrence.Amount = *override.Amount
+			}
+			if override.Description != nil {
+				occurrence.Description = override.Description
+			}
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	return &occurrences, nil
+}
+
+func (s *recurringTransactionService) SetOverride(ctx context.Context, input *dto.RecurringOccurrenceOverrideRequest, id int, occurrenceDate time.Time, userID uuid.UUID) (*models.RecurringTransactionOverride, error) {
+	var override models.RecurringTransactionOverride
+	override.RecurringTransactionID = id
+	override.OccurrenceDate = occurrenceDate
+	override.Skip = input.Skip
+	override.Amount = input.Amount
+	override.Description = input.Description
+
+	if err := s.recurringTransactionRepo.SetOverride(ctx, &override, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("recurringTransactionService.SetOverride - Calling recurringTransactionRepo.SetOverride: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &override, nil
+}
+
+func (s *recurringTransactionService) DeleteOverride(ctx context.Context, id int, occurrenceDate time.Time, userID uuid.UUID) error {
+	if err := s.recurringTransactionRepo.DeleteOverride(ctx, id, occurrenceDate, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("recurringTransactionService.DeleteOverride - Calling recurringTransactionRepo.DeleteOverride: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
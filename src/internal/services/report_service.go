@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+// maxDailyReportDays keeps daily series small enough for a chart.
+const maxDailyReportDays = 366
+
+const defaultReportTopLimit = 10
+
+type ReportService interface {
+	Summary(ctx context.Context, input *dto.ReportRequest, userID uuid.UUID) (*dto.ReportSummaryResponse, error)
+	Breakdown(ctx context.Context, input *dto.ReportBreakdownRequest, userID uuid.UUID) (*dto.ReportBreakdownResponse, error)
+	Comparison(ctx context.Context, input *dto.ReportRequest, userID uuid.UUID) (*dto.ReportComparisonResponse, error)
+	Top(ctx context.Context, input *dto.ReportTopRequest, userID uuid.UUID) (*dto.ReportTopResponse, error)
+}
+
+type reportService struct {
+	reportRepo repositories.ReportRepository
+}
+
+func NewReportService(reportRepo repositories.ReportRepository) ReportService {
+	return &reportService{reportRepo: reportRepo}
+}
+
+func validateReportRange(input *dto.ReportRequest) error {
+	if input.From.After(input.To) {
+		return &server_errors.InvalidDateRange
+	}
+	return nil
+}
+
+func (s *reportService) Summary(ctx context.Context, input *dto.ReportRequest, userID uuid.UUID) (*dto.ReportSummaryResponse, error) {
+	if err := validateReportRange(input); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.reportRepo.Summary(ctx, input, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("reportService.Summary - Calling reportRepo.Summary: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &dto.ReportSummaryResponse{From: input.From, To: input.To, Totals: totals}, nil
+}
+
+func (s *reportService) Breakdown(ctx context.Context, input *dto.ReportBreakdownRequest, userID uuid.UUID) (*dto.ReportBreakdownResponse, error) {
+	if err := validateReportRange(&input.ReportRequest); err != nil {
+		return nil, err
+	}
+	if input.GroupBy == enums.ReportByDay && input.To.Sub(input.From) >= maxDailyReportDays*24*time.Hour {
+		return nil, &server_errors.ReportRangeTooLong
+	}
+
+	rows, err := s.reportRepo.Breakdown(ctx, input, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("reportService.Breakdown - Calling reportRepo.Breakdown: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &dto.ReportBreakdownResponse{From: input.From, To: input.To, GroupBy: input.GroupBy, Rows: rows}, nil
+}
+
+// Comparison covers whole months, from the month of From to the month of To.
+// The month before From is read as well so the first month has something to
+// be compared with.
+func (s *reportService) Comparison(ctx context.Context, input *dto.ReportRequest, userID uuid.UUID) (*dto.ReportComparisonResponse, error) {
+	if err := validateReportRange(input); err != nil {
+		return nil, err
+	}
+
+	from := time.Date(input.From.Year(), input.From.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(input.To.Year(), input.To.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	monthly := dto.ReportBreakdownRequest{
+		ReportRequest: dto.ReportRequest{
+			From:      from.AddDate(0, -1, 0),
+			To:        to,
+			AccountID: input.AccountID,
+		},
+		GroupBy: enums.ReportByMonth,
+	}
+	months, err := s.reportRepo.Breakdown(ctx, &monthly, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("reportService.Comparison - Calling reportRepo.Breakdown: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	// Months come ordered by currency, then chronologically
+	rows := make([]dto.ReportComparisonRow, 0, len(months))
+	for i, month := range months {
+		if i == 0 || months[i-1].Currency != month.Currency {
+			continue
+		}
+		previous := months[i-1]
+		rows = append(rows, dto.ReportComparisonRow{
+			Month:           *month.PeriodStart,
+			ReportTotals:    month.ReportTotals,
+			PreviousIncome:  previous.Income,
+			PreviousExpense: previous.Expense,
+			IncomeChange:    percentChange(previous.Income, month.Income),
+			ExpenseChange:   percentChange(previous.Expense, month.Expense),
+		})
+	}
+
+	return &dto.ReportComparisonResponse{From: from, To: to, Rows: rows}, nil
+}
+
+// percentChange is rounded to one decimal.
+func percentChange(previous, current models.Money) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := math.Round(float64(current-previous)*1000/float64(previous)) / 10
+	return &change
+}
+
+func (s *reportService) Top(ctx context.Context, input *dto.ReportTopRequest, userID uuid.UUID) (*dto.ReportTopResponse, error) {
+	if err := validateReportRange(&input.ReportRequest); err != nil {
+		return nil, err
+	}
+	if input.Type == "" {
+		input.Type = enums.TransactionExpense
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultReportTopLimit
+	}
+
+	rows, err := s.reportRepo.Top(ctx, input, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("reportService.Top - Calling reportRepo.Top: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return &dto.ReportTopResponse{From: input.From, To: input.To, Type: input.Type, Rows: rows}, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type ScheduledTransferService interface {
+	Create(ctx context.Context, input *dto.ScheduledTransferCreateRequest, userID uuid.UUID) (*dto.ScheduledTransferResponse, error)
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.ScheduledTransferResponse, error)
+	List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.ScheduledTransferListResponse, error)
+	Cancel(ctx context.Context, id int, userID uuid.UUID) (*dto.ScheduledTransferResponse, error)
+	ListRuns(ctx context.Context, id, page, size int, userID uuid.UUID) (*dto.ScheduledTransferRunListResponse, error)
+}
+
+type scheduledTransferService struct {
+	scheduledTransferRepo repositories.ScheduledTransferRepository
+}
+
+func NewScheduledTransferService(scheduledTransferRepo repositories.ScheduledTransferRepository) ScheduledTransferService {
+	return &scheduledTransferService{scheduledTransferRepo: scheduledTransferRepo}
+}
+
+func (s *scheduledTransferService) Create(ctx context.Context, input *dto.ScheduledTransferCreateRequest, userID uuid.UUID) (*dto.ScheduledTransferResponse, error) {
+	var transfer models.ScheduledTransfer
+	transfer.UserID = userID
+	transfer.FromAccountID = &input.From
+	transfer.DestAccountID = &input.Dest
+	transfer.Amount = input.Amount
+	transfer.Rate = input.Rate
+	transfer.Frequency = input.Frequency
+	transfer.Interval = 1
+	if input.Interval != nil {
+		transfer.Interval = *input.Interval
+	}
+
+	transfer.StartDate = input.StartDate.UTC().Truncate(24 * time.Hour)
+	if transfer.StartDate.Before(currentDate()) {
+		return nil, &server_errors.ScheduleInPast
+	}
+
+	// One-off transfers run on their start date only
+	if input.EndDate != nil && input.Frequency != nil {
+		endDate := input.EndDate.UTC().Truncate(24 * time.Hour)
+		if endDate.Before(transfer.StartDate) {
+			return nil, &server_errors.InvalidRecurrence
+		}
+		transfer.EndDate = &endDate
+	}
+
+	if err := s.scheduledTransferRepo.Create(ctx, &transfer); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("scheduledTransferService.Create - Calling scheduledTransferRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, transfer.ID, userID)
+}
+
+func (s *scheduledTransferService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.ScheduledTransferResponse, error) {
+	transfer, err := s.scheduledTransferRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("scheduledTransferService.GetByID - Calling scheduledTransferRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return transfer, nil
+}
+
+func (s *scheduledTransferService) List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.ScheduledTransferListResponse, error) {
+	limit := size
+	offset := page * size
+	transfers, totalCount, err := s.scheduledTransferRepo.List(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("scheduledTransferService.List - Calling scheduledTransferRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.ScheduledTransferListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.ScheduledTransfers = transfers
+
+	return &response, nil
+}
+
+func (s *scheduledTransferService) Cancel(ctx context.Context, id int, userID uuid.UUID) (*dto.ScheduledTransferResponse, error) {
+	if err := s.scheduledTransferRepo.Cancel(ctx, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("scheduledTransferService.Cancel - Calling scheduledTransferRepo.Cancel: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+func (s *scheduledTransferService) ListRuns(ctx context.Context, id, page, size int, userID uuid.UUID) (*dto.ScheduledTransferRunListResponse, error) {
+	if _, err := s.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	limit := size
+	offset := page * size
+	runs, totalCount, err := s.scheduledTransferRepo.ListRuns(ctx, id, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("scheduledTransferService.ListRuns - Calling scheduledTransferRepo.ListRuns: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.ScheduledTransferRunListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Runs = runs
+
+	return &response, nil
+}
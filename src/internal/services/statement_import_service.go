@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/statement"
+	"shirinec.com/src/internal/utils"
+)
+
+type StatementImportService interface {
+	Create(ctx context.Context, input *dto.StatementImportRequest, file io.Reader, fileName string, userID uuid.UUID) (*dto.StatementImportResponse, error)
+	GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.StatementImportResponse, error)
+	List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.StatementImportListResponse, error)
+	Commit(ctx context.Context, input *dto.StatementImportCommitRequest, id int, userID uuid.UUID) (*dto.StatementImportResponse, error)
+	Delete(ctx context.Context, id int, userID uuid.UUID) error
+}
+
+type statementImportService struct {
+	statementImportRepo repositories.StatementImportRepository
+}
+
+func NewStatementImportService(statementImportRepo repositories.StatementImportRepository) StatementImportService {
+	return &statementImportService{statementImportRepo: statementImportRepo}
+}
+
+func statementParser(input *dto.StatementImportRequest) (statement.Parser, error) {
+	switch input.Format {
+	case enums.StatementOFX:
+		return statement.NewOFXParser(), nil
+	case enums.StatementQIF:
+		return statement.NewQIFParser(input.DateFormat, input.DecimalSeparator)
+	default:
+		return statement.NewCSVParser(statement.CSVMapping{
+			Delimiter:         input.Delimiter,
+			HasHeader:         input.HasHeader,
+			DateColumn:        input.DateColumn,
+			DateFormat:        input.DateFormat,
+			DescriptionColumn: input.DescriptionColumn,
+			AmountColumn:      input.AmountColumn,
+			DebitColumn:       input.DebitColumn,
+			CreditColumn:      input.CreditColumn,
+			DecimalSeparator:  input.DecimalSeparator,
+		}), nil
+	}
+}
+
+// Create parses the statement into a pending import, nothing is recorded on
+// the account until it is committed.
+func (s *statementImportService) Create(ctx context.Context, input *dto.StatementImportRequest, file io.Reader, fileName string, userID uuid.UUID) (*dto.StatementImportResponse, error) {
+	parser, err := statementParser(input)
+	if err != nil {
+		return nil, server_errors.StatementErrorBuilder(err)
+	}
+
+	parsedRows, err := parser.Parse(file)
+	if err != nil {
+		return nil, server_errors.StatementErrorBuilder(err)
+	}
+
+	// Zero amount lines, like waived fees, do not change anything
+	rows := make([]models.StatementImportRow, 0, len(parsedRows))
+	for _, parsed := range parsedRows {
+		if parsed.Amount == 0 {
+			continue
+		}
+		row := models.StatementImportRow{
+			LineNumber:      parsed.Line,
+			TransactionDate: parsed.Date,
+			Amount:          parsed.Amount,
+		}
+		if parsed.Description != "" {
+			row.Description = &parsed.Description
+		}
+		if parsed.ExternalID != "" {
+			row.ExternalID = &parsed.ExternalID
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, &server_errors.StatementIsEmpty
+	}
+
+	var statementImport models.StatementImport
+	statementImport.UserID = userID
+	statementImport.AccountID = input.AccountID
+	statementImport.Format = input.Format
+	statementImport.FileName = fileName
+
+	if err := s.statementImportRepo.Create(ctx, &statementImport, rows); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("statementImportService.Create - Calling statementImportRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, statementImport.ID, userID)
+}
+
+func (s *statementImportService) GetByID(ctx context.Context, id int, userID uuid.UUID) (*dto.StatementImportResponse, error) {
+	statementImport, err := s.statementImportRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("statementImportService.GetByID - Calling statementImportRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return statementImport, nil
+}
+
+func (s *statementImportService) List(ctx context.Context, page, size int, userID uuid.UUID) (*dto.StatementImportListResponse, error) {
+	limit := size
+	offset := page * size
+	imports, totalCount, err := s.statementImportRepo.List(ctx, limit, offset, userID)
+	if err != nil {
+		utils.Logger.Errorf("statementImportService.List - Calling statementImportRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(size)))
+	remainingPages := int(math.Max(float64(totalPages-page-1), 0))
+
+	var response dto.StatementImportListResponse
+	response.Pagination.PageNumber = page
+	response.Pagination.TotalRecord = totalCount
+	response.Pagination.PageSize = size
+	response.Pagination.RemainingPages = remainingPages
+	response.Imports = imports
+
+	return &response, nil
+}
+
+func (s *statementImportService) Commit(ctx context.Context, input *dto.StatementImportCommitRequest, id int, userID uuid.UUID) (*dto.StatementImportResponse, error) {
+	if err := s.statementImportRepo.Commit(ctx, id, userID, input); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("statementImportService.Commit - Calling statementImportRepo.Commit: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, id, userID)
+}
+
+func (s *statementImportService) Delete(ctx context.Context, id int, userID uuid.UUID) error {
+	if err := s.statementImportRepo.Delete(ctx, id, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("statementImportService.Delete - Calling statementImportRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
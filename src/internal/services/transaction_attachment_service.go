@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type TransactionAttachmentService interface {
+	Attach(ctx context.Context, input *dto.TransactionAttachRequest, transactionID int, userID uuid.UUID) (*dto.TransactionAttachment, error)
+	List(ctx context.Context, transactionID int, userID uuid.UUID) (*[]dto.TransactionAttachment, error)
+	Detach(ctx context.Context, transactionID, mediaID int, userID uuid.UUID) error
+}
+
+type transactionAttachmentService struct {
+	transactionAttachmentRepo repositories.TransactionAttachmentRepository
+}
+
+func NewTransactionAttachmentService(transactionAttachmentRepo repositories.TransactionAttachmentRepository) TransactionAttachmentService {
+	return &transactionAttachmentService{transactionAttachmentRepo: transactionAttachmentRepo}
+}
+
+func (s *transactionAttachmentService) Attach(ctx context.Context, input *dto.TransactionAttachRequest, transactionID int, userID uuid.UUID) (*dto.TransactionAttachment, error) {
+	attachment, err := s.transactionAttachmentRepo.Attach(ctx, transactionID, input.MediaID, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("transactionAttachmentService.Attach - Calling transactionAttachmentRepo.Attach: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return attachment, nil
+}
+
+func (s *transactionAttachmentService) List(ctx context.Context, transactionID int, userID uuid.UUID) (*[]dto.TransactionAttachment, error) {
+	attachments, err := s.transactionAttachmentRepo.List(ctx, transactionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("transactionAttachmentService.List - Calling transactionAttachmentRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return attachments, nil
+}
+
+func (s *transactionAttachmentService) Detach(ctx context.Context, transactionID, mediaID int, userID uuid.UUID) error {
+	if err := s.transactionAttachmentRepo.Detach(ctx, transactionID, mediaID, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		utils.Logger.Errorf("transactionAttachmentService.Detach - Calling transactionAttachmentRepo.Detach: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/enums"
+	server_errors "shirinec.com/src/internal/errors"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type TransactionService interface {
+	Create(ctx context.Context, input *dto.TransactionCreateRequest, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error)
+	GetByID(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error)
+	List(ctx context.Context, input *dto.TransactionListRequest, userID uuid.UUID) (*dto.TransactionListResponse, error)
+	Update(ctx context.Context, input *dto.TransactionUpdateRequest, id int, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error)
+	Delete(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) error
+	Reverse(ctx context.Context, id int, userID uuid.UUID) (*dto.TransactionReversalResponse, error)
+}
+
+type transactionService struct {
+	transactionRepo repositories.TransactionRepository
+}
+
+func NewTransactionService(transactionRepo repositories.TransactionRepository) TransactionService {
+	return &transactionService{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// signedAmount converts the always positive amount users send into the value
+// stored in transactions, expenses are saved as negative numbers so the sum of
+// an account transactions always matches its balance change.
+func signedAmount(amount models.Money, transactionType enums.TransactionType) models.Money {
+	if transactionType == enums.TransactionExpense {
+		return -amount
+	}
+	return amount
+}
+
+func (s *transactionService) Create(ctx context.Context, input *dto.TransactionCreateRequest, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error) {
+	amount := signedAmount(input.Amount, transactionType)
+
+	var transaction models.Transaction
+	transaction.UserID = userID
+	transaction.AccountID = &input.AccountID
+	transaction.CategoryID = input.CategoryID
+	transaction.Amount = &amount
+	transaction.Description = input.Description
+	transaction.TransactionType = transactionType
+	if input.Date != nil {
+		date := input.Date.UTC().Truncate(time.Second)
+		transaction.TransactionDate = &date
+	}
+
+	if err := s.transactionRepo.Create(ctx, &transaction); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("transactionService.Create - Calling transactionRepo.Create: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return s.GetByID(ctx, transaction.ID, transactionType, userID)
+}
+
+func (s *transactionService) GetByID(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error) {
+	transaction, err := s.transactionRepo.GetByID(ctx, id, transactionType, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("transactionService.GetByID - Calling transactionRepo.GetByID: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return transaction, nil
+}
+
+func (s *transactionService) List(ctx context.Context, input *dto.TransactionListRequest, userID uuid.UUID) (*dto.TransactionListResponse, error) {
+	filter := dto.TransactionListFilter{
+		UserID:      userID,
+		AccountID:   input.AccountID,
+		CategoryID:  input.CategoryID,
+		Type:        input.Type,
+		From:        input.From,
+		Description: input.Description,
+		SortBy:      input.SortBy,
+		Ascending:   input.Order == "asc",
+		Limit:       input.Size,
+	}
+	if input.MinAmount != nil {
+		minAmount, err := models.ParseMoney(*input.MinAmount)
+		if err != nil || minAmount < 0 {
+			return nil, &server_errors.InvalidInput
+		}
+		filter.MinAmount = &minAmount
+	}
+	if input.MaxAmount != nil {
+		maxAmount, err := models.ParseMoney(*input.MaxAmount)
+		if err != nil || maxAmount < 0 {
+			return nil, &server_errors.InvalidInput
+		}
+		filter.MaxAmount = &maxAmount
+	}
+	if input.To != nil {
+		// "to" is a day, including all of it means comparing against the next midnight
+		to := input.To.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	if input.Paging == "cursor" {
+		return s.listByCursor(ctx, &filter, input.Cursor)
+	}
+
+	filter.Offset = input.Page * input.Size
+	transactions, err := s.transactionRepo.List(ctx, &filter)
+	if err != nil {
+		utils.Logger.Errorf("transactionService.List - Calling transactionRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalCount, err := s.transactionRepo.Count(ctx, &filter)
+	if err != nil {
+		utils.Logger.Errorf("transactionService.List - Calling transactionRepo.Count: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(input.Size)))
+	remainingPages := int(math.Max(float64(totalPages-input.Page-1), 0))
+
+	var response dto.TransactionListResponse
+	response.Pagination = &dto.PaginationData{
+		PageNumber:     input.Page,
+		PageSize:       input.Size,
+		TotalRecord:    totalCount,
+		RemainingPages: remainingPages,
+	}
+	response.Transactions = transactions
+
+	return &response, nil
+}
+
+// listByCursor pages with keyset pagination, an extra row is fetched to know
+// whether a next cursor should be returned without running a count query.
+func (s *transactionService) listByCursor(ctx context.Context, filter *dto.TransactionListFilter, cursor string) (*dto.TransactionListResponse, error) {
+	if cursor != "" {
+		after, err := decodeTransactionCursor(cursor)
+		if err != nil {
+			utils.Logger.Infof("transactionService.listByCursor - Decoding cursor: %s", err.Error())
+			return nil, &server_errors.InvalidInput
+		}
+		filter.After = after
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+	transactions, err := s.transactionRepo.List(ctx, filter)
+	if err != nil {
+		utils.Logger.Errorf("transactionService.listByCursor - Calling transactionRepo.List: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	var response dto.TransactionListResponse
+	if len(*transactions) > pageSize {
+		page := (*transactions)[:pageSize]
+		last := page[len(page)-1]
+		nextCursor, err := encodeTransactionCursor(&dto.TransactionCursor{Date: last.Date, Amount: last.Amount.Abs(), ID: last.ID})
+		if err != nil {
+			utils.Logger.Errorf("transactionService.listByCursor - Encoding cursor: %s", err.Error())
+			return nil, &server_errors.InternalError
+		}
+		response.NextCursor = &nextCursor
+		transactions = &page
+	}
+	response.Transactions = transactions
+
+	return &response, nil
+}
+
+func encodeTransactionCursor(cursor *dto.TransactionCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeTransactionCursor(cursor string) (*dto.TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var result dto.TransactionCursor
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *transactionService) Update(ctx context.Context, input *dto.TransactionUpdateRequest, id int, transactionType enums.TransactionType, userID uuid.UUID) (*dto.TransactionJoinedResponse, error) {
+	var transaction models.Transaction
+	transaction.ID = id
+	transaction.UserID = userID
+	transaction.TransactionType = transactionType
+	transaction.AccountID = input.AccountID
+	transaction.CategoryID = input.CategoryID
+	transaction.Description = input.Description
+	if input.Amount != nil {
+		amount := signedAmount(*input.Amount, transactionType)
+		transaction.Amount = &amount
+	}
+	if input.Date != nil {
+		date := input.Date.UTC().Truncate(time.Second)
+		transaction.TransactionDate = &date
+	}
+
+	result, err := s.transactionRepo.Update(ctx, &transaction)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("transactionService.Update - Calling transactionRepo.Update: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return result, nil
+}
+
+func (s *transactionService) Delete(ctx context.Context, id int, transactionType enums.TransactionType, userID uuid.UUID) error {
+	if err := s.transactionRepo.Delete(ctx, id, transactionType, userID); err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return pgErr
+		}
+
+		utils.Logger.Errorf("transactionService.Delete - Calling transactionRepo.Delete: %s", err.Error())
+		return &server_errors.InternalError
+	}
+
+	return nil
+}
+
+func (s *transactionService) Reverse(ctx context.Context, id int, userID uuid.UUID) (*dto.TransactionReversalResponse, error) {
+	result, err := s.transactionRepo.Reverse(ctx, id, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("transactionService.Reverse - Calling transactionRepo.Reverse: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	return result, nil
+}
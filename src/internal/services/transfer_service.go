@@ -17,28 +17,82 @@ type TransferService interface {
 }
 
 type transferService struct {
-	transactionRepo repositories.TransactionRepository
+	transactionRepo  repositories.TransactionRepository
+	accountRepo      repositories.AccountRepository
+	exchangeRateRepo repositories.ExchangeRateRepository
 }
 
-func NewTransferService(transferRepo repositories.TransactionRepository) TransferService {
+func NewTransferService(transferRepo repositories.TransactionRepository, accountRepo repositories.AccountRepository, exchangeRateRepo repositories.ExchangeRateRepository) TransferService {
 	return &transferService{
-		transactionRepo: transferRepo,
+		transactionRepo:  transferRepo,
+		accountRepo:      accountRepo,
+		exchangeRateRepo: exchangeRateRepo,
 	}
 }
 
 func (s *transferService) Transfer(ctx context.Context, input *dto.TransferRequest, userID uuid.UUID) (*dto.AccountTransferResult, error) {
-    result, err := s.transactionRepo.Transfer(ctx, input.From, input.Dest, input.Amount, userID)
-    if err != nil {
-        if errors.Is(err, sql.ErrNoRows){
-            return nil, &server_errors.ItemNotFound
-        }
-
-        if pgErr := server_errors.AsPgError(err); pgErr != nil {
-            return nil, pgErr
-        }
-
-        utils.Logger.Errorf("transferService.Transfer - Calling transactionRepo.Transfer: %s", err.Error())
-        return nil, &server_errors.InternalError
-    }
+	rate, err := s.transferRate(ctx, input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.transactionRepo.Transfer(ctx, input.From, input.Dest, input.Amount, rate, userID)
+	if err != nil {
+		var sErr *server_errors.SError
+		if errors.As(err, &sErr) {
+			return nil, sErr
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+
+		if pgErr := server_errors.AsPgError(err); pgErr != nil {
+			return nil, pgErr
+		}
+
+		utils.Logger.Errorf("transferService.Transfer - Calling transactionRepo.Transfer: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
 	return result, nil
 }
+
+// transferRate returns nil when both accounts share a currency, otherwise the
+// rate given by the user or the one saved for the currency pair.
+func (s *transferService) transferRate(ctx context.Context, input *dto.TransferRequest, userID uuid.UUID) (*string, error) {
+	from, err := s.accountRepo.GetByID(ctx, input.From, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("transferService.transferRate - Calling accountRepo.GetByID(%d): %s", input.From, err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	dest, err := s.accountRepo.GetByID(ctx, input.Dest, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ItemNotFound
+		}
+		utils.Logger.Errorf("transferService.transferRate - Calling accountRepo.GetByID(%d): %s", input.Dest, err.Error())
+		return nil, &server_errors.InternalError
+	}
+
+	if from.Currency == dest.Currency {
+		return nil, nil
+	}
+
+	if input.Rate != nil {
+		return input.Rate, nil
+	}
+
+	rate, err := s.exchangeRateRepo.GetRate(ctx, from.Currency, dest.Currency, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server_errors.ExchangeRateNotFound
+		}
+		utils.Logger.Errorf("transferService.transferRate - Calling exchangeRateRepo.GetRate: %s", err.Error())
+		return nil, &server_errors.InternalError
+	}
+	return &rate, nil
+}
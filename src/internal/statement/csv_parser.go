@@ -0,0 +1,209 @@
+package statement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CSVMapping tells which columns of a CSV statement hold what. Columns are
+// header names when HasHeader is set and 1-based positions otherwise. Either
+// AmountColumn holds signed amounts or DebitColumn and CreditColumn hold the
+// outgoing and incoming ones.
+type CSVMapping struct {
+	Delimiter         string
+	HasHeader         bool
+	DateColumn        string
+	DateFormat        string
+	DescriptionColumn string
+	AmountColumn      string
+	DebitColumn       string
+	CreditColumn      string
+	DecimalSeparator  string
+}
+
+type csvParser struct {
+	mapping CSVMapping
+}
+
+func NewCSVParser(mapping CSVMapping) Parser {
+	if mapping.Delimiter == "" {
+		mapping.Delimiter = ","
+	}
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = "YYYY-MM-DD"
+	}
+	if mapping.DecimalSeparator == "" {
+		mapping.DecimalSeparator = "."
+	}
+	return &csvParser{mapping: mapping}
+}
+
+type csvColumns struct {
+	date, description, amount, debit, credit int
+}
+
+func (p *csvParser) Parse(r io.Reader) ([]Row, error) {
+	layout, err := DateLayout(p.mapping.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(p.mapping.Delimiter)
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	if p.mapping.HasHeader {
+		if header, err = reader.Read(); err != nil {
+			return nil, &ParseError{Line: 1, Err: err}
+		}
+		// Excel adds a byte order mark to UTF-8 exports
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+	}
+
+	columns, err := p.columns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, &ParseError{Line: line, Err: err}
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		row, err := p.row(record, columns, layout)
+		if err != nil {
+			return nil, &ParseError{Line: line, Err: err}
+		}
+		row.Line = line
+		rows = append(rows, *row)
+		if len(rows) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+	return rows, nil
+}
+
+func (p *csvParser) columns(header []string) (*csvColumns, error) {
+	var columns csvColumns
+	var err error
+	if columns.date, err = columnIndex(header, p.mapping.DateColumn, true); err != nil {
+		return nil, err
+	}
+	if columns.description, err = columnIndex(header, p.mapping.DescriptionColumn, true); err != nil {
+		return nil, err
+	}
+	if columns.amount, err = columnIndex(header, p.mapping.AmountColumn, false); err != nil {
+		return nil, err
+	}
+	if columns.debit, err = columnIndex(header, p.mapping.DebitColumn, false); err != nil {
+		return nil, err
+	}
+	if columns.credit, err = columnIndex(header, p.mapping.CreditColumn, false); err != nil {
+		return nil, err
+	}
+	if columns.amount < 0 && (columns.debit < 0 || columns.credit < 0) {
+		return nil, errors.New("either an amount column or both debit and credit columns are required")
+	}
+	return &columns, nil
+}
+
+// columnIndex returns -1 for an optional column that is not set.
+func columnIndex(header []string, column string, required bool) (int, error) {
+	column = strings.TrimSpace(column)
+	if column == "" {
+		if required {
+			return -1, errors.New("date and description columns are required")
+		}
+		return -1, nil
+	}
+
+	if header == nil {
+		position, err := strconv.Atoi(column)
+		if err != nil || position < 1 {
+			return -1, fmt.Errorf("column %q must be a position starting from 1", column)
+		}
+		return position - 1, nil
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("column %q was not found in the header", column)
+}
+
+func (p *csvParser) row(record []string, columns *csvColumns, layout string) (*Row, error) {
+	field := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	date, err := time.Parse(layout, field(columns.date))
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", field(columns.date))
+	}
+
+	var row Row
+	row.Date = date
+	row.Description = collapseSpaces(field(columns.description))
+
+	if columns.amount >= 0 {
+		if row.Amount, err = ParseAmount(field(columns.amount), p.mapping.DecimalSeparator); err != nil {
+			return nil, fmt.Errorf("invalid amount %q", field(columns.amount))
+		}
+		return &row, nil
+	}
+
+	// Banks leave the unused side empty, some write the debit as a negative
+	// number already
+	if debit := field(columns.debit); debit != "" {
+		amount, err := ParseAmount(debit, p.mapping.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid debit %q", debit)
+		}
+		if amount > 0 {
+			amount = -amount
+		}
+		row.Amount += amount
+	}
+	if credit := field(columns.credit); credit != "" {
+		amount, err := ParseAmount(credit, p.mapping.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credit %q", credit)
+		}
+		row.Amount += amount
+	}
+	return &row, nil
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
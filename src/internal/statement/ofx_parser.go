@@ -0,0 +1,117 @@
+package statement
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ofxParser reads both the SGML based OFX 1.x, where leaf elements have no
+// closing tag, and the XML based OFX 2.x by looking at STMTTRN aggregates
+// only.
+type ofxParser struct{}
+
+func NewOFXParser() Parser {
+	return &ofxParser{}
+}
+
+var ofxTagPattern = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+func (p *ofxParser) Parse(r io.Reader) ([]Row, error) {
+	var rows []Row
+	var current map[string]string
+	var startLine int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		for _, match := range ofxTagPattern.FindAllStringSubmatch(scanner.Text(), -1) {
+			closing, tag, value := match[1] == "/", strings.ToUpper(match[2]), strings.TrimSpace(match[3])
+			switch {
+			case tag == "STMTTRN" && !closing:
+				current = make(map[string]string)
+				startLine = line
+			case tag == "STMTTRN" && closing:
+				if current == nil {
+					continue
+				}
+				row, err := ofxRow(current)
+				if err != nil {
+					return nil, &ParseError{Line: startLine, Err: err}
+				}
+				row.Line = startLine
+				rows = append(rows, *row)
+				if len(rows) > MaxRows {
+					return nil, ErrTooManyRows
+				}
+				current = nil
+			case current != nil && !closing && value != "":
+				current[tag] = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, &ParseError{Line: startLine, Err: errors.New("unterminated STMTTRN")}
+	}
+	return rows, nil
+}
+
+func ofxRow(fields map[string]string) (*Row, error) {
+	date, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		return nil, err
+	}
+
+	// The spec uses a dot but a few banks export with their locale separator
+	decimalSeparator := "."
+	if amount := fields["TRNAMT"]; strings.Contains(amount, ",") && !strings.Contains(amount, ".") {
+		decimalSeparator = ","
+	}
+	amount, err := ParseAmount(fields["TRNAMT"], decimalSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRNAMT %q", fields["TRNAMT"])
+	}
+
+	// NAME is limited to 32 characters, banks often repeat it in full in MEMO
+	description := fields["NAME"]
+	if memo := fields["MEMO"]; memo != "" && !strings.EqualFold(memo, description) {
+		if strings.HasPrefix(strings.ToLower(memo), strings.ToLower(description)) {
+			description = memo
+		} else {
+			description += " - " + memo
+		}
+	}
+
+	return &Row{
+		Date:        date,
+		Amount:      amount,
+		Description: collapseSpaces(unescapeOFX(description)),
+		ExternalID:  fields["FITID"],
+	}, nil
+}
+
+// parseOFXDate reads YYYYMMDD optionally followed by HHMMSS, milliseconds and
+// a [offset:TZ] suffix. Only the date part matters for a transaction.
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DTPOSTED %q", value)
+	}
+	return date, nil
+}
+
+func unescapeOFX(value string) string {
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'").Replace(value)
+}
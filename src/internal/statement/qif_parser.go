@@ -0,0 +1,142 @@
+package statement
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// qifParser reads the bank, cash and credit card sections of a QIF file. QIF
+// has no standard date format, US month first dates are tried unless a
+// DateFormat is given.
+type qifParser struct {
+	dateLayouts      []string
+	decimalSeparator string
+}
+
+var qifDefaultDateLayouts = []string{"1/2/2006", "1/2/06", "01/02/2006", "01/02/06", "2006-01-02"}
+
+func NewQIFParser(dateFormat, decimalSeparator string) (Parser, error) {
+	parser := &qifParser{dateLayouts: qifDefaultDateLayouts, decimalSeparator: decimalSeparator}
+	if parser.decimalSeparator == "" {
+		parser.decimalSeparator = "."
+	}
+	if dateFormat != "" {
+		layout, err := DateLayout(dateFormat)
+		if err != nil {
+			return nil, err
+		}
+		parser.dateLayouts = []string{layout}
+	}
+	return parser, nil
+}
+
+func (p *qifParser) Parse(r io.Reader) ([]Row, error) {
+	var rows []Row
+	fields := make(map[byte]string)
+	startLine := 0
+	skipSection := false
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			// Only transaction sections are imported, account lists,
+			// categories and memorized items are skipped
+			section := strings.ToLower(strings.TrimSpace(text))
+			skipSection = !strings.HasPrefix(section, "!type:bank") &&
+				!strings.HasPrefix(section, "!type:cash") &&
+				!strings.HasPrefix(section, "!type:ccard")
+			continue
+		}
+		if skipSection {
+			continue
+		}
+
+		if text[0] == '^' {
+			if len(fields) > 0 {
+				row, err := p.row(fields)
+				if err != nil {
+					return nil, &ParseError{Line: startLine, Err: err}
+				}
+				row.Line = startLine
+				rows = append(rows, *row)
+				if len(rows) > MaxRows {
+					return nil, ErrTooManyRows
+				}
+			}
+			fields = make(map[byte]string)
+			continue
+		}
+
+		if len(fields) == 0 {
+			startLine = line
+		}
+		// Split lines (S, E, $) repeat, the first value is enough here
+		if _, found := fields[text[0]]; !found {
+			fields[text[0]] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		return nil, &ParseError{Line: startLine, Err: errors.New("record is not terminated with ^")}
+	}
+	return rows, nil
+}
+
+func (p *qifParser) row(fields map[byte]string) (*Row, error) {
+	date, err := p.parseDate(fields['D'])
+	if err != nil {
+		return nil, err
+	}
+
+	rawAmount := fields['T']
+	if rawAmount == "" {
+		rawAmount = fields['U']
+	}
+	amount, err := ParseAmount(rawAmount, p.decimalSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", rawAmount)
+	}
+
+	description := fields['P']
+	if memo := fields['M']; memo != "" && !strings.EqualFold(memo, description) {
+		if description == "" {
+			description = memo
+		} else {
+			description += " - " + memo
+		}
+	}
+
+	return &Row{
+		Date:        date,
+		Amount:      amount,
+		Description: collapseSpaces(description),
+		ExternalID:  fields['N'],
+	}, nil
+}
+
+// parseDate also accepts the Quicken styles 1/2'06 and 1/ 2/06.
+func (p *qifParser) parseDate(value string) (time.Time, error) {
+	normalized := strings.ReplaceAll(strings.ReplaceAll(value, "'", "/"), " ", "")
+	for _, layout := range p.dateLayouts {
+		if date, err := time.Parse(layout, normalized); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
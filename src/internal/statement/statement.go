@@ -0,0 +1,119 @@
+// Package statement parses bank statement files into rows that can be
+// previewed and imported as transactions.
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"shirinec.com/src/internal/models"
+)
+
+// MaxRows caps how many transactions a single statement can hold.
+const MaxRows = 5000
+
+var ErrTooManyRows = fmt.Errorf("statement has more than %d transactions", MaxRows)
+
+// Row is a single transaction of a statement. Amount is signed, negative for
+// money leaving the account, and ExternalID is the bank id when the format has
+// one.
+type Row struct {
+	Line        int
+	Date        time.Time
+	Amount      models.Money
+	Description string
+	ExternalID  string
+}
+
+// ParseError points at the part of the file that could not be read.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err.Error())
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parser reads every row of a statement.
+type Parser interface {
+	Parse(r io.Reader) ([]Row, error)
+}
+
+// ParseAmount reads amounts as banks write them: with thousands separators,
+// currency symbols, a trailing minus or parentheses for negative values.
+// decimalSeparator is either "." or ",".
+func ParseAmount(value, decimalSeparator string) (models.Money, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+	if strings.HasSuffix(value, "-") {
+		negative = !negative
+		value = strings.TrimSuffix(value, "-")
+	}
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+
+	var cleaned strings.Builder
+	for _, char := range value {
+		switch {
+		case char >= '0' && char <= '9', char == '-', char == '+':
+			cleaned.WriteRune(char)
+		case string(char) == decimalSeparator:
+			cleaned.WriteRune('.')
+		case string(char) == thousandsSeparator, char == ' ', char == '\'':
+		default:
+			// Currency symbols and codes
+			if char > 127 || (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') || char == '$' {
+				continue
+			}
+			return 0, models.ErrInvalidMoney
+		}
+	}
+
+	// Some banks write more decimals than they use, e.g. 12.500
+	normalized := cleaned.String()
+	if whole, fraction, found := strings.Cut(normalized, "."); found && len(fraction) > 2 {
+		if strings.Trim(fraction[2:], "0") != "" {
+			return 0, models.ErrInvalidMoney
+		}
+		normalized = whole + "." + fraction[:2]
+	}
+
+	amount, err := models.ParseMoney(normalized)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// DateLayout converts a user friendly pattern such as DD/MM/YYYY into a Go
+// time layout.
+func DateLayout(pattern string) (string, error) {
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	layout := replacer.Replace(strings.ToUpper(pattern))
+	if strings.ContainsAny(layout, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return "", errors.New("unsupported date format")
+	}
+	return layout, nil
+}
+
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,311 @@
+package statement
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"shirinec.com/src/internal/models"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value            string
+		decimalSeparator string
+		want             models.Money
+		wantErr          bool
+	}{
+		{value: "12.34", decimalSeparator: ".", want: 1234},
+		{value: "-12.34", decimalSeparator: ".", want: -1234},
+		{value: "1,234.56", decimalSeparator: ".", want: 123456},
+		{value: "1.234,56", decimalSeparator: ",", want: 123456},
+		{value: "1 234,56", decimalSeparator: ",", want: 123456},
+		{value: "1'234.56", decimalSeparator: ".", want: 123456},
+		{value: "$12.00", decimalSeparator: ".", want: 1200},
+		{value: "12.00 EUR", decimalSeparator: ".", want: 1200},
+		{value: "€ 5,10", decimalSeparator: ",", want: 510},
+		{value: "(12.50)", decimalSeparator: ".", want: -1250},
+		{value: "12.50-", decimalSeparator: ".", want: -1250},
+		{value: "12.500", decimalSeparator: ".", want: 1250},
+		{value: "12.505", decimalSeparator: ".", wantErr: true},
+		{value: "", decimalSeparator: ".", wantErr: true},
+		{value: "12#34", decimalSeparator: ".", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseAmount(test.value, test.decimalSeparator)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseAmount(%q, %q) error = %v, want error %t", test.value, test.decimalSeparator, err, test.wantErr)
+			}
+			if err == nil && got != test.want {
+				t.Errorf("ParseAmount(%q, %q) = %d, want %d", test.value, test.decimalSeparator, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDateLayout(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{pattern: "YYYY-MM-DD", want: "2006-01-02"},
+		{pattern: "dd/mm/yyyy", want: "02/01/2006"},
+		{pattern: "MM.DD.YY", want: "01.02.06"},
+		{pattern: "DD MMM YYYY", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			got, err := DateLayout(test.pattern)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("DateLayout(%q) error = %v, want error %t", test.pattern, err, test.wantErr)
+			}
+			if err == nil && got != test.want {
+				t.Errorf("DateLayout(%q) = %q, want %q", test.pattern, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCSVParser(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapping  CSVMapping
+		input    string
+		want     []Row
+		wantLine int
+		wantErr  bool
+	}{
+		{
+			name:    "header with signed amounts",
+			mapping: CSVMapping{HasHeader: true, DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"},
+			input:   "\ufeffDate,Description,Amount\n2024-01-05,Coffee   shop,-3.50\n\n2024-01-06,Salary,1500.00\n",
+			want: []Row{
+				{Line: 2, Date: date(2024, time.January, 5), Amount: -350, Description: "Coffee shop"},
+				{Line: 4, Date: date(2024, time.January, 6), Amount: 150000, Description: "Salary"},
+			},
+		},
+		{
+			name:    "positions with debit and credit",
+			mapping: CSVMapping{Delimiter: ";", DateColumn: "1", DateFormat: "DD.MM.YYYY", DescriptionColumn: "2", DebitColumn: "3", CreditColumn: "4", DecimalSeparator: ","},
+			input:   "05.01.2024;Rent;1.200,00;\n06.01.2024;Refund;;15,25\n07.01.2024;Fee;-2,00;\n",
+			want: []Row{
+				{Line: 1, Date: date(2024, time.January, 5), Amount: -120000, Description: "Rent"},
+				{Line: 2, Date: date(2024, time.January, 6), Amount: 1525, Description: "Refund"},
+				{Line: 3, Date: date(2024, time.January, 7), Amount: -200, Description: "Fee"},
+			},
+		},
+		{
+			name:    "header names ignore case",
+			mapping: CSVMapping{HasHeader: true, DateColumn: "date", DescriptionColumn: "memo", AmountColumn: "amount"},
+			input:   "DATE,MEMO,AMOUNT\n2024-02-01,Books,-20\n",
+			want:    []Row{{Line: 2, Date: date(2024, time.February, 1), Amount: -2000, Description: "Books"}},
+		},
+		{
+			name:    "missing header column",
+			mapping: CSVMapping{HasHeader: true, DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Value"},
+			input:   "Date,Description,Amount\n",
+			wantErr: true,
+		},
+		{
+			name:    "no amount columns",
+			mapping: CSVMapping{DateColumn: "1", DescriptionColumn: "2"},
+			input:   "2024-01-05,Coffee\n",
+			wantErr: true,
+		},
+		{
+			name:     "invalid date",
+			mapping:  CSVMapping{DateColumn: "1", DescriptionColumn: "2", AmountColumn: "3"},
+			input:    "2024-01-05,Coffee,-3.50\n05/01/2024,Tea,-2.00\n",
+			wantLine: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid amount",
+			mapping:  CSVMapping{DateColumn: "1", DescriptionColumn: "2", AmountColumn: "3"},
+			input:    "2024-01-05,Coffee,abc#\n",
+			wantLine: 1,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := NewCSVParser(test.mapping).Parse(strings.NewReader(test.input))
+			checkRows(t, rows, err, test.want, test.wantLine, test.wantErr)
+		})
+	}
+}
+
+func TestOFXParser(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     []Row
+		wantLine int
+		wantErr  bool
+	}{
+		{
+			name: "SGML without closing tags",
+			input: `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240105120000.000[-5:EST]
+<TRNAMT>-3.50
+<FITID>A1
+<NAME>Coffee &amp; cake
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240106
+<TRNAMT>1500,00
+<FITID>A2
+<NAME>Salary
+<MEMO>January
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`,
+			want: []Row{
+				{Line: 5, Date: date(2024, time.January, 5), Amount: -350, Description: "Coffee & cake", ExternalID: "A1"},
+				{Line: 12, Date: date(2024, time.January, 6), Amount: 150000, Description: "Salary - January", ExternalID: "A2"},
+			},
+		},
+		{
+			name: "XML on one line with the full name in MEMO",
+			input: `<?xml version="1.0"?><OFX><BANKTRANLIST>` +
+				`<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240210</DTPOSTED><TRNAMT>-42.00</TRNAMT><FITID>B1</FITID>` +
+				`<NAME>SUPERMARKET NORTH BRANCH 0012</NAME><MEMO>Supermarket North Branch 0012 card 1234</MEMO></STMTTRN>` +
+				`</BANKTRANLIST></OFX>`,
+			want: []Row{
+				{Line: 1, Date: date(2024, time.February, 10), Amount: -4200, Description: "Supermarket North Branch 0012 card 1234", ExternalID: "B1"},
+			},
+		},
+		{
+			name:     "invalid date",
+			input:    "<STMTTRN>\n<DTPOSTED>2024\n<TRNAMT>1.00\n</STMTTRN>\n",
+			wantLine: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "unterminated transaction",
+			input:    "<STMTTRN>\n<DTPOSTED>20240101\n<TRNAMT>1.00\n",
+			wantLine: 1,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := NewOFXParser().Parse(strings.NewReader(test.input))
+			checkRows(t, rows, err, test.want, test.wantLine, test.wantErr)
+		})
+	}
+}
+
+func TestQIFParser(t *testing.T) {
+	tests := []struct {
+		name       string
+		dateFormat string
+		input      string
+		want       []Row
+		wantLine   int
+		wantErr    bool
+	}{
+		{
+			name: "bank section with US dates",
+			input: "!Type:Bank\r\nD1/5/2024\r\nT-3.50\r\nPCoffee\r\nN101\r\n^\r\n" +
+				"D1/ 6'24\r\nU1,500.00\r\nPEmployer\r\nMSalary\r\n^\r\n",
+			want: []Row{
+				{Line: 2, Date: date(2024, time.January, 5), Amount: -350, Description: "Coffee", ExternalID: "101"},
+				{Line: 7, Date: date(2024, time.January, 6), Amount: 150000, Description: "Employer - Salary"},
+			},
+		},
+		{
+			name:  "skips non transaction sections",
+			input: "!Type:Cat\nNGroceries\nE\n^\n!Type:CCard\nD2024-03-01\nT-9.99\nMStreaming\n^\n",
+			want: []Row{
+				{Line: 6, Date: date(2024, time.March, 1), Amount: -999, Description: "Streaming"},
+			},
+		},
+		{
+			name:       "given date format",
+			dateFormat: "DD/MM/YYYY",
+			input:      "!Type:Bank\nD05/01/2024\nT10.00\nPGift\n^\n",
+			want: []Row{
+				{Line: 2, Date: date(2024, time.January, 5), Amount: 1000, Description: "Gift"},
+			},
+		},
+		{
+			name:     "invalid date",
+			input:    "!Type:Bank\nD31/31/2024\nT1.00\n^\n",
+			wantLine: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "unterminated record",
+			input:    "!Type:Bank\nD1/5/2024\nT1.00\n",
+			wantLine: 2,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := NewQIFParser(test.dateFormat, "")
+			if err != nil {
+				t.Fatalf("NewQIFParser error = %v", err)
+			}
+			rows, err := parser.Parse(strings.NewReader(test.input))
+			checkRows(t, rows, err, test.want, test.wantLine, test.wantErr)
+		})
+	}
+}
+
+func TestParseTooManyRows(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i <= MaxRows; i++ {
+		input.WriteString("2024-01-05,Coffee,-3.50\n")
+	}
+
+	parser := NewCSVParser(CSVMapping{DateColumn: "1", DescriptionColumn: "2", AmountColumn: "3"})
+	if _, err := parser.Parse(strings.NewReader(input.String())); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("Parse error = %v, want %v", err, ErrTooManyRows)
+	}
+}
+
+// checkRows compares the parsed rows, or the line of the ParseError when
+// wantLine is set.
+func checkRows(t *testing.T, rows []Row, err error, want []Row, wantLine int, wantErr bool) {
+	t.Helper()
+	if (err != nil) != wantErr {
+		t.Fatalf("Parse error = %v, want error %t", err, wantErr)
+	}
+	if err != nil {
+		var parseErr *ParseError
+		if wantLine > 0 && (!errors.As(err, &parseErr) || parseErr.Line != wantLine) {
+			t.Errorf("Parse error = %v, want a ParseError on line %d", err, wantLine)
+		}
+		return
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("Parse returned %d rows %+v, want %d %+v", len(rows), rows, len(want), want)
+	}
+	for i := range rows {
+		if rows[i].Line != want[i].Line || !rows[i].Date.Equal(want[i].Date) || rows[i].Amount != want[i].Amount ||
+			rows[i].Description != want[i].Description || rows[i].ExternalID != want[i].ExternalID {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
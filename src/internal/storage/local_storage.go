@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+)
+
+type localStorage struct {
+	root string
+}
+
+// NewLocalStorage keeps the files in the root folder, it only works when a
+// single app instance serves the uploads.
+func NewLocalStorage(root string) (Storage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &localStorage{root: root}, nil
+}
+
+// path rejects keys leaving the root folder.
+func (s *localStorage) path(key string) (string, error) {
+	if !fs.ValidPath(key) {
+		return "", ErrNotExist
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Save writes into a temporary file first, so a failed upload never leaves a
+// partial file behind the key.
+func (s *localStorage) Save(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), target)
+}
+
+func (s *localStorage) Open(ctx context.Context, key string) (*Object, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(target))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Object{ReadCloser: file, Size: info.Size(), ContentType: contentType, ModTime: info.ModTime()}, nil
+}
+
+func (s *localStorage) PresignedURL(ctx context.Context, key string) (string, error) {
+	return "", nil
+}
+
+func (s *localStorage) Remove(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options configures an S3 compatible storage, a zero PresignExpiry streams
+// the files through the app instead of handing out presigned URLs.
+type S3Options struct {
+	Endpoint      string
+	Region        string
+	Bucket        string
+	AccessKey     string
+	SecretKey     string
+	UseSSL        bool
+	PresignExpiry time.Duration
+}
+
+type s3Storage struct {
+	client  *minio.Client
+	options S3Options
+}
+
+// NewS3Storage connects to the object storage and creates the bucket when it
+// does not exist yet, which keeps local MinIO setups free of manual steps.
+func NewS3Storage(ctx context.Context, options S3Options) (Storage, error) {
+	client, err := minio.New(options.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(options.AccessKey, options.SecretKey, ""),
+		Secure: options.UseSSL,
+		Region: options.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, options.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("checking bucket %s: %w", options.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, options.Bucket, minio.MakeBucketOptions{Region: options.Region}); err != nil {
+			return nil, fmt.Errorf("creating bucket %s: %w", options.Bucket, err)
+		}
+	}
+
+	return &s3Storage{client: client, options: options}, nil
+}
+
+func (s *s3Storage) Save(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.options.Bucket, key, reader, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *s3Storage) Open(ctx context.Context, key string) (*Object, error) {
+	object, err := s.client.GetObject(ctx, s.options.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	// GetObject is lazy, Stat is the first request actually reaching the storage
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, s3Error(err)
+	}
+	return &Object{ReadCloser: object, Size: info.Size, ContentType: info.ContentType, ModTime: info.LastModified}, nil
+}
+
+func (s *s3Storage) PresignedURL(ctx context.Context, key string) (string, error) {
+	if s.options.PresignExpiry <= 0 {
+		return "", nil
+	}
+
+	url, err := s.client.PresignedGetObject(ctx, s.options.Bucket, key, s.options.PresignExpiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return url.String(), nil
+}
+
+func (s *s3Storage) Remove(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.options.Bucket, key, minio.RemoveObjectOptions{})
+}
+
+func s3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotExist
+	}
+	return err
+}
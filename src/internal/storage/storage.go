@@ -0,0 +1,59 @@
+// Package storage keeps the uploaded media files outside of the database, on
+// the local disk or in an S3 compatible object storage. Files are addressed by
+// the key saved as the media file_path.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"shirinec.com/config"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var ErrNotExist = errors.New("storage: file does not exist")
+
+// Object is an opened file, it must be closed once read.
+type Object struct {
+	io.ReadCloser
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+type Storage interface {
+	Save(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (*Object, error)
+	// PresignedURL returns a temporary URL the client can read the file from
+	// directly, it is empty when files have to be streamed through the app.
+	PresignedURL(ctx context.Context, key string) (string, error)
+	// Remove does not fail when the file is already gone.
+	Remove(ctx context.Context, key string) error
+}
+
+// New returns the storage selected by the configured driver.
+func New(ctx context.Context, cfg *config.Config) (Storage, error) {
+	switch cfg.StorageDriver {
+	case DriverLocal, "":
+		return NewLocalStorage(cfg.UploadFolder)
+	case DriverS3:
+		return NewS3Storage(ctx, S3Options{
+			Endpoint:      cfg.S3Endpoint,
+			Region:        cfg.S3Region,
+			Bucket:        cfg.S3Bucket,
+			AccessKey:     cfg.S3AccessKey,
+			SecretKey:     cfg.S3SecretKey,
+			UseSSL:        cfg.S3UseSSL,
+			PresignExpiry: cfg.S3PresignExpiry,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
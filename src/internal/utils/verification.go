@@ -1,9 +1,21 @@
 package utils
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
 	"math/rand"
 )
 
 func GenerateVerificationCode() int {
     return rand.Intn(900000) + 100000
 }
+
+// GenerateToken returns a random hex encoded token of size bytes, it is meant
+// for links shared out of band so it uses crypto/rand.
+func GenerateToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := crand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
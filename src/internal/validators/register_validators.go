@@ -26,4 +26,8 @@ func RegisterValidators(validatorObject *validator.Validate) {
 	if err := validatorObject.RegisterValidation("accountType", accountTypeValidator); err != nil {
 		log.Fatalf("[Panic] - RegisterValidators - registering accountTypeValidator")
 	}
+
+	if err := validatorObject.RegisterValidation("transactionType", transactionTypeValidator); err != nil {
+		log.Fatalf("[Panic] - RegisterValidators - registering transactionTypeValidator")
+	}
 }
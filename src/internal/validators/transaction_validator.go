@@ -0,0 +1,20 @@
+package validators
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"shirinec.com/src/internal/enums"
+)
+
+func transactionTypeValidator(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	validValues := []enums.TransactionType{enums.TransactionIncome, enums.TransactionExpense, enums.TransactionTransfer}
+
+	for _, v := range validValues {
+		if value == strings.ToLower(string(v)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+// budgetOverspentThreshold is always alerted on, besides the threshold chosen
+// for the budget.
+const budgetOverspentThreshold = 100
+
+type BudgetAlertWorker interface {
+	CheckThresholds()
+}
+
+type budgetAlertWorker struct {
+	budgetRepo repositories.BudgetRepository
+}
+
+func NewBudgetAlertWorker(budgetRepo *repositories.BudgetRepository) BudgetAlertWorker {
+	return &budgetAlertWorker{budgetRepo: *budgetRepo}
+}
+
+// CheckThresholds records an alert for every active budget that crossed its
+// alert threshold or its limit in the current period. Alerts are unique per
+// budget, period and threshold so each one is raised once however often the
+// worker runs.
+func (w *budgetAlertWorker) CheckThresholds() {
+	utils.Logger.Info("Starting budget alert worker...")
+	ctx := context.Background()
+
+	budgets, err := w.budgetRepo.ListActive(ctx, time.Now().UTC())
+	if err != nil {
+		utils.Logger.Errorf("budgetAlertWorker.CheckThresholds - Calling budgetRepo.ListActive: %s", err.Error())
+		return
+	}
+
+	alertCount := 0
+	for i := range *budgets {
+		budget := &(*budgets)[i]
+		for _, threshold := range []int{budget.AlertThreshold, budgetOverspentThreshold} {
+			if budget.Percent < threshold {
+				continue
+			}
+
+			created, err := w.budgetRepo.CreateAlert(ctx, budget, threshold)
+			if err != nil {
+				utils.Logger.Errorf("budgetAlertWorker.CheckThresholds - Calling budgetRepo.CreateAlert for budget %d: %s", budget.ID, err.Error())
+				continue
+			}
+			if created {
+				alertCount++
+				utils.Logger.Infof("Budget %d of user %s reached %d%% of its limit", budget.ID, budget.UserID, threshold)
+			}
+		}
+	}
+
+	utils.Logger.Infof("%d budget alerts created", alertCount)
+	utils.Logger.Info("Finished budget alert worker process")
+}
@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"shirinec.com/src/internal/dto"
+	"shirinec.com/src/internal/export"
+	"shirinec.com/src/internal/models"
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/storage"
+	"shirinec.com/src/internal/utils"
+)
+
+// exportJobTimeout is how long a job may stay running before another run of
+// the worker considers it abandoned and claims it again.
+const exportJobTimeout = 30 * time.Minute
+
+type ExportWorker interface {
+	RunPending()
+}
+
+type exportWorker struct {
+	exportRepo repositories.ExportRepository
+	storage    storage.Storage
+}
+
+func NewExportWorker(exportRepo *repositories.ExportRepository, mediaStorage storage.Storage) ExportWorker {
+	return &exportWorker{exportRepo: *exportRepo, storage: mediaStorage}
+}
+
+// RunPending saves the files of the pending export jobs into the media
+// storage one job at a time, until none is left.
+func (w *exportWorker) RunPending() {
+	utils.Logger.Info("Starting export worker...")
+	ctx := context.Background()
+
+	completedCount := 0
+	for {
+		job, err := w.exportRepo.ClaimJob(ctx, exportJobTimeout)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				utils.Logger.Errorf("exportWorker.RunPending - Calling exportRepo.ClaimJob: %s", err.Error())
+			}
+			break
+		}
+
+		if err := w.run(ctx, job); err != nil {
+			utils.Logger.Errorf("exportWorker.RunPending - Running export job %d: %s", job.ID, err.Error())
+			if err := w.exportRepo.FailJob(ctx, job.ID, err.Error()); err != nil {
+				utils.Logger.Errorf("exportWorker.RunPending - Calling exportRepo.FailJob for job %d: %s", job.ID, err.Error())
+			}
+			continue
+		}
+		completedCount++
+	}
+
+	utils.Logger.Infof("%d export jobs completed", completedCount)
+	utils.Logger.Info("Finished export worker process")
+}
+
+func (w *exportWorker) run(ctx context.Context, job *models.ExportJob) error {
+	// The storage needs the size upfront, the file is written to a temporary
+	// file first instead of being held in memory
+	file, err := os.CreateTemp("", "export-*")
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	header := dto.ExportHeader{ExportDate: time.Now(), From: job.FromDate, To: job.ToDate}
+	writer, err := export.NewWriter(job.Format, file, &header)
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	if err = w.exportRepo.Stream(ctx, job.UserID, job.FromDate, job.ToDate, writer); err != nil {
+		return fmt.Errorf("reading data: %w", err)
+	}
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	extension := export.FileExtension(job.Format)
+	fileName := fmt.Sprintf("export-%s%s", uuid.New().String(), extension)
+	if err = w.storage.Save(ctx, fileName, file, size, mime.TypeByExtension(extension)); err != nil {
+		return fmt.Errorf("saving file: %w", err)
+	}
+
+	if err = w.exportRepo.CompleteJob(ctx, job, fileName); err != nil {
+		if err := w.storage.Remove(ctx, fileName); err != nil {
+			utils.Logger.Errorf("exportWorker.run - Calling storage.Remove on %s: %s", fileName, err.Error())
+		}
+		return fmt.Errorf("completing job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type LedgerIntegrityWorker interface {
+	CheckBalances()
+}
+
+type ledgerIntegrityWorker struct {
+	reconciliationRepo repositories.ReconciliationRepository
+}
+
+func NewLedgerIntegrityWorker(reconciliationRepo *repositories.ReconciliationRepository) LedgerIntegrityWorker {
+	return &ledgerIntegrityWorker{reconciliationRepo: *reconciliationRepo}
+}
+
+// CheckBalances records the accounts whose stored balance is not the sum of
+// their transactions, they are listed to their users until the balances match
+// again.
+func (w *ledgerIntegrityWorker) CheckBalances() {
+	utils.Logger.Info("Starting ledger integrity worker...")
+
+	drifted, err := w.reconciliationRepo.RecordDiscrepancies(context.Background(), time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		utils.Logger.Errorf("ledgerIntegrityWorker.CheckBalances - Calling reconciliationRepo.RecordDiscrepancies: %s", err.Error())
+		return
+	}
+
+	if drifted > 0 {
+		utils.Logger.Warnf("%d accounts have a balance different from their transactions", drifted)
+	}
+	utils.Logger.Info("Finished ledger integrity worker process")
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"shirinec.com/config"
+	"shirinec.com/src/internal/mediafile"
 	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/storage"
 	"shirinec.com/src/internal/utils"
 )
 
@@ -14,10 +16,11 @@ type MediaCleanupWorker interface {
 
 type mediaCleanupWorker struct {
 	mediaRepo repositories.MediaRepository
+	storage   storage.Storage
 }
 
-func NewMediaCleanupWorker(mediaRepo *repositories.MediaRepository) MediaCleanupWorker {
-	return &mediaCleanupWorker{mediaRepo: *mediaRepo}
+func NewMediaCleanupWorker(mediaRepo *repositories.MediaRepository, mediaStorage storage.Storage) MediaCleanupWorker {
+	return &mediaCleanupWorker{mediaRepo: *mediaRepo, storage: mediaStorage}
 }
 
 func (w *mediaCleanupWorker) CleanupUnusedImages() {
@@ -30,14 +33,20 @@ func (w *mediaCleanupWorker) CleanupUnusedImages() {
         return
 	}
 
-    utils.Logger.Info("Removing orphaned medias from disk...")
+    utils.Logger.Info("Removing orphaned medias from storage...")
     utils.Logger.Infof("%d orphaned media found", len(mediaList))
     for _, media := range mediaList {
-        if err := utils.RemoveMedia(media); err != nil {
-            utils.Logger.Errorf("mediaCleanupWorker.CleanupUnusedImages - Calling utils.RemoveMedia on %s: %s", media, err.Error())
+        keys := []string{media}
+        for _, size := range mediafile.Sizes {
+            keys = append(keys, mediafile.VariantKey(media, size.Name))
+        }
+        for _, key := range keys {
+            if err := w.storage.Remove(context.Background(), key); err != nil {
+                utils.Logger.Errorf("mediaCleanupWorker.CleanupUnusedImages - Calling storage.Remove on %s: %s", key, err.Error())
+            }
         }
     }
-    utils.Logger.Info("Listed medias removed from disk")
+    utils.Logger.Info("Listed medias removed from storage")
 
     if err := w.mediaRepo.DeleteRemovedMedia(context.Background()); err != nil {
         utils.Logger.Errorf("mediaCleanupWorker.CleanupUnusedImages - Calling mediaRepo.DeleteRemovedMedia: %s", err.Error())
@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type NetWorthSnapshotWorker interface {
+	RecordBalances()
+}
+
+type netWorthSnapshotWorker struct {
+	netWorthRepo repositories.NetWorthRepository
+}
+
+func NewNetWorthSnapshotWorker(netWorthRepo *repositories.NetWorthRepository) NetWorthSnapshotWorker {
+	return &netWorthSnapshotWorker{netWorthRepo: *netWorthRepo}
+}
+
+// RecordBalances builds the history of the accounts that have none yet, then
+// updates the snapshot of the current day for every account. Running it more
+// than once a day keeps the snapshot of the day close to the live balance.
+func (w *netWorthSnapshotWorker) RecordBalances() {
+	utils.Logger.Info("Starting net worth snapshot worker...")
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	backfilled, err := w.netWorthRepo.BackfillMissing(ctx, today)
+	if err != nil {
+		utils.Logger.Errorf("netWorthSnapshotWorker.RecordBalances - Calling netWorthRepo.BackfillMissing: %s", err.Error())
+	}
+
+	recorded, err := w.netWorthRepo.Snapshot(ctx, today)
+	if err != nil {
+		utils.Logger.Errorf("netWorthSnapshotWorker.RecordBalances - Calling netWorthRepo.Snapshot: %s", err.Error())
+		return
+	}
+
+	utils.Logger.Infof("%d account balances recorded, %d snapshots backfilled", recorded, backfilled)
+	utils.Logger.Info("Finished net worth snapshot worker process")
+}
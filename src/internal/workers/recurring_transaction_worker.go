@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type RecurringTransactionWorker interface {
+	MaterializeDue()
+}
+
+type recurringTransactionWorker struct {
+	recurringTransactionRepo repositories.RecurringTransactionRepository
+}
+
+func NewRecurringTransactionWorker(recurringTransactionRepo *repositories.RecurringTransactionRepository) RecurringTransactionWorker {
+	return &recurringTransactionWorker{recurringTransactionRepo: *recurringTransactionRepo}
+}
+
+// MaterializeDue creates the transactions of every occurrence that fell due
+// since the last run, including the ones missed while the app was down. Rules
+// are handled one database transaction at a time so a failing rule, for
+// example one whose account access was revoked, does not block the others and
+// is retried on the next run.
+func (w *recurringTransactionWorker) MaterializeDue() {
+	utils.Logger.Info("Starting recurring transaction worker...")
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	ids, err := w.recurringTransactionRepo.ListDue(ctx, today)
+	if err != nil {
+		utils.Logger.Errorf("recurringTransactionWorker.MaterializeDue - Calling recurringTransactionRepo.ListDue: %s", err.Error())
+		return
+	}
+
+	createdCount := 0
+	for _, id := range ids {
+		created, err := w.recurringTransactionRepo.Materialize(ctx, id, today)
+		if err != nil {
+			utils.Logger.Errorf("recurringTransactionWorker.MaterializeDue - Calling recurringTransactionRepo.Materialize for rule %d: %s", id, err.Error())
+			continue
+		}
+		createdCount += created
+	}
+
+	utils.Logger.Infof("%d recurring transactions created from %d due rules", createdCount, len(ids))
+	utils.Logger.Info("Finished recurring transaction worker process")
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/utils"
+)
+
+type ScheduledTransferWorker interface {
+	ExecuteDue()
+}
+
+type scheduledTransferWorker struct {
+	scheduledTransferRepo repositories.ScheduledTransferRepository
+}
+
+func NewScheduledTransferWorker(scheduledTransferRepo *repositories.ScheduledTransferRepository) ScheduledTransferWorker {
+	return &scheduledTransferWorker{scheduledTransferRepo: *scheduledTransferRepo}
+}
+
+// ExecuteDue runs the scheduled transfers that fell due since the last run.
+// Every executed occurrence is recorded in scheduled_transfer_runs as
+// succeeded or failed, transfers hitting unexpected errors are left untouched
+// and retried on the next run.
+func (w *scheduledTransferWorker) ExecuteDue() {
+	utils.Logger.Info("Starting scheduled transfer worker...")
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	ids, err := w.scheduledTransferRepo.ListDue(ctx, today)
+	if err != nil {
+		utils.Logger.Errorf("scheduledTransferWorker.ExecuteDue - Calling scheduledTransferRepo.ListDue: %s", err.Error())
+		return
+	}
+
+	runCount := 0
+	for _, id := range ids {
+		recorded, err := w.scheduledTransferRepo.ExecuteDue(ctx, id, today)
+		if err != nil {
+			utils.Logger.Errorf("scheduledTransferWorker.ExecuteDue - Calling scheduledTransferRepo.ExecuteDue for transfer %d: %s", id, err.Error())
+			continue
+		}
+		runCount += recorded
+	}
+
+	utils.Logger.Infof("%d scheduled transfer runs recorded from %d due transfers", runCount, len(ids))
+	utils.Logger.Info("Finished scheduled transfer worker process")
+}
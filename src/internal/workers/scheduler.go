@@ -6,18 +6,55 @@ import (
 	"github.com/robfig/cron/v3"
 	"shirinec.com/config"
 	"shirinec.com/src/internal/repositories"
+	"shirinec.com/src/internal/storage"
 	"shirinec.com/src/internal/utils"
 )
 
-func ScheduleWorkers(mediaRepo repositories.MediaRepository) {
+func ScheduleWorkers(mediaRepo repositories.MediaRepository, budgetRepo repositories.BudgetRepository, recurringTransactionRepo repositories.RecurringTransactionRepository, scheduledTransferRepo repositories.ScheduledTransferRepository, exportRepo repositories.ExportRepository, netWorthRepo repositories.NetWorthRepository, reconciliationRepo repositories.ReconciliationRepository, mediaStorage storage.Storage) {
 	c := cron.New()
 
-	mediaCleaner := NewMediaCleanupWorker(&mediaRepo)
+	mediaCleaner := NewMediaCleanupWorker(&mediaRepo, mediaStorage)
     mediaCleanerTimer := fmt.Sprintf("@every %s", config.AppConfig.MediaCleanerInterval)
 	_, err := c.AddFunc(mediaCleanerTimer, mediaCleaner.CleanupUnusedImages)
 	if err != nil {
 		utils.Logger.Fatalf("ScheduleWorkers - Adding media.Cleaner.CleanupUnusedImages: %s", err.Error())
 	}
 
+	budgetAlert := NewBudgetAlertWorker(&budgetRepo)
+	budgetAlertTimer := fmt.Sprintf("@every %s", config.AppConfig.BudgetAlertInterval)
+	if _, err = c.AddFunc(budgetAlertTimer, budgetAlert.CheckThresholds); err != nil {
+		utils.Logger.Fatalf("ScheduleWorkers - Adding budgetAlert.CheckThresholds: %s", err.Error())
+	}
+
+	recurringTransaction := NewRecurringTransactionWorker(&recurringTransactionRepo)
+	recurringTransactionTimer := fmt.Sprintf("@every %s", config.AppConfig.RecurringInterval)
+	if _, err = c.AddFunc(recurringTransactionTimer, recurringTransaction.MaterializeDue); err != nil {
+		utils.Logger.Fatalf("ScheduleWorkers - Adding recurringTransaction.MaterializeDue: %s", err.Error())
+	}
+
+	scheduledTransfer := NewScheduledTransferWorker(&scheduledTransferRepo)
+	scheduledTransferTimer := fmt.Sprintf("@every %s", config.AppConfig.TransferInterval)
+	if _, err = c.AddFunc(scheduledTransferTimer, scheduledTransfer.ExecuteDue); err != nil {
+		utils.Logger.Fatalf("ScheduleWorkers - Adding scheduledTransfer.ExecuteDue: %s", err.Error())
+	}
+
+	exportWorker := NewExportWorker(&exportRepo, mediaStorage)
+	exportTimer := fmt.Sprintf("@every %s", config.AppConfig.ExportInterval)
+	if _, err = c.AddFunc(exportTimer, exportWorker.RunPending); err != nil {
+		utils.Logger.Fatalf("ScheduleWorkers - Adding exportWorker.RunPending: %s", err.Error())
+	}
+
+	netWorthSnapshot := NewNetWorthSnapshotWorker(&netWorthRepo)
+	netWorthSnapshotTimer := fmt.Sprintf("@every %s", config.AppConfig.NetWorthInterval)
+	if _, err = c.AddFunc(netWorthSnapshotTimer, netWorthSnapshot.RecordBalances); err != nil {
+		utils.Logger.Fatalf("ScheduleWorkers - Adding netWorthSnapshot.RecordBalances: %s", err.Error())
+	}
+
+	ledgerIntegrity := NewLedgerIntegrityWorker(&reconciliationRepo)
+	ledgerIntegrityTimer := fmt.Sprintf("@every %s", config.AppConfig.IntegrityInterval)
+	if _, err = c.AddFunc(ledgerIntegrityTimer, ledgerIntegrity.CheckBalances); err != nil {
+		utils.Logger.Fatalf("ScheduleWorkers - Adding ledgerIntegrity.CheckBalances: %s", err.Error())
+	}
+
     c.Start()
 }